@@ -0,0 +1,20 @@
+package pattern
+
+// Curated alphabets for OneOfByte, NanoID and SequenceBase.
+// The slices are shared and must not be modified.
+var (
+	// AlphaLower holds the lowercase ASCII letters.
+	AlphaLower = []byte("abcdefghijklmnopqrstuvwxyz")
+	// AlphaUpper holds the uppercase ASCII letters.
+	AlphaUpper = []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	// Digits holds the decimal digits.
+	Digits = []byte("0123456789")
+	// AlphaNumeric holds the ASCII letters and decimal digits.
+	AlphaNumeric = []byte("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+	// Hex holds the lowercase hexadecimal digits.
+	Hex = []byte(hexDigits)
+	// URLSafe64 holds the URL-safe base64 alphabet of RFC 4648 in encoding order.
+	URLSafe64 = []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_")
+	// Unambiguous holds the ASCII letters and digits without the easily confused 0, O, 1, l and I.
+	Unambiguous = []byte("23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+)
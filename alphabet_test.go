@@ -0,0 +1,39 @@
+package pattern
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAlphabets(t *testing.T) {
+	tests := []struct {
+		name     string
+		alphabet []byte
+		len      int
+	}{
+		{"AlphaLower", AlphaLower, 26},
+		{"AlphaUpper", AlphaUpper, 26},
+		{"Digits", Digits, 10},
+		{"AlphaNumeric", AlphaNumeric, 62},
+		{"Hex", Hex, 16},
+		{"URLSafe64", URLSafe64, 64},
+		{"Unambiguous", Unambiguous, 57},
+	}
+
+	for _, test := range tests {
+		if len(test.alphabet) != test.len {
+			t.Errorf("%s has %d characters, want %d", test.name, len(test.alphabet), test.len)
+		}
+		seen := map[byte]bool{}
+		for _, c := range test.alphabet {
+			if seen[c] {
+				t.Errorf("%s contains %q twice", test.name, c)
+			}
+			seen[c] = true
+		}
+	}
+
+	if bytes.ContainsAny(Unambiguous, "0O1lI") {
+		t.Errorf("Unambiguous contains ambiguous characters")
+	}
+}
@@ -0,0 +1,37 @@
+// Package alphabets provides rune alphabets of scripts and locales for pattern.OneOfRune.
+//
+// The slices are shared and must not be modified.
+package alphabets
+
+var (
+	// Hiragana holds the 46 basic hiragana without voiced or small kana.
+	Hiragana = []rune("あいうえおかきくけこさしすせそたちつてとなにぬねのはひふへほまみむめもやゆよらりるれろわをん")
+	// Katakana holds the 46 basic katakana without voiced or small kana.
+	Katakana = []rune("アイウエオカキクケコサシスセソタチツテトナニヌネノハヒフヘホマミムメモヤユヨラリルレロワヲン")
+	// FullWidthDigits holds the full-width digits used in Japanese and Chinese text.
+	FullWidthDigits = []rune("０１２３４５６７８９")
+	// CyrillicLower holds the 33 lowercase letters of the Russian alphabet.
+	CyrillicLower = []rune("абвгдеёжзийклмнопрстуфхцчшщъыьэюя")
+	// CyrillicUpper holds the 33 uppercase letters of the Russian alphabet.
+	CyrillicUpper = []rune("АБВГДЕЁЖЗИЙКЛМНОПРСТУФХЦЧШЩЪЫЬЭЮЯ")
+	// GreekLower holds the 24 lowercase letters of the Greek alphabet without the final sigma.
+	GreekLower = []rune("αβγδεζηθικλμνξοπρστυφχψω")
+	// GreekUpper holds the 24 uppercase letters of the Greek alphabet.
+	GreekUpper = []rune("ΑΒΓΔΕΖΗΘΙΚΛΜΝΞΟΠΡΣΤΥΦΧΨΩ")
+	// Arabic holds the 28 basic letters of the Arabic alphabet in their isolated form.
+	Arabic = []rune("ابتثجحخدذرزسشصضطظعغفقكلمنهوي")
+	// Hebrew holds the 22 letters of the Hebrew alphabet without final forms.
+	Hebrew = []rune("אבגדהוזחטיכלמנסעפצקרשת")
+	// HangulSyllables holds all 11172 precomposed Hangul syllables from U+AC00 to U+D7A3.
+	// pattern.OneOfRuneRange(0xAC00, 0xD7A3) selects from them without materializing the alphabet.
+	HangulSyllables = runeRange(0xAC00, 0xD7A3)
+)
+
+// runeRange returns the runes from lo to hi inclusive.
+func runeRange(lo rune, hi rune) []rune {
+	r := make([]rune, 0, hi-lo+1)
+	for c := lo; c <= hi; c++ {
+		r = append(r, c)
+	}
+	return r
+}
@@ -0,0 +1,45 @@
+package alphabets
+
+import (
+	"testing"
+	"unicode"
+)
+
+func TestAlphabets(t *testing.T) {
+	tests := []struct {
+		name     string
+		alphabet []rune
+		len      int
+		is       func(rune) bool
+	}{
+		{"Hiragana", Hiragana, 46, func(r rune) bool { return unicode.Is(unicode.Hiragana, r) }},
+		{"Katakana", Katakana, 46, func(r rune) bool { return unicode.Is(unicode.Katakana, r) }},
+		{"FullWidthDigits", FullWidthDigits, 10, unicode.IsDigit},
+		{"CyrillicLower", CyrillicLower, 33, func(r rune) bool { return unicode.Is(unicode.Cyrillic, r) && unicode.IsLower(r) }},
+		{"CyrillicUpper", CyrillicUpper, 33, func(r rune) bool { return unicode.Is(unicode.Cyrillic, r) && unicode.IsUpper(r) }},
+		{"GreekLower", GreekLower, 24, func(r rune) bool { return unicode.Is(unicode.Greek, r) && unicode.IsLower(r) }},
+		{"GreekUpper", GreekUpper, 24, func(r rune) bool { return unicode.Is(unicode.Greek, r) && unicode.IsUpper(r) }},
+		{"Arabic", Arabic, 28, func(r rune) bool { return unicode.Is(unicode.Arabic, r) && unicode.IsLetter(r) }},
+		{"Hebrew", Hebrew, 22, func(r rune) bool { return unicode.Is(unicode.Hebrew, r) && unicode.IsLetter(r) }},
+		{"HangulSyllables", HangulSyllables, 11172, func(r rune) bool { return unicode.Is(unicode.Hangul, r) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.alphabet) != tt.len {
+				t.Errorf("invalid number of runes: want %d, got %d", tt.len, len(tt.alphabet))
+			}
+
+			seen := make(map[rune]bool, len(tt.alphabet))
+			for _, r := range tt.alphabet {
+				if !tt.is(r) {
+					t.Errorf("invalid rune %q", r)
+				}
+				if seen[r] {
+					t.Errorf("duplicate rune %q", r)
+				}
+				seen[r] = true
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package pattern
+
+import (
+	"sort"
+)
+
+// AtLeastOneOf returns a Part that outputs between min and max elements in random order,
+// where at least one element is generated by each of classes, e.g. for passwords that must contain
+// an upper case letter, a lower case letter, a digit and a symbol:
+//
+//	AtLeastOneOf(12, 16, OneOfByte(AlphaUpper), OneOfByte(AlphaLower), OneOfByte(Digits), OneOfByte([]byte("!#$%&*+-=?@")))
+//
+// The remaining elements are generated by a random class.
+// If the outputs of all classes can be counted, the classes are weighted by their number of outputs,
+// so every element of their union is equally likely; otherwise each class is equally likely.
+//
+// Panics if classes is empty, min is less than the number of classes or max < min.
+func AtLeastOneOf(min uint32, max uint32, classes ...Part) Part {
+	if len(classes) == 0 {
+		panic("classes must not be empty")
+	}
+
+	if min < uint32(len(classes)) {
+		panic("min must be >= the number of classes")
+	}
+
+	if max < min {
+		panic("max must be >= min")
+	}
+
+	return atLeastOneOf{
+		classes: classes,
+		min:     min,
+		maxr:    (max - min) + 1,
+		weights: classWeights(classes),
+	}
+}
+
+type atLeastOneOf struct {
+	classes []Part
+	min     uint32
+	// maxr is the value needed to generate [min, max] with the RNG.
+	maxr uint32
+	// weights holds the cumulative number of outputs of the classes or is nil if they are chosen uniformly.
+	weights []uint64
+}
+
+// classWeights returns the cumulative number of outputs of classes or nil if they can not be counted.
+func classWeights(classes []Part) []uint64 {
+	weights := make([]uint64, len(classes))
+	total := uint64(0)
+	for i, c := range classes {
+		if !canCount(c) {
+			return nil
+		}
+
+		n, ok := c.(countable).count()
+		if !ok {
+			return nil
+		}
+		if total, ok = addCount(total, n); !ok {
+			return nil
+		}
+		weights[i] = total
+	}
+
+	if total == 0 {
+		return nil
+	}
+	return weights
+}
+
+func (p atLeastOneOf) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p atLeastOneOf) appendRun(r *run, b []byte) []byte {
+	defer r.restoreLimit(r.suspendLimit())
+	n := int(r.randN(p.maxr) + p.min)
+	start := len(b)
+
+	// offsets holds the start of each element and the end of the last one.
+	var buf [33]int
+	offsets := append(buf[:0], start)
+	for i := 0; i < n; i++ {
+		b = appendRun(r, p.class(r, i), b)
+		offsets = append(offsets, len(b))
+	}
+
+	// Shuffle the elements, so the required ones are not always in front.
+	var idxBuf [32]int
+	idx := idxBuf[:0]
+	for i := 0; i < n; i++ {
+		idx = append(idx, i)
+	}
+	for i := n - 1; i > 0; i-- {
+		j := r.randN(uint32(i + 1))
+		idx[i], idx[j] = idx[j], idx[i]
+	}
+
+	// The elements are moved through a copy, since they may have different lengths.
+	src := append([]byte(nil), b[start:]...)
+	b = b[:start]
+	for _, i := range idx {
+		b = append(b, src[offsets[i]-start:offsets[i+1]-start]...)
+	}
+	return b
+}
+
+// class returns the class of the i-th element.
+func (p atLeastOneOf) class(r *run, i int) Part {
+	if i < len(p.classes) {
+		return p.classes[i]
+	}
+
+	if p.weights == nil {
+		return p.classes[r.randN(uint32(len(p.classes)))]
+	}
+
+	v := r.uint64N(p.weights[len(p.weights)-1])
+	return p.classes[sort.Search(len(p.weights), func(j int) bool {
+		return v < p.weights[j]
+	})]
+}
+
+func (p atLeastOneOf) Children() []Part {
+	return p.classes
+}
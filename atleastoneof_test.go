@@ -0,0 +1,70 @@
+package pattern
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAtLeastOneOf(t *testing.T) {
+	gen := New(Literal("-"), AtLeastOneOf(4, 6, OneOfByte(AlphaUpper), OneOfByte(AlphaLower), OneOfByte(Digits), Literal("!")))
+
+	firstBang := 0
+	for i := 0; i < 500; i++ {
+		v := gen.String()
+		if !strings.HasPrefix(v, "-") || len(v) < 5 || len(v) > 7 {
+			t.Fatalf("AtLeastOneOf returned invalid value: %s", strconv.Quote(v))
+		}
+
+		v = v[1:]
+		if !strings.ContainsAny(v, string(AlphaUpper)) || !strings.ContainsAny(v, string(AlphaLower)) || !strings.ContainsAny(v, string(Digits)) || !strings.Contains(v, "!") {
+			t.Errorf("AtLeastOneOf is missing a class: %s", strconv.Quote(v))
+		}
+		if v[0] == '!' {
+			firstBang++
+		}
+	}
+
+	// The required elements are shuffled.
+	if firstBang == 0 || firstBang > 250 {
+		t.Errorf("AtLeastOneOf did not shuffle: \"!\" was %d of 500 times the first element", firstBang)
+	}
+}
+
+func TestAtLeastOneOfWeights(t *testing.T) {
+	// The extra elements are drawn from the union of the classes, so "a" is 9 times as likely as "!".
+	gen := New(AtLeastOneOf(102, 102, OneOfByte([]byte("abcdefghi")), Literal("!")))
+
+	bangs := 0
+	for i := 0; i < 10; i++ {
+		bangs += strings.Count(gen.String(), "!")
+	}
+
+	// 10 required and about 100 of 1000 extra elements.
+	if bangs < 60 || bangs > 170 {
+		t.Errorf("AtLeastOneOf did not weight the classes: got %d \"!\" in 1020 elements, want about 110", bangs)
+	}
+}
+
+func TestAtLeastOneOfPanic(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max uint32
+		classes  []Part
+	}{
+		{"no classes", 1, 2, nil},
+		{"min < classes", 1, 2, []Part{Literal("a"), Literal("b")}},
+		{"max < min", 3, 2, []Part{Literal("a")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("AtLeastOneOf did not panic")
+				}
+			}()
+			AtLeastOneOf(tt.min, tt.max, tt.classes...)
+		})
+	}
+}
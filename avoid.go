@@ -0,0 +1,70 @@
+package pattern
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Avoid returns a Part that regenerates p as long as its output contains one of the strings of blocklist,
+// e.g. profanity, reserved words or confusing sequences in human-facing codes.
+// The comparison is case-sensitive, so blocklist should contain all spellings that p can output.
+//
+// The Part panics with an error wrapping ErrRetriesExhausted if the output of p still contains a blocked string after maxRetries regenerations.
+// Panics if blocklist contains an empty string or maxRetries is < 0.
+func Avoid(p Part, blocklist []string, maxRetries int) Part {
+	if maxRetries < 0 {
+		panic("maxRetries must be >= 0")
+	}
+
+	for _, s := range blocklist {
+		if s == "" {
+			panic("blocklist must not contain empty strings")
+		}
+	}
+
+	return avoid{
+		part:       p,
+		blocklist:  blocklist,
+		maxRetries: maxRetries,
+	}
+}
+
+type avoid struct {
+	part       Part
+	blocklist  []string
+	maxRetries int
+}
+
+func (p avoid) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p avoid) appendRun(r *run, b []byte) []byte {
+	defer r.restoreLimit(r.suspendLimit())
+	start := len(b)
+	for i := 0; ; i++ {
+		b = appendRun(r, p.part, b[:start])
+		blocked := p.blocked(b[start:])
+		if blocked == "" {
+			return b
+		}
+
+		if i == p.maxRetries {
+			panic(fmt.Errorf("%w: value still contains %q after %d retries", ErrRetriesExhausted, blocked, p.maxRetries))
+		}
+	}
+}
+
+// blocked returns the first string of the blocklist contained in b or "" if there is none.
+func (p avoid) blocked(b []byte) string {
+	for _, s := range p.blocklist {
+		if bytes.Contains(b, []byte(s)) {
+			return s
+		}
+	}
+	return ""
+}
+
+func (p avoid) Children() []Part {
+	return []Part{p.part}
+}
@@ -0,0 +1,52 @@
+package pattern
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAvoid(t *testing.T) {
+	gen := New(Avoid(Repeat(4, 4, OneOfByte([]byte("ab"))), []string{"aa", "bb"}, 1000))
+	for i := 0; i < 100; i++ {
+		if v := gen.String(); v != "abab" && v != "baba" {
+			t.Fatalf("Avoid returned invalid value %q", v)
+		}
+	}
+
+	if v := string(gen.Append([]byte("aa"))); !strings.HasPrefix(v, "aa") || len(v) != 6 {
+		t.Errorf("Avoid checked output before the Part: %q", v)
+	}
+}
+
+func TestAvoidRetriesExhausted(t *testing.T) {
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, ErrRetriesExhausted) {
+			t.Errorf("Avoid did not panic with ErrRetriesExhausted, got %v", err)
+		}
+	}()
+
+	_ = New(Avoid(Literal("foobar"), []string{"oba"}, 3)).String()
+}
+
+func TestAvoidPanic(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"empty", func() { Avoid(Literal("a"), []string{"b", ""}, 1) }},
+		{"maxRetries", func() { Avoid(Literal("a"), nil, -1) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Avoid did not panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
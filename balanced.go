@@ -0,0 +1,59 @@
+package pattern
+
+// balancedWidth is the maximum number of Parts between a pair of delimiters of Balanced.
+const balancedWidth = 3
+
+// Balanced returns a Part that outputs properly nested pairs of the delimiters open and close,
+// filled with inner, e.g. Balanced("(", ")", OneOfByteRange('a', 'z'), 3) for values like "(a(bc)()(d(e)))" to fuzz expression parsers.
+// Each pair of delimiters contains up to 3 Parts, each of which is either inner or another pair of delimiters,
+// nested at most maxDepth levels deep. A nil inner outputs only delimiters.
+//
+// Panics if open or close is empty or maxDepth is < 1.
+func Balanced(open string, close string, inner Part, maxDepth int) Part {
+	if open == "" || close == "" {
+		panic("delimiters must not be empty")
+	}
+
+	if maxDepth < 1 {
+		panic("maxDepth must be >= 1")
+	}
+
+	if inner == nil {
+		inner = nullpart{}
+	}
+
+	// The levels are unrolled, so that each level is built from the previous one.
+	var level Part = Group(Literal(open), Repeat(0, balancedWidth, inner), Literal(close))
+	for i := 1; i < maxDepth; i++ {
+		level = Group(Literal(open), Repeat(0, balancedWidth, OneOf(inner, level)), Literal(close))
+	}
+
+	return balanced{
+		open:     open,
+		close:    close,
+		inner:    inner,
+		maxDepth: maxDepth,
+		nested:   level,
+	}
+}
+
+type balanced struct {
+	open     string
+	close    string
+	inner    Part
+	maxDepth int
+	// nested is the unrolled Part with the same outputs.
+	nested Part
+}
+
+func (p balanced) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p balanced) appendRun(r *run, b []byte) []byte {
+	return appendRun(r, p.nested, b)
+}
+
+func (p balanced) Children() []Part {
+	return []Part{p.inner}
+}
@@ -0,0 +1,86 @@
+package pattern
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBalanced(t *testing.T) {
+	gen := New(Balanced("<<", ">>", OneOfByte([]byte("ab")), 3))
+
+	nested := false
+	for i := 0; i < 1000; i++ {
+		v := gen.String()
+		depth, maxDepth := 0, 0
+		for s := v; s != ""; {
+			switch {
+			case strings.HasPrefix(s, "<<"):
+				depth++
+				if depth > maxDepth {
+					maxDepth = depth
+				}
+				s = s[2:]
+			case strings.HasPrefix(s, ">>"):
+				depth--
+				s = s[2:]
+			case s[0] == 'a' || s[0] == 'b':
+				if depth == 0 {
+					t.Fatalf("Balanced returned value outside of delimiters %q", v)
+				}
+				s = s[1:]
+			default:
+				t.Fatalf("Balanced returned invalid value %q", v)
+			}
+			if depth < 0 {
+				t.Fatalf("Balanced returned unbalanced value %q", v)
+			}
+		}
+		if depth != 0 {
+			t.Fatalf("Balanced returned unbalanced value %q", v)
+		}
+		if maxDepth > 3 {
+			t.Fatalf("Balanced exceeded the maximum depth: %q", v)
+		}
+		if maxDepth > 1 {
+			nested = true
+		}
+	}
+
+	if !nested {
+		t.Errorf("Balanced never nested delimiters")
+	}
+
+	p := Balanced("(", ")", nil, 2)
+	for _, s := range []string{"()", "(())", "(()()())"} {
+		if !Match(p, s) {
+			t.Errorf("Match(%q) returned false", s)
+		}
+	}
+	for _, s := range []string{"", "(", "((()))", "()()"} {
+		if Match(p, s) {
+			t.Errorf("Match(%q) returned true", s)
+		}
+	}
+}
+
+func TestBalancedPanic(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"open", func() { Balanced("", ")", nil, 1) }},
+		{"close", func() { Balanced("(", "", nil, 1) }},
+		{"maxDepth", func() { Balanced("(", ")", nil, 0) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Balanced did not panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package pattern
+
+import (
+	"math"
+	"strconv"
+)
+
+// BenfordDigit returns a Part that outputs a leading digit from 1 to 9 following Benford's law,
+// where d is output with probability log10(1 + 1/d), i.e. 1 in about 30% and 9 in about 5% of the iterations.
+//
+// https://en.wikipedia.org/wiki/Benford%27s_law
+func BenfordDigit() Part {
+	choices := make([]WeightedChoice, 9)
+	for d := 1; d <= 9; d++ {
+		choices[d-1] = WeightedChoice{Weight: math.Log10(1 + 1/float64(d)), Part: Literal(strconv.Itoa(d))}
+	}
+	return benfordDigit{choose: Choose(choices...).(choose)}
+}
+
+// benfordDigit is a Choose of the digits with the probabilities of Benford's law.
+type benfordDigit struct {
+	choose
+}
+
+// BenfordAmount returns a Part that outputs a number in [min, max] with decimals digits after the decimal point,
+// whose leading digits follow Benford's law, e.g. BenfordAmount(1, 100000, 2) for realistic transaction amounts.
+// The numbers are log-uniformly distributed, which follows Benford's law exactly if max/min is a power of 10.
+//
+// Panics if min is <= 0, max <= min, decimals is < 0 or no number with decimals digits after the decimal point is in [min, max].
+func BenfordAmount(min float64, max float64, decimals int) Part {
+	if !(min > 0) || math.IsInf(min, 0) {
+		panic("min must be > 0")
+	}
+
+	if !(max > min) || math.IsInf(max, 0) {
+		panic("max must be > min")
+	}
+
+	if decimals < 0 {
+		panic("decimals must be >= 0")
+	}
+
+	p := benfordAmount{
+		min:      min,
+		max:      max,
+		decimals: decimals,
+		scale:    math.Pow10(decimals),
+	}
+
+	// The outputs are rounded, so they are clamped to the smallest and largest rounded numbers in the range.
+	step := 1 / p.scale
+	if p.lo = p.round(min); p.lo < min {
+		p.lo = p.round(min + step)
+	}
+	if p.hi = p.round(max); p.hi > max {
+		p.hi = p.round(max - step)
+	}
+	if p.lo > p.hi {
+		panic("no number with decimals digits after the decimal point is in [min, max]")
+	}
+	return p
+}
+
+type benfordAmount struct {
+	min      float64
+	max      float64
+	decimals int
+	// scale is 10^decimals.
+	scale float64
+	// lo and hi are the smallest and largest numbers in [min, max] with decimals digits after the decimal point.
+	lo float64
+	hi float64
+}
+
+// round returns x rounded to decimals digits after the decimal point.
+func (p benfordAmount) round(x float64) float64 {
+	// Numbers beyond the precision of float64 have no digits to round.
+	if y := x * p.scale; y < 1<<53 {
+		return math.Round(y) / p.scale
+	}
+	return x
+}
+
+func (p benfordAmount) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p benfordAmount) appendRun(r *run, b []byte) []byte {
+	lo, hi := math.Log(p.min), math.Log(p.max)
+	x := math.Exp(lo + r.float64()*(hi-lo))
+	// Neither rounding errors of Exp nor the rounding to decimals must leave the range.
+	x = math.Max(p.lo, math.Min(p.hi, p.round(x)))
+	return strconv.AppendFloat(b, x, 'f', p.decimals, 64)
+}
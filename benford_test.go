@@ -0,0 +1,100 @@
+package pattern
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestBenfordDigit(t *testing.T) {
+	gen := New(BenfordDigit())
+
+	const n = 100000
+	counts := make([]int, 10)
+	for i := 0; i < n; i++ {
+		counts[gen.String()[0]-'0']++
+	}
+
+	if counts[0] != 0 {
+		t.Errorf("BenfordDigit returned 0")
+	}
+	for d := 1; d <= 9; d++ {
+		want := math.Log10(1 + 1/float64(d))
+		if got := float64(counts[d]) / n; math.Abs(got-want) > 0.01 {
+			t.Errorf("BenfordDigit returned %d with invalid frequency: want %v, got %v", d, want, got)
+		}
+	}
+
+	if got := gen.Pattern(); got != "[1-9]" {
+		t.Errorf("Pattern returned invalid regular expression: %s", got)
+	}
+}
+
+func TestBenfordAmount(t *testing.T) {
+	gen := New(BenfordAmount(1, 10000, 2))
+
+	const n = 100000
+	counts := make([]int, 10)
+	for i := 0; i < n; i++ {
+		v := gen.String()
+		x, err := strconv.ParseFloat(v, 64)
+		if err != nil || x < 1 || x > 10000 || len(v) < 4 || v[len(v)-3] != '.' {
+			t.Fatalf("BenfordAmount returned invalid value: %s", strconv.Quote(v))
+		}
+		counts[v[0]-'0']++
+	}
+
+	for d := 1; d <= 9; d++ {
+		want := math.Log10(1 + 1/float64(d))
+		if got := float64(counts[d]) / n; math.Abs(got-want) > 0.01 {
+			t.Errorf("BenfordAmount returned leading digit %d with invalid frequency: want %v, got %v", d, want, got)
+		}
+	}
+}
+
+func TestBenfordAmountRounding(t *testing.T) {
+	tests := []struct {
+		min, max float64
+		decimals int
+	}{
+		{0.001, 1, 0},
+		{0.001, 1.5, 0},
+		{0.25, 0.75, 1},
+		{1, 1e300, 2},
+	}
+
+	for _, tt := range tests {
+		p := BenfordAmount(tt.min, tt.max, tt.decimals)
+		for i := 0; i < 1000; i++ {
+			v := string(p.Append(nil))
+			if x, err := strconv.ParseFloat(v, 64); err != nil || x < tt.min || x > tt.max {
+				t.Fatalf("BenfordAmount(%v, %v, %d) returned value out of range: %s", tt.min, tt.max, tt.decimals, strconv.Quote(v))
+			}
+		}
+	}
+}
+
+func TestBenfordAmountPanic(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max float64
+		decimals int
+	}{
+		{"min", 0, 10, 0},
+		{"max", 10, 10, 0},
+		{"max Inf", 1, math.Inf(1), 0},
+		{"decimals", 1, 10, -1},
+		{"no rounded number", 0.001, 0.4, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("BenfordAmount did not panic")
+				}
+			}()
+			BenfordAmount(tt.min, tt.max, tt.decimals)
+		})
+	}
+}
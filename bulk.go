@@ -0,0 +1,222 @@
+package pattern
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/sollniss/pattern/internal"
+)
+
+// bulkBatch is the number of rows that WriteCSVParallel and WriteJSONLParallel generate at once.
+const bulkBatch = 4096
+
+// WriteCSV writes n rows to w, where each column is generated by the corresponding Part of columns.
+// If header is not nil, it is written as the first row and must have the same length as columns.
+// Fields are quoted as described in RFC 4180 when necessary.
+// The output buffer is reused for all rows, so no allocations are made per row.
+func WriteCSV(w io.Writer, n int, header []string, columns []Part) error {
+	row, err := csvRow(header, columns)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	if err := writeCSVHeader(bw, header); err != nil {
+		return err
+	}
+	return writeRows(bw, n, row)
+}
+
+// WriteCSVParallel writes n rows to w like WriteCSV, but generates the rows with StringsParallel on workers goroutines.
+// Like with StringsParallel, stateful Parts like Sequence are advanced concurrently, so their values are not necessarily in order.
+// If ctx is cancelled before all rows are written, ctx.Err() is returned.
+func WriteCSVParallel(ctx context.Context, w io.Writer, n int, header []string, columns []Part, workers int) error {
+	row, err := csvRow(header, columns)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	if err := writeCSVHeader(bw, header); err != nil {
+		return err
+	}
+	return writeRowsParallel(ctx, bw, n, row, workers)
+}
+
+// csvRow returns the Part that generates a CSV row of columns.
+func csvRow(header []string, columns []Part) (Part, error) {
+	if header != nil && len(header) != len(columns) {
+		return nil, errors.New("pattern: header and columns must have the same length")
+	}
+
+	row := make(group, 0, 2*len(columns))
+	for i, p := range columns {
+		if i > 0 {
+			row = append(row, Literal(","))
+		}
+		row = append(row, csvField{part: p})
+	}
+	return append(row, Literal("\r\n")), nil
+}
+
+func writeCSVHeader(bw *bufio.Writer, header []string) error {
+	if header == nil {
+		return nil
+	}
+
+	var b []byte
+	for i, h := range header {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendCSVField(b, []byte(h))
+	}
+	b = append(b, '\r', '\n')
+
+	_, err := bw.Write(b)
+	return err
+}
+
+// csvField outputs the output of part as a CSV field.
+type csvField struct {
+	part Part
+}
+
+func (p csvField) Append(b []byte) []byte {
+	start := len(b)
+	b = p.part.Append(b)
+	if !csvNeedsQuotes(b[start:]) {
+		return b
+	}
+
+	// Quote a copy of the field after it, then move the copy in place.
+	end := len(b)
+	b = appendCSVField(b, b[start:end])
+	n := copy(b[start:], b[end:])
+	return b[:start+n]
+}
+
+func csvNeedsQuotes(field []byte) bool {
+	return bytes.ContainsAny(field, ",\"\r\n") || (len(field) > 0 && field[0] == ' ')
+}
+
+func appendCSVField(b []byte, field []byte) []byte {
+	if !csvNeedsQuotes(field) {
+		return append(b, field...)
+	}
+
+	b = append(b, '"')
+	for _, c := range field {
+		if c == '"' {
+			b = append(b, '"')
+		}
+		b = append(b, c)
+	}
+	return append(b, '"')
+}
+
+// WriteJSONL writes n JSON objects to w, each followed by a newline (JSON Lines).
+// The value of the member names[i] of each object is generated by columns[i].
+// The output buffer is reused for all rows, so no allocations are made per row.
+func WriteJSONL(w io.Writer, n int, names []string, columns []Part) error {
+	row, err := jsonlRow(names, columns)
+	if err != nil {
+		return err
+	}
+	return writeRows(bufio.NewWriter(w), n, row)
+}
+
+// WriteJSONLParallel writes n JSON objects to w like WriteJSONL, but generates the objects with StringsParallel on workers goroutines.
+// Like with StringsParallel, stateful Parts like Sequence are advanced concurrently, so their values are not necessarily in order.
+// If ctx is cancelled before all objects are written, ctx.Err() is returned.
+func WriteJSONLParallel(ctx context.Context, w io.Writer, n int, names []string, columns []Part, workers int) error {
+	row, err := jsonlRow(names, columns)
+	if err != nil {
+		return err
+	}
+	return writeRowsParallel(ctx, bufio.NewWriter(w), n, row, workers)
+}
+
+// jsonlRow returns the Part that generates a JSON object with the members names, followed by a newline.
+func jsonlRow(names []string, columns []Part) (Part, error) {
+	if len(names) != len(columns) {
+		return nil, errors.New("pattern: names and columns must have the same length")
+	}
+
+	if len(columns) == 0 {
+		return Literal("{}\n"), nil
+	}
+
+	// Pre-encode the names.
+	row := make(group, 0, 2*len(columns)+1)
+	for i, name := range names {
+		k := []byte{','}
+		if i == 0 {
+			k[0] = '{'
+		}
+		k = appendJSONString(k, []byte(name))
+		row = append(row, Literal(string(append(k, ':'))), jsonString{part: columns[i]})
+	}
+	return append(row, Literal("}\n")), nil
+}
+
+// jsonString outputs the output of part as a quoted JSON string.
+type jsonString struct {
+	part Part
+}
+
+func (p jsonString) Append(b []byte) []byte {
+	// Escape a copy of the output after it, then move the copy in place.
+	start := len(b)
+	b = p.part.Append(b)
+	end := len(b)
+	b = appendJSONString(b, b[start:end])
+	n := copy(b[start:], b[end:])
+	return b[:start+n]
+}
+
+// appendJSONString appends s as a quoted JSON string.
+// Invalid UTF-8 is replaced by U+FFFD.
+func appendJSONString(b []byte, s []byte) []byte {
+	b = append(b, '"')
+	b = internal.AppendJSONEscaped(b, s)
+	return append(b, '"')
+}
+
+// writeRows writes n outputs of row to bw, reusing the buffer for all rows.
+func writeRows(bw *bufio.Writer, n int, row Part) error {
+	var b []byte
+	for i := 0; i < n; i++ {
+		b = row.Append(b[:0])
+		if _, err := bw.Write(b); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// writeRowsParallel writes n outputs of row to bw, generating them in batches with StringsParallel.
+func writeRowsParallel(ctx context.Context, bw *bufio.Writer, n int, row Part, workers int) error {
+	g := New(row)
+	for n > 0 {
+		batch := n
+		if batch > bulkBatch {
+			batch = bulkBatch
+		}
+
+		rows, err := g.StringsParallel(ctx, batch, workers)
+		if err != nil {
+			return err
+		}
+		for _, r := range rows {
+			if _, err := bw.WriteString(r); err != nil {
+				return err
+			}
+		}
+		n -= batch
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,131 @@
+package pattern
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+var bulkColumns = []Part{
+	Sequence(1, 1000, 0),
+	OneOfString([]string{"plain", "with,comma", "with \"quotes\"", "multi\nline", " space", "ユーザー", "\x01"}),
+	Repeat(0, 3, Literal("x")),
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, 100, []string{"id", "text", "opt,ional"}, bulkColumns); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("WriteCSV generated invalid CSV: %v", err)
+	}
+
+	if len(records) != 101 {
+		t.Fatalf("WriteCSV generated invalid number of rows: want 101, got %d", len(records))
+	}
+
+	if records[0][2] != "opt,ional" {
+		t.Errorf("WriteCSV generated invalid header: got %v", records[0])
+	}
+
+	texts := make(map[string]bool)
+	for _, r := range records[1:] {
+		texts[r[1]] = true
+	}
+
+	for _, want := range []string{"with,comma", "with \"quotes\"", "multi\nline", " space"} {
+		if !texts[want] {
+			t.Errorf("WriteCSV never generated %s", strconv.Quote(want))
+		}
+	}
+
+	if err := WriteCSV(&buf, 1, []string{"id"}, bulkColumns); err == nil {
+		t.Errorf("WriteCSV with mismatched header did not return an error")
+	}
+}
+
+func TestWriteJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSONL(&buf, 100, []string{"id", "text", "opt\"ional"}, bulkColumns); err != nil {
+		t.Fatalf("WriteJSONL returned error: %v", err)
+	}
+
+	rows := 0
+	s := bufio.NewScanner(&buf)
+	for s.Scan() {
+		var m map[string]string
+		if err := json.Unmarshal(s.Bytes(), &m); err != nil {
+			t.Fatalf("WriteJSONL generated invalid JSON %s: %v", strconv.Quote(s.Text()), err)
+		}
+		if len(m) != 3 {
+			t.Errorf("WriteJSONL generated invalid number of members: want 3, got %d", len(m))
+		}
+		if _, ok := m["opt\"ional"]; !ok {
+			t.Errorf("WriteJSONL did not generate member \"opt\\\"ional\"")
+		}
+		rows++
+	}
+
+	if rows != 100 {
+		t.Errorf("WriteJSONL generated invalid number of rows: want 100, got %d", rows)
+	}
+
+	if err := WriteJSONL(&buf, 1, nil, bulkColumns); err == nil {
+		t.Errorf("WriteJSONL with mismatched names did not return an error")
+	}
+}
+
+func TestWriteParallel(t *testing.T) {
+	var par bytes.Buffer
+	if err := WriteCSVParallel(context.Background(), &par, 5000, []string{"id", "text", "opt"}, bulkColumns, 4); err != nil {
+		t.Fatalf("WriteCSVParallel returned error: %v", err)
+	}
+
+	records, err := csv.NewReader(&par).ReadAll()
+	if err != nil {
+		t.Fatalf("WriteCSVParallel generated invalid CSV: %v", err)
+	}
+	if len(records) != 5001 {
+		t.Errorf("WriteCSVParallel generated invalid number of rows: want 5001, got %d", len(records))
+	}
+
+	par.Reset()
+	if err := WriteJSONLParallel(context.Background(), &par, 5000, []string{"id", "text", "opt"}, bulkColumns, 4); err != nil {
+		t.Fatalf("WriteJSONLParallel returned error: %v", err)
+	}
+
+	rows := 0
+	s := bufio.NewScanner(&par)
+	for s.Scan() {
+		var m map[string]string
+		if err := json.Unmarshal(s.Bytes(), &m); err != nil {
+			t.Fatalf("WriteJSONLParallel generated invalid JSON %s: %v", strconv.Quote(s.Text()), err)
+		}
+		rows++
+	}
+	if rows != 5000 {
+		t.Errorf("WriteJSONLParallel generated invalid number of rows: want 5000, got %d", rows)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := WriteCSVParallel(ctx, &par, 5000, nil, bulkColumns, 4); !errors.Is(err, context.Canceled) {
+		t.Errorf("WriteCSVParallel with cancelled context returned invalid error: want %v, got %v", context.Canceled, err)
+	}
+}
+
+func BenchmarkWriteCSV(b *testing.B) {
+	var buf bytes.Buffer
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		_ = WriteCSV(&buf, 1000, nil, bulkColumns)
+	}
+}
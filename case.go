@@ -0,0 +1,108 @@
+package pattern
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Upper returns a Part that maps the output of p to upper case, e.g. to normalize mixed word lists.
+func Upper(p Part) Part {
+	return caseMap{part: p, mode: caseUpper}
+}
+
+// Lower returns a Part that maps the output of p to lower case.
+func Lower(p Part) Part {
+	return caseMap{part: p, mode: caseLower}
+}
+
+// Title returns a Part that maps the first letter of each word of the output of p to title case and the other letters to lower case.
+// A word starts at every letter or digit that does not follow a letter, digit, mark or apostrophe.
+func Title(p Part) Part {
+	return caseMap{part: p, mode: caseTitle}
+}
+
+type caseMode uint8
+
+const (
+	caseUpper caseMode = iota
+	caseLower
+	caseTitle
+)
+
+func (m caseMode) String() string {
+	switch m {
+	case caseUpper:
+		return "Upper"
+	case caseLower:
+		return "Lower"
+	}
+	return "Title"
+}
+
+type caseMap struct {
+	part Part
+	mode caseMode
+}
+
+func (p caseMap) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p caseMap) appendRun(r *run, b []byte) []byte {
+	defer r.restoreLimit(r.suspendLimit())
+	start := len(b)
+	b = appendRun(r, p.part, b)
+	return p.apply(b, start)
+}
+
+// apply maps b[start:] in place.
+// Outputs with non-ASCII characters are mapped through a copy, since their length may change.
+func (p caseMap) apply(b []byte, start int) []byte {
+	out := b[start:]
+	for _, c := range out {
+		if c >= utf8.RuneSelf {
+			return append(b[:start], p.mapUnicode(out)...)
+		}
+	}
+
+	word := false
+	for i, c := range out {
+		lower := 'a' <= c && c <= 'z'
+		upper := 'A' <= c && c <= 'Z'
+		switch {
+		case upper && (p.mode == caseLower || (p.mode == caseTitle && word)):
+			out[i] = c + 'a' - 'A'
+		case lower && (p.mode == caseUpper || (p.mode == caseTitle && !word)):
+			out[i] = c - 'a' + 'A'
+		}
+		word = lower || upper || ('0' <= c && c <= '9') || c == '\''
+	}
+	return b
+}
+
+func (p caseMap) mapUnicode(s []byte) []byte {
+	switch p.mode {
+	case caseUpper:
+		return bytes.ToUpper(s)
+	case caseLower:
+		return bytes.ToLower(s)
+	}
+
+	out := make([]byte, 0, len(s))
+	word := false
+	for _, r := range string(s) {
+		if word {
+			r = unicode.ToLower(r)
+		} else {
+			r = unicode.ToTitle(r)
+		}
+		out = utf8.AppendRune(out, r)
+		word = unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '\''
+	}
+	return out
+}
+
+func (p caseMap) Children() []Part {
+	return []Part{p.part}
+}
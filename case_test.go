@@ -0,0 +1,40 @@
+package pattern
+
+import (
+	"testing"
+)
+
+func TestCase(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Part
+		want string
+	}{
+		{"Upper", Upper(Literal("hello, World 1")), "HELLO, WORLD 1"},
+		{"Upper unicode", Upper(Literal("straße ǆ")), "STRAßE Ǆ"},
+		{"Lower", Lower(Literal("Hello, WORLD")), "hello, world"},
+		{"Lower unicode", Lower(Literal("ÄÖÜ İ")), "äöü i"},
+		{"Title", Title(Literal("hello wORLD o'neil x1y")), "Hello World O'neil X1y"},
+		{"Title unicode", Title(Literal("ǆungla émile")), "ǅungla Émile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Prefix the output to check that only the output of the Part is mapped.
+			if got := New(Literal("aB-"), tt.p).String(); got != "aB-"+tt.want {
+				t.Errorf("got %q, want %q", got, "aB-"+tt.want)
+			}
+		})
+	}
+}
+
+func TestCaseAllocs(t *testing.T) {
+	g := New(Upper(OneOfString([]string{"foo", "bar"})))
+	b := make([]byte, 0, 16)
+	allocs := testing.AllocsPerRun(100, func() {
+		b = g.Append(b[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("Upper of ASCII allocated %v times", allocs)
+	}
+}
@@ -0,0 +1,134 @@
+package pattern
+
+import (
+	"math"
+)
+
+// WeightedChoice is a Part with a weight for Choose.
+// A nil Part outputs nothing.
+type WeightedChoice struct {
+	Weight float64
+	Part   Part
+}
+
+// Choose returns a Part that selects one of choices randomly in each iteration,
+// with a probability proportional to its weight, e.g. to output "US" in 60%, "DE" in 30% and nothing in 10% of the iterations:
+//
+//	Choose(
+//		WeightedChoice{Weight: 6, Part: Literal("US")},
+//		WeightedChoice{Weight: 3, Part: Literal("DE")},
+//		WeightedChoice{Weight: 1},
+//	)
+//
+// It generalizes Potentially and OneOf. Choices with weight 0 are never selected.
+// The selection takes constant time, since Choose precomputes an alias table.
+//
+// Panics if choices is empty, a weight is negative, NaN or infinite, or all weights are 0.
+func Choose(choices ...WeightedChoice) Part {
+	total := 0.0
+	for _, c := range choices {
+		if c.Weight < 0 || math.IsNaN(c.Weight) || math.IsInf(c.Weight, 0) {
+			panic("weights must be finite and >= 0")
+		}
+		total += c.Weight
+	}
+
+	if total == 0 {
+		panic("total weight must be > 0")
+	}
+
+	p := choose{}
+	for _, c := range choices {
+		if c.Weight == 0 {
+			continue
+		}
+
+		part := c.Part
+		if part == nil {
+			part = nullpart{}
+		}
+		p.parts = append(p.parts, part)
+		p.weights = append(p.weights, c.Weight)
+	}
+
+	p.prob, p.alias = aliasTable(p.weights, total)
+	return p
+}
+
+type choose struct {
+	parts   []Part
+	weights []float64
+	// prob and alias are the alias table of the weights.
+	prob  []float64
+	alias []uint32
+}
+
+// aliasTable returns the alias table of weights with Vose's method.
+// Choice i is selected if a random number in [0, 1) is less than prob[i], otherwise alias[i] is selected.
+//
+// https://www.keithschwarz.com/darts-dice-coins/
+func aliasTable(weights []float64, total float64) ([]float64, []uint32) {
+	n := len(weights)
+	prob := make([]float64, n)
+	alias := make([]uint32, n)
+
+	// The weights scaled so that their mean is 1.
+	scaled := make([]float64, n)
+	var small, large []uint32
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, uint32(i))
+		} else {
+			large = append(large, uint32(i))
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small, large = small[:len(small)-1], large[:len(large)-1]
+
+		prob[s] = scaled[s]
+		alias[s] = l
+
+		scaled[l] = (scaled[l] + scaled[s]) - 1
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+
+	// The remaining choices are only left because of rounding errors.
+	for _, i := range large {
+		prob[i] = 1
+	}
+	for _, i := range small {
+		prob[i] = 1
+	}
+	return prob, alias
+}
+
+func (p choose) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p choose) appendRun(r *run, b []byte) []byte {
+	i := r.randN(uint32(len(p.parts)))
+	if r.float64() >= p.prob[i] {
+		i = p.alias[i]
+	}
+	return appendRun(r, p.parts[i], b)
+}
+
+// oneOf returns the OneOf with the same outputs as the Choose.
+func (p choose) oneOf() anyOf {
+	return anyOf{
+		parts: p.parts,
+		len:   uint32(len(p.parts)),
+	}
+}
+
+func (p choose) Children() []Part {
+	return p.parts
+}
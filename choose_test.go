@@ -0,0 +1,94 @@
+package pattern
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestChoose(t *testing.T) {
+	gen := New(Choose(
+		WeightedChoice{Weight: 6, Part: Literal("a")},
+		WeightedChoice{Weight: 3, Part: Literal("b")},
+		WeightedChoice{Weight: 0, Part: Literal("c")},
+		WeightedChoice{Weight: 1},
+	))
+
+	const n = 100000
+	counts := make(map[string]int)
+	for i := 0; i < n; i++ {
+		counts[gen.String()]++
+	}
+
+	for v, want := range map[string]float64{"a": 0.6, "b": 0.3, "c": 0, "": 0.1} {
+		if got := float64(counts[v]) / n; math.Abs(got-want) > 0.01 {
+			t.Errorf("Choose returned %s with invalid frequency: want %v, got %v", strconv.Quote(v), want, got)
+		}
+	}
+	if len(counts) != 3 {
+		t.Errorf("Choose returned invalid values: %v", counts)
+	}
+}
+
+func TestChooseAnalysis(t *testing.T) {
+	p := Choose(
+		WeightedChoice{Weight: 1, Part: OneOfByte([]byte("ab"))},
+		WeightedChoice{Weight: 2, Part: Literal("c")},
+		WeightedChoice{Weight: 0, Part: Literal("d")},
+	)
+
+	if c, ok := p.(countable).count(); !ok || c != 3 {
+		t.Errorf("count returned invalid value: want 3, got %d", c)
+	}
+
+	for _, s := range []string{"a", "b", "c"} {
+		if !Match(p, s) {
+			t.Errorf("Match did not match %s", strconv.Quote(s))
+		}
+	}
+	if Match(p, "d") {
+		t.Errorf("Match matched a choice with weight 0")
+	}
+}
+
+func TestAliasTable(t *testing.T) {
+	weights := []float64{1, 2, 3, 4, 0.5}
+	total := 10.5
+	prob, alias := aliasTable(weights, total)
+
+	// The probability of each choice is the sum of its own and its alias shares.
+	got := make([]float64, len(weights))
+	for i := range weights {
+		got[i] += prob[i] / float64(len(weights))
+		got[alias[i]] += (1 - prob[i]) / float64(len(weights))
+	}
+	for i, w := range weights {
+		if math.Abs(got[i]-w/total) > 1e-9 {
+			t.Errorf("invalid probability of choice %d: want %v, got %v", i, w/total, got[i])
+		}
+	}
+}
+
+func TestChoosePanic(t *testing.T) {
+	tests := []struct {
+		name    string
+		choices []WeightedChoice
+	}{
+		{"empty", nil},
+		{"zero", []WeightedChoice{{Weight: 0, Part: Literal("a")}}},
+		{"negative", []WeightedChoice{{Weight: -1, Part: Literal("a")}, {Weight: 2, Part: Literal("b")}}},
+		{"NaN", []WeightedChoice{{Weight: math.NaN(), Part: Literal("a")}}},
+		{"Inf", []WeightedChoice{{Weight: math.Inf(1), Part: Literal("a")}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Choose did not panic")
+				}
+			}()
+			Choose(tt.choices...)
+		})
+	}
+}
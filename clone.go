@@ -0,0 +1,279 @@
+package pattern
+
+import (
+	"sync/atomic"
+)
+
+// cloner is implemented by Parts that contain other Parts or keep state between iterations,
+// and by the OneOf Parts, whose copies get their own alphabet so that Coverage tells them apart.
+type cloner interface {
+	// clone returns a copy of the Part that shares no state with it.
+	// m maps the state of the original Parts to the state of their copies,
+	// so Parts that share state, like the copies of a constant Repeat, share the copied state too.
+	clone(m map[any]any) Part
+}
+
+// Clone returns a deep copy of the generator.
+// The copies of stateful Parts, like Sequence, PermutedSequence, SequencePer and Throttle,
+// continue from the current state but are independent of the original.
+// State that lives outside of the generator, like the Counter of SequenceBackend or the Store of Dedup, is shared.
+// Custom Parts are not copied.
+func (g gen) Clone() *gen {
+	return g.clone(map[any]any{}).(*gen)
+}
+
+// With returns a new generator that generates the Parts of the generator followed by parts.
+// The original generator is not changed, but both share their Parts; use Clone first to get independent state.
+func (g gen) With(parts ...Part) *gen {
+	n := New(parts...)
+	g.parts = append(append(make([]Part, 0, len(g.parts)+len(n.parts)), g.parts...), n.parts...)
+	g.folded = append(append([]string(nil), g.folded...), n.folded...)
+	g.mirror = g.mirror || n.mirror
+	g.consecutive = g.consecutive || n.consecutive
+	g.hint = capacityHint(g.parts)
+	return &g
+}
+
+func clonePart(p Part, m map[any]any) Part {
+	if v, ok := p.(cloner); ok {
+		return v.clone(m)
+	}
+	return p
+}
+
+func cloneParts(parts []Part, m map[any]any) []Part {
+	c := make([]Part, len(parts))
+	for i, p := range parts {
+		c[i] = clonePart(p, m)
+	}
+	return c
+}
+
+// cloneCounter returns the copy of the counter at p.
+func cloneCounter(p *uint64, m map[any]any) *uint64 {
+	if c, ok := m[p]; ok {
+		return c.(*uint64)
+	}
+
+	c := atomic.LoadUint64(p)
+	m[p] = &c
+	return &c
+}
+
+func (g gen) clone(m map[any]any) Part {
+	g.parts = cloneParts(g.parts, m)
+	g.folded = append([]string(nil), g.folded...)
+	return &g
+}
+
+func (p group) clone(m map[any]any) Part {
+	return group(cloneParts(p, m))
+}
+
+func (p constRepeat) clone(m map[any]any) Part {
+	p.group = cloneParts(p.group, m)
+	return p
+}
+
+func (p repeat) clone(m map[any]any) Part {
+	p.parts = cloneParts(p.parts, m)
+	return p
+}
+
+func (p potentially50) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p potentiallyP) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p anyOf) clone(m map[any]any) Part {
+	p.parts = cloneParts(p.parts, m)
+	return p
+}
+
+func (p anyOfString) clone(m map[any]any) Part {
+	p.alphabet = append([]string(nil), p.alphabet...)
+	return p
+}
+
+func (p anyOfByte) clone(m map[any]any) Part {
+	p.alphabet = append([]byte(nil), p.alphabet...)
+	return p
+}
+
+func (p anyOfRune) clone(m map[any]any) Part {
+	p.alphabet = append([]rune(nil), p.alphabet...)
+	return p
+}
+
+func (p shuffle) clone(m map[any]any) Part {
+	p.parts = cloneParts(p.parts, m)
+	return p
+}
+
+func (p fpe) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p constrain) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p avoid) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p uniqueBy) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p caseMap) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p shuffleChars) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p encoded) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p hashed) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p pad) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p exactLen) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p maxOutputLen) clone(m map[any]any) Part {
+	p.parts = cloneParts(p.parts, m)
+	return p
+}
+
+func (p maxTotalLen) clone(m map[any]any) Part {
+	p.parts = cloneParts(p.parts, m)
+	return p
+}
+
+func (p cycle) clone(m map[any]any) Part {
+	p.parts = cloneParts(p.parts, m)
+	p.curr = cloneCounter(p.curr, m)
+	return p
+}
+
+func (p choose) clone(m map[any]any) Part {
+	p.parts = cloneParts(p.parts, m)
+	return p
+}
+
+func (p repeatNormal) clone(m map[any]any) Part {
+	p.parts = cloneParts(p.parts, m)
+	p.uniform = Repeat(p.min, p.max, p.parts...)
+	return p
+}
+
+func (p balanced) clone(m map[any]any) Part {
+	return Balanced(p.open, p.close, clonePart(p.inner, m), p.maxDepth)
+}
+
+func (p define) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p ifPart) clone(m map[any]any) Part {
+	p.then = clonePart(p.then, m)
+	p.els = clonePart(p.els, m)
+	return p
+}
+
+func (p repeatDist) clone(m map[any]any) Part {
+	p.parts = cloneParts(p.parts, m)
+	return p
+}
+
+func (p benfordDigit) clone(m map[any]any) Part {
+	// The digits are Literals without state.
+	return p
+}
+
+func (p zipf) clone(m map[any]any) Part {
+	p.parts = cloneParts(p.parts, m)
+	return p
+}
+
+func (p noConsecutive) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p repeatDistinct) clone(m map[any]any) Part {
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p atLeastOneOf) clone(m map[any]any) Part {
+	p.classes = cloneParts(p.classes, m)
+	return p
+}
+
+func (p throttle) clone(m map[any]any) Part {
+	if c, ok := m[p.next]; ok {
+		p.next = c.(*int64)
+	} else {
+		next := atomic.LoadInt64(p.next)
+		m[p.next] = &next
+		p.next = &next
+	}
+	p.part = clonePart(p.part, m)
+	return p
+}
+
+func (p sequence) clone(m map[any]any) Part {
+	p.curr = cloneCounter(p.curr, m)
+	return p
+}
+
+func (p permutation) clone(m map[any]any) Part {
+	p.curr = cloneCounter(p.curr, m)
+	return p
+}
+
+func (p sequencePer) clone(m map[any]any) Part {
+	if c, ok := m[p.state]; ok {
+		p.state = c.(*sequencePerState)
+		return p
+	}
+
+	s := p.state
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p.state = &sequencePerState{
+		valid:  s.valid,
+		bucket: s.bucket,
+		curr:   s.curr,
+	}
+	m[s] = p.state
+	return p
+}
@@ -0,0 +1,60 @@
+package pattern
+
+import (
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	base := New(
+		Literal("id-"),
+		Repeat(2, 2, Sequence(1, 100, 3)),
+		Potentially(1, Group(Literal("-"), Sequence(1, 100, 0))),
+	)
+
+	if v := base.String(); v != "id-001002-1" {
+		t.Fatalf("unexpected value %q", v)
+	}
+
+	clone := base.Clone()
+	if v := clone.Explain(); v != base.Explain() {
+		t.Errorf("clone has a different structure:\n%s\nwant:\n%s", v, base.Explain())
+	}
+
+	// The clone continues from the current state, independently of the original.
+	want := base.Clone()
+	for i := 0; i < 10; i++ {
+		_ = base.String()
+	}
+	for i := 0; i < 10; i++ {
+		if v, w := clone.String(), want.String(); v != w {
+			t.Fatalf("got %q, want %q", v, w)
+		}
+	}
+	if v := clone.String(); v != "id-023024-12" {
+		t.Errorf("got %q, want id-023024-12", v)
+	}
+}
+
+func TestWith(t *testing.T) {
+	base := New(Literal("a"), Repeat(2, 2, Literal("b")))
+	dev := base.With(Literal("-dev"))
+	prod := base.With(Group(Literal("-"), Literal("prod")))
+
+	tests := []struct {
+		gen  *gen
+		want string
+	}{
+		{base, "abb"},
+		{dev, "abb-dev"},
+		{prod, "abb-prod"},
+	}
+	for _, test := range tests {
+		if v := test.gen.String(); v != test.want {
+			t.Errorf("got %q, want %q", v, test.want)
+		}
+	}
+
+	if v := prod.Explain(); v[:len("New (unwrapped Repeat(2, 2), unwrapped Group)")] != "New (unwrapped Repeat(2, 2), unwrapped Group)" {
+		t.Errorf("unexpected folded notes %q", v)
+	}
+}
@@ -0,0 +1,140 @@
+// Command pattern prints strings generated from regular expressions.
+//
+// Usage:
+//
+//	pattern [flags] expr...
+//
+// Each expr is a regular expression in the syntax of the regexp package and generates one column.
+// The flags are:
+//
+//	-n count
+//		number of rows to generate (default 1)
+//	-seed string
+//		derive all random choices from seed, so the output is reproducible
+//	-format plain|csv|jsonl
+//		output format (default plain, which separates columns with tabs)
+//	-names name,...
+//		comma separated column names used as CSV header and JSON keys (default c1,c2,...)
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/sollniss/pattern"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "pattern:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet("pattern", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: pattern [flags] expr...")
+		fs.PrintDefaults()
+	}
+
+	n := fs.Int("n", 1, "number of rows to generate")
+	seed := fs.String("seed", "", "derive all random choices from `seed`, so the output is reproducible")
+	format := fs.String("format", "plain", "output `format`: plain, csv or jsonl")
+	names := fs.String("names", "", "comma separated column `names` used as CSV header and JSON keys")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("missing expression")
+	}
+
+	if *n < 0 {
+		return errors.New("count must be >= 0")
+	}
+
+	columns := make([]pattern.Part, fs.NArg())
+	for i, expr := range fs.Args() {
+		g, err := pattern.Compile(expr)
+		if err != nil {
+			return err
+		}
+
+		columns[i] = g
+		if *seed != "" {
+			columns[i] = &seeded{
+				gen:  g,
+				seed: *seed + "/" + strconv.Itoa(i) + "/",
+			}
+		}
+	}
+
+	header := make([]string, len(columns))
+	if *names != "" {
+		header = strings.Split(*names, ",")
+		if len(header) != len(columns) {
+			return fmt.Errorf("got %d names for %d expressions", len(header), len(columns))
+		}
+	} else {
+		for i := range header {
+			header[i] = "c" + strconv.Itoa(i+1)
+		}
+	}
+
+	switch *format {
+	case "plain":
+		return writePlain(stdout, *n, columns)
+	case "csv":
+		return pattern.WriteCSV(stdout, *n, header, columns)
+	case "jsonl":
+		return pattern.WriteJSONL(stdout, *n, header, columns)
+	}
+
+	return fmt.Errorf("unknown format %q", *format)
+}
+
+func writePlain(w io.Writer, n int, columns []pattern.Part) error {
+	bw := bufio.NewWriter(w)
+	var b []byte
+	for row := 0; row < n; row++ {
+		b = b[:0]
+		for i, p := range columns {
+			if i > 0 {
+				b = append(b, '\t')
+			}
+			b = p.Append(b)
+		}
+		b = append(b, '\n')
+
+		if _, err := bw.Write(b); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// seeded is a Part that derives the random choices of the n-th generated value from the seed and n.
+type seeded struct {
+	gen interface {
+		StringFor(key []byte) string
+	}
+	seed string
+	n    int
+}
+
+func (p *seeded) Append(b []byte) []byte {
+	p.n++
+	return append(b, p.gen.StringFor([]byte(p.seed+strconv.Itoa(p.n)))...)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"plain", []string{"-n", "3", `[a-z]{3}`, `\d{2}`}, `^([a-z]{3}\t\d{2}\n){3}$`},
+		{"csv", []string{"-n", "2", "-format", "csv", "-names", "a,b", `[a-z]{3}`, `\d{2}`}, `^a,b\r\n([a-z]{3},\d{2}\r\n){2}$`},
+		{"jsonl", []string{"-n", "2", "-format", "jsonl", `[a-z]{3}`}, `^(\{"c1":"[a-z]{3}"\}\n){2}$`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := run(tt.args, &out, io.Discard); err != nil {
+				t.Fatalf("run returned error: %v", err)
+			}
+
+			if !regexp.MustCompile(tt.want).MatchString(out.String()) {
+				t.Errorf("run returned invalid output: got %s", strconv.Quote(out.String()))
+			}
+		})
+	}
+}
+
+func TestRunSeed(t *testing.T) {
+	args := []string{"-n", "5", "-seed", "42", `[a-z0-9]{16}`}
+
+	var out1, out2, out3 bytes.Buffer
+	if err := run(args, &out1, io.Discard); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	if err := run(args, &out2, io.Discard); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	args[3] = "43"
+	if err := run(args, &out3, io.Discard); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+
+	if out1.String() != out2.String() {
+		t.Errorf("run with the same seed returned different output: %s != %s", strconv.Quote(out1.String()), strconv.Quote(out2.String()))
+	}
+
+	if out1.String() == out3.String() {
+		t.Errorf("run with different seeds returned the same output")
+	}
+
+	if lines := strings.Split(strings.TrimSpace(out1.String()), "\n"); len(lines) != 5 || lines[0] == lines[1] {
+		t.Errorf("run with seed returned invalid output: got %s", strconv.Quote(out1.String()))
+	}
+}
+
+func TestRunError(t *testing.T) {
+	tests := [][]string{
+		{},
+		{"-n", "-1", "a"},
+		{"("},
+		{"-format", "xml", "a"},
+		{"-names", "a,b", "a"},
+		{"-unknown", "a"},
+	}
+
+	for _, args := range tests {
+		if err := run(args, io.Discard, io.Discard); err == nil {
+			t.Errorf("run with %q did not return an error", args)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package pattern
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// noConsecutiveRetries is the number of times NoConsecutive regenerates an output that repeats the previous one.
+const noConsecutiveRetries = 100
+
+// NoConsecutive returns a Part that regenerates the output of p as long as it equals its previous output directly before it,
+// so a Repeat of NoConsecutive(OneOf(...)) never selects the same choice twice in a row, e.g. to avoid outputs like aaa.
+// Only an output of the same NoConsecutive in the same generation counts, so other Parts between the repetitions hide the repetition.
+//
+// The Part panics with an error wrapping ErrRetriesExhausted if p still repeats the previous output after 100 regenerations,
+// which usually means that p has only one choice.
+func NoConsecutive(p Part) Part {
+	return noConsecutive{
+		part: p,
+		key:  new(byte),
+	}
+}
+
+type noConsecutive struct {
+	part Part
+	// key identifies the Part in the run, which holds its previous output.
+	key *byte
+}
+
+// span is the position of an output in the buffer.
+type span struct {
+	start int
+	end   int
+}
+
+func (p noConsecutive) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p noConsecutive) appendRun(r *run, b []byte) []byte {
+	if r == nil {
+		// Without a run there is no previous output.
+		return appendRun(r, p.part, b)
+	}
+
+	defer r.restoreLimit(r.suspendLimit())
+	start := len(b)
+	prev, ok := r.last[p.key]
+	ok = ok && prev.end == start
+	for i := 0; ; i++ {
+		b = appendRun(r, p.part, b[:start])
+		if !ok || !bytes.Equal(b[prev.start:prev.end], b[start:]) {
+			if r.last == nil {
+				r.last = make(map[*byte]span)
+			}
+			r.last[p.key] = span{start, len(b)}
+			return b
+		}
+
+		if i == noConsecutiveRetries {
+			panic(fmt.Errorf("%w: output still repeats the previous output after %d retries", ErrRetriesExhausted, noConsecutiveRetries))
+		}
+	}
+}
+
+func (p noConsecutive) Children() []Part {
+	return []Part{p.part}
+}
@@ -0,0 +1,54 @@
+package pattern
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNoConsecutive(t *testing.T) {
+	g := New(Repeat(10, 20, NoConsecutive(OneOfString([]string{"a", "b", "cd"}))))
+
+	for i := 0; i < 1000; i++ {
+		s := g.String()
+		for j := 1; j < len(s); j++ {
+			if s[j] == s[j-1] {
+				t.Fatalf("got %q with a repeated choice", s)
+			}
+		}
+	}
+}
+
+func TestNoConsecutiveExhausted(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrRetriesExhausted) {
+			t.Errorf("got %v, want ErrRetriesExhausted", err)
+		}
+	}()
+
+	_ = New(Repeat(2, 2, NoConsecutive(Literal("a")))).String()
+}
+
+func TestNoConsecutiveLengths(t *testing.T) {
+	// "b" after "ab" is a different choice, even though the bytes before it are "b".
+	g := New(Repeat(2, 2, NoConsecutive(OneOfString([]string{"ab", "b"}))))
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		seen[g.String()] = true
+	}
+	for _, want := range []string{"abb", "bab"} {
+		if !seen[want] {
+			t.Errorf("NoConsecutive never returned %q, got %v", want, seen)
+		}
+	}
+	for _, bad := range []string{"abab", "bb"} {
+		if seen[bad] {
+			t.Errorf("NoConsecutive returned repeated choice %q", bad)
+		}
+	}
+
+	// The Literal before is not a previous output of the NoConsecutive.
+	if v := New(Literal("x"), NoConsecutive(Literal("x"))).String(); v != "xx" {
+		t.Errorf("NoConsecutive returned invalid value: want \"xx\", got %q", v)
+	}
+}
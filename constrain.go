@@ -0,0 +1,65 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Constrain returns a Part that regenerates p until its output matches must and does not match mustNot,
+// e.g. for format rules that are easier to filter than to construct.
+// A nil must or mustNot is not checked. Like regexp.Regexp.Match, the expressions match anywhere in the output unless anchored.
+//
+// The Part panics with an error wrapping ErrRetriesExhausted, which names the last output and the violated expression,
+// if no output satisfies the constraints after maxRetries regenerations.
+// Panics if maxRetries is < 0.
+func Constrain(p Part, must *regexp.Regexp, mustNot *regexp.Regexp, maxRetries int) Part {
+	if maxRetries < 0 {
+		panic("maxRetries must be >= 0")
+	}
+
+	return constrain{
+		part:       p,
+		must:       must,
+		mustNot:    mustNot,
+		maxRetries: maxRetries,
+	}
+}
+
+type constrain struct {
+	part       Part
+	must       *regexp.Regexp
+	mustNot    *regexp.Regexp
+	maxRetries int
+}
+
+func (p constrain) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p constrain) appendRun(r *run, b []byte) []byte {
+	defer r.restoreLimit(r.suspendLimit())
+	start := len(b)
+	for i := 0; ; i++ {
+		b = appendRun(r, p.part, b[:start])
+		v := b[start:]
+		if p.must != nil && !p.must.Match(v) {
+			if i == p.maxRetries {
+				panic(fmt.Errorf("%w: %q does not match %s after %d retries", ErrRetriesExhausted, v, p.must, p.maxRetries))
+			}
+			continue
+		}
+
+		if p.mustNot != nil && p.mustNot.Match(v) {
+			if i == p.maxRetries {
+				panic(fmt.Errorf("%w: %q matches %s after %d retries", ErrRetriesExhausted, v, p.mustNot, p.maxRetries))
+			}
+			continue
+		}
+
+		return b
+	}
+}
+
+func (p constrain) Children() []Part {
+	return []Part{p.part}
+}
@@ -0,0 +1,51 @@
+package pattern
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestConstrain(t *testing.T) {
+	// No three identical consecutive characters, which can not be constructed with Repeat.
+	gen := New(Constrain(Repeat(6, 6, OneOfByte([]byte("ab"))), regexp.MustCompile("^a"), regexp.MustCompile("aaa|bbb"), 1000))
+	for i := 0; i < 100; i++ {
+		v := gen.String()
+		if v[0] != 'a' || strings.Contains(v, "aaa") || strings.Contains(v, "bbb") {
+			t.Fatalf("Constrain returned invalid value %q", v)
+		}
+	}
+
+	if v := New(Constrain(Literal("a"), nil, nil, 0)).String(); v != "a" {
+		t.Errorf("Constrain returned invalid value: want \"a\", got %q", v)
+	}
+}
+
+func TestConstrainRetriesExhausted(t *testing.T) {
+	tests := []struct {
+		name    string
+		must    *regexp.Regexp
+		mustNot *regexp.Regexp
+		want    string
+	}{
+		{"must", regexp.MustCompile("b"), nil, `"a" does not match b`},
+		{"mustNot", nil, regexp.MustCompile("a"), `"a" matches a`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				err, ok := recover().(error)
+				if !ok || !errors.Is(err, ErrRetriesExhausted) {
+					t.Fatalf("Constrain did not panic with ErrRetriesExhausted, got %v", err)
+				}
+				if !strings.Contains(err.Error(), tt.want) {
+					t.Errorf("Constrain panicked with %q, want it to contain %q", err, tt.want)
+				}
+			}()
+
+			_ = New(Constrain(Literal("a"), tt.must, tt.mustNot, 3)).String()
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package pattern
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrInvalidArgument is wrapped by the errors of the constructors that return errors instead of panicking, like RepeatE.
+var ErrInvalidArgument = errors.New("pattern: invalid argument")
+
+// NewE returns a new pattern generator like New, but returns the error of Validate instead of a generator if p is invalid,
+// e.g. for patterns built from user configuration at runtime.
+func NewE(p ...Part) (*gen, error) {
+	for i, part := range p {
+		if err := validate(part, strconv.Itoa(i)); err != nil {
+			return nil, err
+		}
+	}
+	return New(p...), nil
+}
+
+// Validate returns an error wrapping ErrInvalidArgument if the generator contains Parts that would panic when generated,
+// like nil Parts or a OneOf, OneOfByte, OneOfRune or OneOfString without choices.
+// The error names the position of the Part, e.g. "part 2.0" for the first child of the third Part of the generator.
+// Children are numbered as visited by Walk.
+func (g gen) Validate() error {
+	for i, p := range g.parts {
+		if err := validate(p, strconv.Itoa(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate returns an error if p or its children at position pos are invalid.
+func validate(p Part, pos string) error {
+	switch p := p.(type) {
+	case nil:
+		return fmt.Errorf("%w: part %s is nil", ErrInvalidArgument, pos)
+	case anyOf:
+		if p.len == 0 {
+			return fmt.Errorf("%w: part %s: OneOf has no Parts", ErrInvalidArgument, pos)
+		}
+	case anyOfByte:
+		if p.len == 0 {
+			return fmt.Errorf("%w: part %s: OneOfByte has an empty alphabet", ErrInvalidArgument, pos)
+		}
+	case anyOfRune:
+		if p.len == 0 {
+			return fmt.Errorf("%w: part %s: OneOfRune has an empty alphabet", ErrInvalidArgument, pos)
+		}
+	case anyOfString:
+		if p.len == 0 {
+			return fmt.Errorf("%w: part %s: OneOfString has no strings", ErrInvalidArgument, pos)
+		}
+	}
+
+	if v, ok := p.(Parent); ok {
+		for i, c := range v.Children() {
+			if err := validate(c, pos+"."+strconv.Itoa(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RepeatE returns a Part like Repeat, but returns an error wrapping ErrInvalidArgument instead of panicking.
+func RepeatE(min uint32, max uint32, p ...Part) (Part, error) {
+	return constructE(func() Part {
+		return Repeat(min, max, p...)
+	})
+}
+
+// SequenceE returns a Part like Sequence, but returns an error wrapping ErrInvalidArgument instead of panicking.
+// SequenceOptions that are invalid, like SequenceStep(0), still panic when they are created.
+func SequenceE(start uint64, max uint64, width int, opts ...SequenceOption) (Part, error) {
+	return constructE(func() Part {
+		return Sequence(start, max, width, opts...)
+	})
+}
+
+// PotentiallyE returns a Part like Potentially, but returns an error wrapping ErrInvalidArgument instead of panicking.
+func PotentiallyE(c float64, p Part) (Part, error) {
+	return constructE(func() Part {
+		return Potentially(c, p)
+	})
+}
+
+// constructE calls f and returns the panic of a constructor as an error wrapping ErrInvalidArgument.
+func constructE(f func() Part) (Part, error) {
+	p, err := construct(f)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArgument, err)
+	}
+	return p, nil
+}
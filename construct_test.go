@@ -0,0 +1,87 @@
+package pattern
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+)
+
+func TestConstructE(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       func() (Part, error)
+		wantErr string
+	}{
+		{"RepeatE", func() (Part, error) { return RepeatE(1, 3, Literal("a")) }, ""},
+		{"RepeatE max", func() (Part, error) { return RepeatE(1, 0, Literal("a")) }, "pattern: invalid argument: max must be > 0"},
+		{"RepeatE min", func() (Part, error) { return RepeatE(3, 1, Literal("a")) }, "pattern: invalid argument: max must be >= min"},
+		{"SequenceE", func() (Part, error) { return SequenceE(1, 10, 2) }, ""},
+		{"SequenceE max", func() (Part, error) { return SequenceE(10, 1, 2) }, "pattern: invalid argument: max must be >= min"},
+		{"PotentiallyE", func() (Part, error) { return PotentiallyE(0.3, Literal("a")) }, ""},
+		{"PotentiallyE chance", func() (Part, error) { return PotentiallyE(-1, Literal("a")) }, "pattern: invalid argument: chance must be > 0"},
+		{"NewE", func() (Part, error) { return NewE(Literal("a")) }, ""},
+		{"NewE nil", func() (Part, error) { return NewE(Literal("a"), nil) }, "pattern: invalid argument: part 1 is nil"},
+		{"NewE OneOfByte", func() (Part, error) { return NewE(Repeat(1, 3, Literal("a"), OneOfByte(nil))) }, "pattern: invalid argument: part 0.1: OneOfByte has an empty alphabet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := tt.f()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("returned unexpected error: %v", err)
+				}
+				_ = New(p).String()
+				return
+			}
+
+			if err == nil || err.Error() != tt.wantErr || !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("returned invalid error: want %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		gen     *gen
+		wantErr string
+	}{
+		{"valid", New(Literal("a"), Repeat(1, 3, OneOfByte([]byte("ab")))), ""},
+		{"nil", New(Literal("a"), Potentially(0.3, nil)), "pattern: invalid argument: part 1.0 is nil"},
+		{"OneOf", New(OneOf()), "pattern: invalid argument: part 0: OneOf has no Parts"},
+		{"OneOfByte", New(Literal("a"), Repeat(1, 3, OneOfByte(nil))), "pattern: invalid argument: part 1.0: OneOfByte has an empty alphabet"},
+		{"OneOfRune", New(Group(Literal("a"), OneOfRune([]rune{}))), "pattern: invalid argument: part 1: OneOfRune has an empty alphabet"},
+		{"OneOfString", New(Join("-", Literal("a"), OneOfString(nil))), "pattern: invalid argument: part 2: OneOfString has no strings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.gen.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate returned unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != tt.wantErr || !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("Validate returned invalid error: want %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestConstructERuntimeError(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(runtime.Error); !ok {
+			t.Error("constructE did not panic with the runtime error")
+		}
+	}()
+
+	var parts []Part
+	_, _ = constructE(func() Part {
+		return parts[0]
+	})
+}
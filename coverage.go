@@ -0,0 +1,62 @@
+package pattern
+
+// Coverage generates n patterns with p and counts how often each choice of the OneOf, OneOfString, OneOfByte and OneOfRune Parts in p was selected.
+// The result holds the counts of each of these Parts in depth-first order, e.g. to verify that every branch of a pattern is reachable.
+// A Part that is used at several positions, e.g. the copies of a constant Repeat, is counted separately at each position.
+// Stateful Parts like Sequence draw their output at random instead of advancing their state.
+func Coverage(p Part, n int) [][]int {
+	// The choices are keyed by their first member, so each position gets its own copy.
+	// Parts inside custom Parts are not copied and are counted once for all their positions.
+	p = clonePart(p, map[any]any{})
+
+	r := &run{
+		deterministic: true,
+		cover:         make(map[any][]int),
+	}
+
+	var counts [][]int
+	walk(p, func(p Part) {
+		key, choices := choiceKey(p)
+		if choices == 0 || r.cover[key] != nil {
+			return
+		}
+
+		c := make([]int, choices)
+		r.cover[key] = c
+		counts = append(counts, c)
+	})
+
+	b := make([]byte, 0, capacityHint([]Part{p}))
+	for i := 0; i < n; i++ {
+		b = appendRun(r, p, b[:0])
+	}
+	return counts
+}
+
+// choiceKey returns the key identifying a OneOf Part in run.cover and its number of choices,
+// or 0 choices if p is not a OneOf Part.
+func choiceKey(p Part) (any, int) {
+	switch p := p.(type) {
+	case anyOf:
+		if len(p.parts) == 0 {
+			break
+		}
+		return &p.parts[0], len(p.parts)
+	case anyOfString:
+		if len(p.alphabet) == 0 {
+			break
+		}
+		return &p.alphabet[0], len(p.alphabet)
+	case anyOfByte:
+		if len(p.alphabet) == 0 {
+			break
+		}
+		return &p.alphabet[0], len(p.alphabet)
+	case anyOfRune:
+		if len(p.alphabet) == 0 {
+			break
+		}
+		return &p.alphabet[0], len(p.alphabet)
+	}
+	return nil, 0
+}
@@ -0,0 +1,57 @@
+package pattern
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestCoverage(t *testing.T) {
+	color := OneOfString([]string{"red", "green", "blue"})
+	gen := New(
+		OneOf(Literal("a"), Literal("b"), color),
+		Repeat(1, 3, OneOfByte([]byte("xy"))),
+		color,
+		Sequence(1, 10, 0),
+	)
+
+	counts := Coverage(gen, 1000)
+
+	// The shared OneOfString is counted at both positions.
+	want := []int{3, 3, 2, 3}
+	if len(counts) != len(want) {
+		t.Fatalf("Coverage returned invalid number of Parts: want %d, got %d", len(want), len(counts))
+	}
+
+	for i := range want {
+		if len(counts[i]) != want[i] {
+			t.Errorf("Coverage returned invalid number of choices: want %d, got %d", want[i], len(counts[i]))
+		}
+		for j, c := range counts[i] {
+			if c == 0 {
+				t.Errorf("Coverage did not select choice %d of Part %d", j, i)
+			}
+		}
+	}
+
+	// The OneOf is selected once per pattern.
+	if sum := counts[0][0] + counts[0][1] + counts[0][2]; sum != 1000 {
+		t.Errorf("Coverage returned invalid counts: want 1000 selections, got %d", sum)
+	}
+
+	// Parts sharing an alphabet are counted separately.
+	words := []string{"a", "b"}
+	counts = Coverage(New(OneOfString(words), Repeat(2, 2, OneOfString(words))), 100)
+	if len(counts) != 3 {
+		t.Fatalf("Coverage returned invalid number of Parts: want 3, got %d", len(counts))
+	}
+	for i, c := range counts {
+		if sum := c[0] + c[1]; sum != 100 {
+			t.Errorf("Coverage returned invalid counts for Part %d: want 100 selections, got %d", i, sum)
+		}
+	}
+
+	// The Sequence was not advanced.
+	if v := gen.String(); v[len(v)-1] != '1' {
+		t.Errorf("Coverage advanced the sequence: got %s", strconv.Quote(v))
+	}
+}
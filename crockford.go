@@ -0,0 +1,164 @@
+package pattern
+
+import (
+	"errors"
+)
+
+const (
+	crockfordDigits = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// crockfordCheck holds the check symbols, which extend the digits by five symbols for the values 32 to 36.
+	crockfordCheck = crockfordDigits + "*~$=U"
+)
+
+// RandomCrockford returns a Part that outputs n random digits of Crockford's base32,
+// a case-insensitive alphabet without the easily confused I, L, O and U.
+// If check is true, the check symbol of the digits (their value modulo 37) is appended, which detects typos.
+// Use CheckCrockford to validate the output, or DecodeCrockford to decode outputs with n <= 12.
+//
+// https://www.crockford.com/base32.html
+//
+// Panics if n is < 0.
+func RandomCrockford(n int, check bool) Part {
+	if n < 0 {
+		panic("n must be >= 0")
+	}
+
+	if check {
+		return crockford{
+			n: n,
+		}
+	}
+
+	return nanoIDMask{
+		alphabet: []byte(crockfordDigits),
+		mask:     byte(len(crockfordDigits) - 1),
+		size:     n,
+	}
+}
+
+// crockford outputs random digits of Crockford's base32 followed by their check symbol.
+type crockford struct {
+	n int
+}
+
+func (p crockford) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p crockford) appendRun(r *run, b []byte) []byte {
+	// Use 60 bits of each random number.
+	var v, mod uint64
+	for i := 0; i < p.n; i++ {
+		if i%12 == 0 {
+			v = r.uint64()
+		}
+		d := v & 31
+		v >>= 5
+
+		b = append(b, crockfordDigits[d])
+		mod = (mod*32 + d) % 37
+	}
+	return append(b, crockfordCheck[mod])
+}
+
+var (
+	errCrockfordEmpty    = errors.New("pattern: empty Crockford base32 string")
+	errCrockfordDigit    = errors.New("pattern: invalid Crockford base32 digit")
+	errCrockfordOverflow = errors.New("pattern: Crockford base32 value overflows uint64")
+	errCrockfordCheck    = errors.New("pattern: invalid Crockford base32 check symbol")
+)
+
+// DecodeCrockford returns the value of the Crockford base32 string s.
+// Like the specification requires, lowercase letters are accepted, O is read as 0, I and L as 1, and hyphens are ignored.
+// If check is true, the last symbol of s must be the check symbol of the value.
+// DecodeCrockford returns an error if s is empty, contains invalid characters, has an invalid check symbol
+// or its value does not fit into an uint64 (more than 13 digits).
+func DecodeCrockford(s string, check bool) (uint64, error) {
+	digits := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '-' {
+			digits = append(digits, s[i])
+		}
+	}
+
+	var c byte
+	if check && len(digits) > 0 {
+		digits, c = digits[:len(digits)-1], digits[len(digits)-1]
+	}
+
+	if len(digits) == 0 {
+		return 0, errCrockfordEmpty
+	}
+
+	var v uint64
+	for _, d := range digits {
+		x := crockfordValue(d)
+		if x < 0 || x >= 32 {
+			return 0, errCrockfordDigit
+		}
+		if v>>59 != 0 {
+			return 0, errCrockfordOverflow
+		}
+		v = v<<5 | uint64(x)
+	}
+
+	if check && crockfordValue(c) != int(v%37) {
+		return 0, errCrockfordCheck
+	}
+	return v, nil
+}
+
+// CheckCrockford returns an error if s is not a Crockford base32 string followed by its check symbol.
+// It accepts the same input as DecodeCrockford, but since the check symbol is accumulated digit by digit, s can have any number of digits.
+func CheckCrockford(s string) error {
+	var (
+		mod uint64
+		n   int
+		c   byte
+	)
+	for i := 0; i < len(s); i++ {
+		if s[i] == '-' {
+			continue
+		}
+
+		// The last symbol is the check symbol, so each symbol is only added once the next one is read.
+		if n > 0 {
+			x := crockfordValue(c)
+			if x < 0 || x >= 32 {
+				return errCrockfordDigit
+			}
+			mod = (mod*32 + uint64(x)) % 37
+		}
+		c = s[i]
+		n++
+	}
+
+	if n < 2 {
+		return errCrockfordEmpty
+	}
+	if crockfordValue(c) != int(mod) {
+		return errCrockfordCheck
+	}
+	return nil
+}
+
+// crockfordValue returns the value of the digit or check symbol c or -1 if it is invalid.
+func crockfordValue(c byte) int {
+	if 'a' <= c && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+
+	switch c {
+	case 'O':
+		return 0
+	case 'I', 'L':
+		return 1
+	}
+
+	for i := 0; i < len(crockfordCheck); i++ {
+		if crockfordCheck[i] == c {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,111 @@
+package pattern
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRandomCrockford(t *testing.T) {
+	for _, check := range []bool{false, true} {
+		for _, n := range []int{1, 5, 12, 13, 30} {
+			p := RandomCrockford(n, check)
+			for i := 0; i < 100; i++ {
+				v := string(p.Append(nil))
+				want := n
+				if check {
+					want++
+				}
+				if len(v) != want || strings.ContainsAny(v[:n], "ILOU") {
+					t.Fatalf("RandomCrockford(%d, %t) returned invalid value %q", n, check, v)
+				}
+				if check {
+					if err := CheckCrockford(v); err != nil {
+						t.Fatalf("CheckCrockford(%q) returned error: %v", v, err)
+					}
+				}
+				if n > 12 {
+					continue
+				}
+				if _, err := DecodeCrockford(v, check); err != nil {
+					t.Fatalf("DecodeCrockford(%q, %t) returned error: %v", v, check, err)
+				}
+			}
+		}
+	}
+
+	// The check symbol of 12 zero digits is 0.
+	if v := StringFrom(RandomCrockford(12, true), zeroSource{}); v != "0000000000000" {
+		t.Errorf("got %q, want 0000000000000", v)
+	}
+
+	data, err := json.Marshal(New(RandomCrockford(4, true)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"New","parts":[{"type":"RandomCrockford","size":4,"check":true}]}`; string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
+
+func TestCheckCrockford(t *testing.T) {
+	tests := []struct {
+		s   string
+		err error
+	}{
+		{"1-0*", nil},
+		{"16Jd", nil},
+		{"16J0", errCrockfordCheck},
+		{"0", errCrockfordEmpty},
+		{"-", errCrockfordEmpty},
+		{"1U0", errCrockfordDigit},
+		// 32 digits overflow uint64, but the check symbol is still valid.
+		{"zzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzz" + string(crockfordCheck[checkOfZs(32)]), nil},
+	}
+
+	for _, tt := range tests {
+		if err := CheckCrockford(tt.s); err != tt.err {
+			t.Errorf("CheckCrockford(%q) returned error %v, want %v", tt.s, err, tt.err)
+		}
+	}
+}
+
+// checkOfZs returns the check value of n Z digits.
+func checkOfZs(n int) int {
+	mod := 0
+	for i := 0; i < n; i++ {
+		mod = (mod*32 + 31) % 37
+	}
+	return mod
+}
+
+func TestDecodeCrockford(t *testing.T) {
+	tests := []struct {
+		s     string
+		check bool
+		want  uint64
+		err   error
+	}{
+		{"10", false, 32, nil},
+		{"1-0*", true, 32, nil},
+		{"oIl", false, 33, nil},
+		{"zz", false, 1023, nil},
+		{"16J", false, 1234, nil},
+		{"16Jd", true, 1234, nil},
+		{"16J0", true, 0, errCrockfordCheck},
+		{"FZZZZZZZZZZZZ", false, 1<<64 - 1, nil},
+		{"G000000000000", false, 0, errCrockfordOverflow},
+		{"1U", false, 0, errCrockfordDigit},
+		{"1*", false, 0, errCrockfordDigit},
+		{"", false, 0, errCrockfordEmpty},
+		{"-", true, 0, errCrockfordEmpty},
+		{"A", true, 0, errCrockfordEmpty},
+	}
+
+	for _, test := range tests {
+		v, err := DecodeCrockford(test.s, test.check)
+		if v != test.want || err != test.err {
+			t.Errorf("DecodeCrockford(%q, %t) = %d, %v, want %d, %v", test.s, test.check, v, err, test.want, test.err)
+		}
+	}
+}
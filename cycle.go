@@ -0,0 +1,69 @@
+package pattern
+
+import (
+	"sync/atomic"
+)
+
+// Cycle returns a Part that outputs p in rotating order, one Part per iteration, e.g. to distribute IDs fairly across prefixes.
+// Like OneOf, but deterministic and fair.
+// Cycle is thread safe.
+//
+// Panics if p is empty.
+func Cycle(p ...Part) Part {
+	if len(p) == 0 {
+		panic("parts must not be empty")
+	}
+
+	// Cycle with one Part is just the Part.
+	if len(p) == 1 {
+		return p[0]
+	}
+
+	return cycle{
+		parts: p,
+		curr:  new(uint64),
+	}
+}
+
+type cycle struct {
+	parts []Part
+	// curr is the number of iterations so far.
+	curr *uint64
+}
+
+func (p cycle) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p cycle) appendRun(r *run, b []byte) []byte {
+	if r.pure() {
+		return appendRun(r, p.parts[r.randN(uint32(len(p.parts)))], b)
+	}
+
+	i := (atomic.AddUint64(p.curr, 1) - 1) % uint64(len(p.parts))
+	return appendRun(r, p.parts[i], b)
+}
+
+// oneOf returns the OneOf with the same outputs as the Cycle.
+func (p cycle) oneOf() anyOf {
+	return anyOf{
+		parts: p.parts,
+		len:   uint32(len(p.parts)),
+	}
+}
+
+func (p cycle) Children() []Part {
+	return p.parts
+}
+
+func (p cycle) saveState() partState {
+	return partState{
+		Kind: "cycle",
+		Curr: atomic.LoadUint64(p.curr),
+	}
+}
+
+func (p cycle) loadState(s partState) error {
+	atomic.StoreUint64(p.curr, s.Curr)
+	return nil
+}
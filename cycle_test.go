@@ -0,0 +1,69 @@
+package pattern
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCycle(t *testing.T) {
+	g := New(Cycle(Literal("A"), Literal("B"), Literal("C")), Literal("-"))
+
+	want := []string{"A-", "B-", "C-", "A-", "B-"}
+	for i, w := range want {
+		if s := g.String(); s != w {
+			t.Errorf("iteration %d: got %q, want %q", i, s, w)
+		}
+	}
+}
+
+func TestCycleConcurrent(t *testing.T) {
+	g := New(Cycle(Literal("A"), Literal("B"), Literal("C")))
+
+	var mu sync.Mutex
+	counts := map[string]int{}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 300; j++ {
+				s := g.String()
+				mu.Lock()
+				counts[s]++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, s := range []string{"A", "B", "C"} {
+		if counts[s] != 800 {
+			t.Errorf("%s was generated %d times, want 800", s, counts[s])
+		}
+	}
+}
+
+func TestCycleState(t *testing.T) {
+	g := New(Cycle(Literal("A"), Literal("B"), Literal("C")))
+	_ = g.String()
+
+	state, err := g.MarshalState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := g.Clone()
+	if s := c.String(); s != "B" {
+		t.Errorf("clone got %q, want \"B\"", s)
+	}
+	if s := g.String(); s != "B" {
+		t.Errorf("original got %q after the clone advanced, want \"B\"", s)
+	}
+
+	if err := g.UnmarshalState(state); err != nil {
+		t.Fatal(err)
+	}
+	if s := g.String(); s != "B" {
+		t.Errorf("got %q after restoring the state, want \"B\"", s)
+	}
+}
@@ -0,0 +1,113 @@
+package pattern
+
+import (
+	"hash/maphash"
+	"math"
+	"sync"
+)
+
+// Store remembers values for Dedup.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Add adds v to the store and reports whether v was not part of it before.
+	// Add must not retain v.
+	Add(v []byte) (bool, error)
+}
+
+// Dedup returns a Part that never outputs a value of p twice, by remembering all values in s.
+// p is regenerated if s already contains its output.
+//
+// The Part panics with an error wrapping ErrRetriesExhausted if no new value is found within maxRetries regenerations,
+// and with the error of s if Add fails.
+func Dedup(p Part, s Store, maxRetries int) Part {
+	return UniqueBy(p, func(b []byte) bool {
+		added, err := s.Add(b)
+		if err != nil {
+			panic(err)
+		}
+		return !added
+	}, maxRetries)
+}
+
+// NewMemoryStore returns a Store that keeps all values in memory.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		values: make(map[string]struct{}),
+	}
+}
+
+type memoryStore struct {
+	mu     sync.Mutex
+	values map[string]struct{}
+}
+
+func (s *memoryStore) Add(v []byte) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.values[string(v)]; ok {
+		return false, nil
+	}
+	s.values[string(v)] = struct{}{}
+	return true, nil
+}
+
+// NewBloomStore returns a Store backed by a Bloom filter sized for n values with a false positive rate of p.
+// The filter uses a constant amount of memory, but may report new values as already added with probability p,
+// which makes Dedup regenerate them.
+//
+// Panics if n is 0 or p is not in (0, 1).
+func NewBloomStore(n uint64, p float64) Store {
+	if n == 0 {
+		panic("n must be > 0")
+	}
+
+	if p <= 0 || p >= 1 {
+		panic("p must be in (0, 1)")
+	}
+
+	// Optimal number of bits and hash functions.
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k := int(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+
+	return &bloomStore{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+		seed: maphash.MakeSeed(),
+	}
+}
+
+type bloomStore struct {
+	mu   sync.Mutex
+	bits []uint64
+	m    uint64
+	k    int
+	seed maphash.Seed
+}
+
+func (s *bloomStore) Add(v []byte) (bool, error) {
+	var h maphash.Hash
+	h.SetSeed(s.seed)
+	h.Write(v)
+	h1 := h.Sum64()
+	h2 := mix64(h1) | 1
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Double hashing: https://www.eecs.harvard.edu/~michaelm/postscripts/rsa2008.pdf
+	added := false
+	for i := 0; i < s.k; i++ {
+		bit := (h1 + uint64(i)*h2) % s.m
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if s.bits[word]&mask == 0 {
+			s.bits[word] |= mask
+			added = true
+		}
+	}
+	return added, nil
+}
@@ -0,0 +1,101 @@
+package pattern
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestDedup(t *testing.T) {
+	stores := []struct {
+		name  string
+		store Store
+	}{
+		{"memory", NewMemoryStore()},
+		{"bloom", NewBloomStore(10000, 0.001)},
+	}
+
+	for _, tt := range stores {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := New(Dedup(Repeat(3, 3, OneOfByte([]byte("0123456789"))), tt.store, 100000))
+
+			var mu sync.Mutex
+			seen := make(map[string]bool, 500)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 125; j++ {
+						v := gen.String()
+
+						mu.Lock()
+						if seen[v] {
+							t.Errorf("Dedup returned duplicate value %s", strconv.Quote(v))
+						}
+						seen[v] = true
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestDedupExhausted(t *testing.T) {
+	gen := New(Dedup(OneOfByte([]byte("ab")), NewMemoryStore(), 100))
+	id = gen.String()
+	id = gen.String()
+
+	func() {
+		defer func() {
+			r := recover()
+			err, ok := r.(error)
+			if !ok || !errors.Is(err, ErrRetriesExhausted) {
+				t.Errorf("Dedup with exhausted values panicked with invalid value: want %v, got %v", ErrRetriesExhausted, r)
+			}
+		}()
+
+		id = gen.String()
+	}()
+}
+
+type failingStore struct{}
+
+var errStore = errors.New("store failed")
+
+func (failingStore) Add([]byte) (bool, error) {
+	return false, errStore
+}
+
+func TestDedupStoreError(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r != errStore {
+				t.Errorf("Dedup with failing store panicked with invalid value: want %v, got %v", errStore, r)
+			}
+		}()
+
+		id = New(Dedup(Literal("a"), failingStore{}, 1)).String()
+	}()
+}
+
+func TestBloomStorePanic(t *testing.T) {
+	for _, tt := range []struct {
+		n uint64
+		p float64
+	}{{0, 0.1}, {10, 0}, {10, 1}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("NewBloomStore(%d, %f) did not panic", tt.n, tt.p)
+				}
+			}()
+
+			NewBloomStore(tt.n, tt.p)
+		}()
+	}
+}
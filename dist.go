@@ -0,0 +1,142 @@
+package pattern
+
+import (
+	"math"
+
+	"github.com/sollniss/pattern/internal"
+)
+
+// Distribution returns a random number drawn from src, e.g. the number of repetitions of RepeatDist.
+// Drawing all randomness from src keeps generators with StringFor and StringFrom deterministic.
+type Distribution func(src Source) uint32
+
+// RepeatDist returns a Part that repeats p a number of times drawn from dist in each iteration,
+// e.g. RepeatDist(Geometric(0.3), p) for lengths that are short most of the time and long sometimes.
+//
+// Panics if dist is nil.
+func RepeatDist(dist Distribution, p ...Part) Part {
+	if dist == nil {
+		panic("dist must not be nil")
+	}
+
+	return repeatDist{
+		parts: p,
+		dist:  dist,
+	}
+}
+
+type repeatDist struct {
+	parts []Part
+	dist  Distribution
+}
+
+func (p repeatDist) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p repeatDist) appendRun(r *run, b []byte) []byte {
+	n := p.dist(runSource{r})
+	for i := uint32(0); i < n; i++ {
+		for _, part := range p.parts {
+			b = appendRun(r, part, b)
+		}
+	}
+	return b
+}
+
+func (p repeatDist) Children() []Part {
+	return p.parts
+}
+
+// runSource is the Source of the random numbers of a run.
+type runSource struct {
+	r *run
+}
+
+func (s runSource) Uint64() uint64 {
+	return s.r.uint64()
+}
+
+// Geometric returns a Distribution of the number of failures before the first success of trials that succeed with probability p,
+// which is k with probability (1-p)^k * p and has the mean (1-p)/p.
+//
+// Panics if p is not in (0, 1].
+func Geometric(p float64) Distribution {
+	if !(p > 0 && p <= 1) {
+		panic("p must be in (0, 1]")
+	}
+
+	if p == 1 {
+		return func(src Source) uint32 {
+			return 0
+		}
+	}
+
+	// Inversion of the cumulative distribution function.
+	l := math.Log1p(-p)
+	return func(src Source) uint32 {
+		u := 1 - internal.Float64(src.Uint64())
+		return clampUint32(math.Floor(math.Log(u) / l))
+	}
+}
+
+// Poisson returns a Distribution of the number of events in an interval with the mean rate lambda,
+// which is k with probability lambda^k * e^-lambda / k!.
+//
+// Panics if lambda is < 0 or not finite.
+func Poisson(lambda float64) Distribution {
+	if !(lambda >= 0) || math.IsInf(lambda, 0) {
+		panic("lambda must be >= 0")
+	}
+
+	if lambda < 10 {
+		// Knuth's multiplication method, which takes O(lambda) time.
+		l := math.Exp(-lambda)
+		return func(src Source) uint32 {
+			k := uint32(0)
+			for p := internal.Float64(src.Uint64()); p > l; p *= internal.Float64(src.Uint64()) {
+				k++
+			}
+			return k
+		}
+	}
+
+	// The transformed rejection method with squeeze (PTRS) of Hörmann, which takes constant time.
+	// https://doi.org/10.1016/0167-6687(93)90997-4
+	slam := math.Sqrt(lambda)
+	loglam := math.Log(lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invalpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	return func(src Source) uint32 {
+		for {
+			u := internal.Float64(src.Uint64()) - 0.5
+			v := internal.Float64(src.Uint64())
+			us := 0.5 - math.Abs(u)
+			k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+			if us >= 0.07 && v <= vr {
+				return clampUint32(k)
+			}
+			if k < 0 || (us < 0.013 && v > us) {
+				continue
+			}
+
+			lg, _ := math.Lgamma(k + 1)
+			if math.Log(v)+math.Log(invalpha)-math.Log(a/(us*us)+b) <= -lambda+k*loglam-lg {
+				return clampUint32(k)
+			}
+		}
+	}
+}
+
+// clampUint32 returns x clamped to the range of uint32.
+func clampUint32(x float64) uint32 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return uint32(x)
+}
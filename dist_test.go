@@ -0,0 +1,105 @@
+package pattern
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// distMean returns the mean of n numbers drawn from dist.
+func distMean(dist Distribution, n int) float64 {
+	src := rand.New(rand.NewSource(1))
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		sum += float64(dist(src))
+	}
+	return sum / float64(n)
+}
+
+func TestGeometric(t *testing.T) {
+	for _, p := range []float64{0.1, 0.5, 0.9} {
+		want := (1 - p) / p
+		if got := distMean(Geometric(p), 100000); math.Abs(got-want) > 0.05*want+0.01 {
+			t.Errorf("Geometric(%v) has invalid mean: want %v, got %v", p, want, got)
+		}
+	}
+
+	if got := distMean(Geometric(1), 100); got != 0 {
+		t.Errorf("Geometric(1) has invalid mean: want 0, got %v", got)
+	}
+}
+
+func TestPoisson(t *testing.T) {
+	// Both methods and the switch between them.
+	for _, lambda := range []float64{0, 0.5, 3, 9.9, 10, 42, 1000} {
+		if got := distMean(Poisson(lambda), 100000); math.Abs(got-lambda) > 0.02*lambda+0.01 {
+			t.Errorf("Poisson(%v) has invalid mean: want %v, got %v", lambda, lambda, got)
+		}
+	}
+
+	// The variance equals the mean.
+	src := rand.New(rand.NewSource(1))
+	dist := Poisson(42)
+	sum, sq := 0.0, 0.0
+	const n = 100000
+	for i := 0; i < n; i++ {
+		k := float64(dist(src))
+		sum += k
+		sq += k * k
+	}
+	mean := sum / n
+	if v := sq/n - mean*mean; math.Abs(v-42) > 2 {
+		t.Errorf("Poisson(42) has invalid variance: want 42, got %v", v)
+	}
+}
+
+func TestRepeatDist(t *testing.T) {
+	gen := New(RepeatDist(Geometric(0.5), Literal("ab")))
+
+	total := 0
+	for i := 0; i < 10000; i++ {
+		v := gen.String()
+		if strings.ReplaceAll(v, "ab", "") != "" {
+			t.Fatalf("RepeatDist returned invalid value: %q", v)
+		}
+		total += len(v) / 2
+	}
+
+	if mean := float64(total) / 10000; math.Abs(mean-1) > 0.1 {
+		t.Errorf("RepeatDist has invalid mean number of repetitions: want 1, got %v", mean)
+	}
+
+	// The distribution draws from the source of StringFor.
+	gen = New(RepeatDist(Poisson(20), OneOfByte(AlphaLower)))
+	for i := 0; i < 10; i++ {
+		key := []byte{byte(i)}
+		if v1, v2 := gen.StringFor(key), gen.StringFor(key); v1 != v2 {
+			t.Errorf("StringFor returned different values for the same key: %q != %q", v1, v2)
+		}
+	}
+}
+
+func TestDistPanic(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"RepeatDist", func() { RepeatDist(nil, Literal("a")) }},
+		{"Geometric 0", func() { Geometric(0) }},
+		{"Geometric > 1", func() { Geometric(1.5) }},
+		{"Poisson", func() { Poisson(-1) }},
+		{"Poisson Inf", func() { Poisson(math.Inf(1)) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("did not panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package pattern
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// repeatDistinctRetries is the number of times RepeatDistinct regenerates a repetition that duplicates an earlier one.
+const repeatDistinctRetries = 100
+
+// RepeatDistinct returns a Part that repeats p between min and max times randomly,
+// regenerating repetitions until each one differs from all earlier repetitions, e.g. for lists of unique tags.
+//
+// The Part panics with an error wrapping ErrRetriesExhausted if a repetition still duplicates an earlier one after 100 regenerations.
+// Panics if max < min, max is 0 or the outputs of p can be counted and there are fewer than max.
+func RepeatDistinct(min uint32, max uint32, p Part) Part {
+	if max == 0 {
+		panic("max must be > 0")
+	}
+
+	if max < min {
+		panic("max must be >= min")
+	}
+
+	if canCount(p) {
+		if n, ok := p.(countable).count(); ok && n < uint64(max) {
+			panic(fmt.Sprintf("part generates %d distinct values, want at least %d", n, max))
+		}
+	}
+
+	return repeatDistinct{
+		part: p,
+		min:  min,
+		maxr: (max - min) + 1,
+	}
+}
+
+type repeatDistinct struct {
+	part Part
+	min  uint32
+	// maxr is the value needed to generate [min, max] with the RNG.
+	maxr uint32
+}
+
+func (p repeatDistinct) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p repeatDistinct) appendRun(r *run, b []byte) []byte {
+	defer r.restoreLimit(r.suspendLimit())
+	n := r.randN(p.maxr) + p.min
+
+	// offsets holds the start of each repetition and the end of the last one.
+	var buf [16]int
+	offsets := append(buf[:0], len(b))
+	for i := uint32(0); i < n; i++ {
+		start := len(b)
+		for retry := 0; ; retry++ {
+			b = appendRun(r, p.part, b[:start])
+			if !p.duplicate(b, offsets) {
+				break
+			}
+
+			if retry == repeatDistinctRetries {
+				panic(fmt.Errorf("%w: repetition %d still duplicates an earlier one after %d retries", ErrRetriesExhausted, i+1, repeatDistinctRetries))
+			}
+		}
+		offsets = append(offsets, len(b))
+	}
+	return b
+}
+
+// duplicate reports whether the last repetition of b equals one of the earlier repetitions delimited by offsets.
+func (p repeatDistinct) duplicate(b []byte, offsets []int) bool {
+	last := b[offsets[len(offsets)-1]:]
+	for i := 0; i < len(offsets)-1; i++ {
+		if bytes.Equal(b[offsets[i]:offsets[i+1]], last) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p repeatDistinct) Children() []Part {
+	return []Part{p.part}
+}
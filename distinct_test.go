@@ -0,0 +1,60 @@
+package pattern
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRepeatDistinct(t *testing.T) {
+	g := New(Literal("a"), RepeatDistinct(3, 5, OneOfByte([]byte("abcde"))))
+
+	lengths := map[int]bool{}
+	for i := 0; i < 1000; i++ {
+		s := g.String()
+		seen := map[byte]bool{}
+		for _, c := range []byte(s[1:]) {
+			if seen[c] {
+				t.Fatalf("got %q with a duplicate repetition", s)
+			}
+			seen[c] = true
+		}
+		lengths[len(s)-1] = true
+	}
+
+	if len(lengths) != 3 {
+		t.Errorf("got %d distinct numbers of repetitions, want 3", len(lengths))
+	}
+}
+
+func TestRepeatDistinctVariableLength(t *testing.T) {
+	g := New(RepeatDistinct(2, 2, OneOfString([]string{"ab", "a", "b"})))
+	for i := 0; i < 1000; i++ {
+		if s := g.String(); s == "abab" || s == "aa" || s == "bb" {
+			t.Fatalf("got %q with a duplicate repetition", s)
+		}
+	}
+}
+
+func TestRepeatDistinctPanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("RepeatDistinct with too few values did not panic")
+			}
+		}()
+
+		RepeatDistinct(1, 4, OneOfByte([]byte("abc")))
+	}()
+
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, ErrRetriesExhausted) {
+				t.Errorf("got %v, want ErrRetriesExhausted", err)
+			}
+		}()
+
+		// The outputs of a PartFunc can not be counted, so the duplicates are only detected during generation.
+		_ = New(RepeatDistinct(2, 2, PartFunc(func(b []byte) []byte { return append(b, 'x') }))).String()
+	}()
+}
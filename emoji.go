@@ -0,0 +1,123 @@
+package pattern
+
+import (
+	"strconv"
+)
+
+// EmojiCategory is a curated group of emoji for OneOfEmoji.
+type EmojiCategory uint8
+
+const (
+	// EmojiFaces holds smileys and other faces.
+	EmojiFaces EmojiCategory = iota
+	// EmojiAnimals holds animals and animal faces.
+	EmojiAnimals
+	// EmojiHands holds hand gestures in the default yellow and all five skin tones.
+	EmojiHands
+	// EmojiFlags holds the flags of common countries, which are pairs of regional indicators.
+	EmojiFlags
+	// EmojiSequences holds ZWJ sequences like professions, families and the rainbow flag.
+	EmojiSequences
+	// EmojiAll holds the emoji of all categories.
+	EmojiAll
+)
+
+func (c EmojiCategory) String() string {
+	switch c {
+	case EmojiFaces:
+		return "EmojiFaces"
+	case EmojiAnimals:
+		return "EmojiAnimals"
+	case EmojiHands:
+		return "EmojiHands"
+	case EmojiFlags:
+		return "EmojiFlags"
+	case EmojiSequences:
+		return "EmojiSequences"
+	case EmojiAll:
+		return "EmojiAll"
+	}
+	return "EmojiCategory(" + strconv.Itoa(int(c)) + ")"
+}
+
+// OneOfEmoji returns a Part that will output one emoji of category randomly in each iteration.
+// Every emoji is output as a whole, including skin tone modifiers, variation selectors and zero width joiners,
+// which OneOfRune can not express.
+//
+// Panics if category is unknown.
+func OneOfEmoji(category EmojiCategory) Part {
+	if category > EmojiAll {
+		panic("unknown emoji category")
+	}
+
+	return OneOfString(emoji[category])
+}
+
+// emoji holds the emoji of each category.
+var emoji = func() [EmojiAll + 1][]string {
+	var e [EmojiAll + 1][]string
+
+	// Faces.
+	e[EmojiFaces] = emojiRange(nil, 0x1F600, 0x1F637)
+	e[EmojiFaces] = emojiRange(e[EmojiFaces], 0x1F910, 0x1F915)
+	e[EmojiFaces] = emojiRange(e[EmojiFaces], 0x1F920, 0x1F925)
+	e[EmojiFaces] = emojiRange(e[EmojiFaces], 0x1F927, 0x1F92F)
+
+	// Animals.
+	e[EmojiAnimals] = emojiRange(nil, 0x1F400, 0x1F43C)
+	e[EmojiAnimals] = emojiRange(e[EmojiAnimals], 0x1F980, 0x1F984)
+
+	// Hands without and with the skin tone modifiers U+1F3FB to U+1F3FF.
+	for _, h := range []rune{0x1F44A, 0x1F44B, 0x1F44C, 0x1F44D, 0x1F44E, 0x1F44F, 0x1F450, 0x1F4AA, 0x1F64C, 0x1F64F, 0x1F918, 0x1F919, 0x1F91E} {
+		e[EmojiHands] = append(e[EmojiHands], string(h))
+		for tone := rune(0x1F3FB); tone <= 0x1F3FF; tone++ {
+			e[EmojiHands] = append(e[EmojiHands], string([]rune{h, tone}))
+		}
+	}
+
+	// Flags are the country code mapped to regional indicators.
+	for _, cc := range []string{
+		"AE", "AR", "AT", "AU", "BE", "BR", "CA", "CH", "CL", "CN", "CO", "CZ", "DE", "DK", "EG", "ES", "FI", "FR", "GB", "GR",
+		"HU", "ID", "IE", "IL", "IN", "IT", "JP", "KE", "KR", "MX", "MY", "NG", "NL", "NO", "NZ", "PH", "PL", "PT", "RO", "SA",
+		"SE", "SG", "TH", "TR", "UA", "US", "VN", "ZA",
+	} {
+		e[EmojiFlags] = append(e[EmojiFlags], string([]rune{0x1F1E6 + rune(cc[0]-'A'), 0x1F1E6 + rune(cc[1]-'A')}))
+	}
+
+	// ZWJ sequences, \u200d is the zero width joiner and \ufe0f the emoji presentation selector.
+	e[EmojiSequences] = []string{
+		"\U0001F469\u200d\U0001F4BB",                                 // woman technologist
+		"\U0001F468\u200d\U0001F4BB",                                 // man technologist
+		"\U0001F469\U0001F3FD\u200d\U0001F4BB",                       // woman technologist: medium skin tone
+		"\U0001F469\u200d\U0001F680",                                 // woman astronaut
+		"\U0001F9D1\U0001F3FF\u200d\U0001F680",                       // astronaut: dark skin tone
+		"\U0001F468\u200d\U0001F373",                                 // man cook
+		"\U0001F469\u200d\U0001F52C",                                 // woman scientist
+		"\U0001F468\u200d\U0001F3A8",                                 // man artist
+		"\U0001F469\u200d\U0001F3EB",                                 // woman teacher
+		"\U0001F468\u200d\U0001F692",                                 // man firefighter
+		"\U0001F468\u200d\U0001F469\u200d\U0001F467",                 // family: man, woman, girl
+		"\U0001F468\u200d\U0001F469\u200d\U0001F467\u200d\U0001F466", // family: man, woman, girl, boy
+		"\U0001F469\u200d\u2764\ufe0f\u200d\U0001F468",               // couple with heart
+		"\U0001F3F3\ufe0f\u200d\U0001F308",                           // rainbow flag
+		"\U0001F3F4\u200d\u2620\ufe0f",                               // pirate flag
+		"\U0001F43B\u200d\u2744\ufe0f",                               // polar bear
+		"\U0001F415\u200d\U0001F9BA",                                 // service dog
+		"\U0001F408\u200d\u2b1b",                                     // black cat
+		"\u2764\ufe0f\u200d\U0001F525",                               // heart on fire
+		"\U0001F636\u200d\U0001F32B\ufe0f",                           // face in clouds
+	}
+
+	for c := EmojiFaces; c < EmojiAll; c++ {
+		e[EmojiAll] = append(e[EmojiAll], e[c]...)
+	}
+	return e
+}()
+
+// emojiRange appends the emoji from lo to hi inclusive to e.
+func emojiRange(e []string, lo rune, hi rune) []string {
+	for r := lo; r <= hi; r++ {
+		e = append(e, string(r))
+	}
+	return e
+}
@@ -0,0 +1,43 @@
+package pattern
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestOneOfEmoji(t *testing.T) {
+	for c := EmojiFaces; c <= EmojiAll; c++ {
+		t.Run(c.String(), func(t *testing.T) {
+			seen := make(map[string]bool, len(emoji[c]))
+			for _, e := range emoji[c] {
+				if n := len(splitGraphemes(e)); n != 1 {
+					t.Errorf("%s is not a single grapheme cluster: got %d clusters", strconv.QuoteToASCII(e), n)
+				}
+				if seen[e] {
+					t.Errorf("duplicate emoji %s", strconv.QuoteToASCII(e))
+				}
+				seen[e] = true
+			}
+
+			gen := New(OneOfEmoji(c))
+			for i := 0; i < 100; i++ {
+				if v := gen.String(); !seen[v] {
+					t.Fatalf("OneOfEmoji returned invalid value: %s", strconv.QuoteToASCII(v))
+				}
+			}
+		})
+	}
+
+	if n, want := len(emoji[EmojiAll]), len(emoji[EmojiFaces])+len(emoji[EmojiAnimals])+len(emoji[EmojiHands])+len(emoji[EmojiFlags])+len(emoji[EmojiSequences]); n != want {
+		t.Errorf("EmojiAll has invalid number of emoji: want %d, got %d", want, n)
+	}
+}
+
+func TestOneOfEmojiPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("OneOfEmoji did not panic on an unknown category")
+		}
+	}()
+	OneOfEmoji(EmojiAll + 1)
+}
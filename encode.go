@@ -0,0 +1,92 @@
+package pattern
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// HexEncode returns a Part that encodes the output of p as lowercase hex, e.g. to print raw binary generated by ReadN or FromFunc.
+func HexEncode(p Part) Part {
+	return encoded{
+		part: p,
+		enc:  hexEncoder{},
+		name: "HexEncode",
+		std:  true,
+	}
+}
+
+// Base64Encode returns a Part that encodes the output of p with encoding.
+// If encoding is nil, the standard base64 alphabet without padding is used.
+func Base64Encode(p Part, encoding *base64.Encoding) Part {
+	e := encoded{
+		part: p,
+		enc:  encoding,
+		name: "Base64Encode",
+	}
+	if encoding == nil {
+		e.enc = base64.RawStdEncoding
+		e.std = true
+	}
+	return e
+}
+
+// Base32Encode returns a Part that encodes the output of p with encoding.
+// If encoding is nil, the standard base32 alphabet without padding is used.
+func Base32Encode(p Part, encoding *base32.Encoding) Part {
+	e := encoded{
+		part: p,
+		enc:  encoding,
+		name: "Base32Encode",
+	}
+	if encoding == nil {
+		e.enc = base32.StdEncoding.WithPadding(base32.NoPadding)
+		e.std = true
+	}
+	return e
+}
+
+type hexEncoder struct{}
+
+func (hexEncoder) Encode(dst []byte, src []byte) {
+	hex.Encode(dst, src)
+}
+
+func (hexEncoder) EncodedLen(n int) int {
+	return hex.EncodedLen(n)
+}
+
+type encoded struct {
+	part Part
+	enc  encoder
+	name string
+	// std reports whether the default encoding of the constructor is used.
+	std bool
+}
+
+func (p encoded) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p encoded) appendRun(r *run, b []byte) []byte {
+	defer r.restoreLimit(r.suspendLimit())
+	start := len(b)
+	b = appendRun(r, p.part, b)
+	return p.encode(b, start)
+}
+
+// encode encodes b[start:] in place.
+func (p encoded) encode(b []byte, start int) []byte {
+	n := len(b) - start
+	m := p.enc.EncodedLen(n)
+
+	// Move the raw output behind the space of the encoded output, so they do not overlap.
+	b = append(b, make([]byte, m)...)
+	copy(b[start+m:], b[start:start+n])
+	p.enc.Encode(b[start:start+m], b[start+m:])
+	return b[:start+m]
+}
+
+func (p encoded) Children() []Part {
+	return []Part{p.part}
+}
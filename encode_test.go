@@ -0,0 +1,51 @@
+package pattern
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	raw := Literal("\x00\xffhi")
+	tests := []struct {
+		name string
+		p    Part
+		want string
+	}{
+		{"HexEncode", HexEncode(raw), "00ff6869"},
+		{"Base64Encode", Base64Encode(raw, nil), "AP9oaQ"},
+		{"Base64Encode URL", Base64Encode(raw, base64.URLEncoding), "AP9oaQ=="},
+		{"Base32Encode", Base32Encode(raw, nil), "AD7WQ2I"},
+		{"Base32Encode hex", Base32Encode(raw, base32.HexEncoding), "03VMGQ8="},
+		{"empty", HexEncode(Group()), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Prefix the output to check that only the output of the Part is encoded.
+			if got := New(Literal("x-"), tt.p).String(); got != "x-"+tt.want {
+				t.Errorf("got %q, want %q", got, "x-"+tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeEnumerate(t *testing.T) {
+	g := New(HexEncode(OneOfByte([]byte{0, 1, 0xab})))
+
+	var got []string
+	g.enumerate(nil, func(b []byte) bool {
+		got = append(got, string(b))
+		return true
+	})
+	want := []string{"00", "01", "ab"}
+	if len(got) != len(want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] || string(g.unrank(nil, uint64(i))) != want[i] {
+			t.Errorf("output %d is %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,776 @@
+package pattern
+
+import (
+	"math/bits"
+	"unicode/utf8"
+)
+
+// enumerable is implemented by Parts whose outputs can be enumerated.
+type enumerable interface {
+	// enumerate calls yield with b extended by each possible output of the Part.
+	// It stops and returns false as soon as yield returns false.
+	// The slice passed to yield is only valid during the call.
+	enumerate(b []byte, yield func([]byte) bool) bool
+}
+
+// countable is implemented by enumerable Parts that can generate their outputs by index.
+type countable interface {
+	enumerable
+	// count returns the number of outputs of the Part or false if it exceeds the range of uint64.
+	count() (uint64, bool)
+	// unrank appends the i-th output of the Part's enumeration to b.
+	unrank(b []byte, i uint64) []byte
+}
+
+// canEnumerate reports whether all outputs of p can be enumerated.
+func canEnumerate(p Part) bool {
+	ok := true
+	walk(p, func(p Part) {
+		if _, e := p.(enumerable); !e {
+			ok = false
+		}
+	})
+	return ok
+}
+
+// canCount reports whether the outputs of p can be generated by index.
+func canCount(p Part) bool {
+	ok := true
+	walk(p, func(p Part) {
+		if _, c := p.(countable); !c {
+			ok = false
+		}
+	})
+	return ok
+}
+
+// enumerateGroup calls yield with b extended by each possible output of parts in order.
+func enumerateGroup(parts []Part, b []byte, yield func([]byte) bool) bool {
+	if len(parts) == 0 {
+		return yield(b)
+	}
+
+	return parts[0].(enumerable).enumerate(b, func(b []byte) bool {
+		return enumerateGroup(parts[1:], b, yield)
+	})
+}
+
+func (g gen) enumerate(b []byte, yield func([]byte) bool) bool {
+	return enumerateGroup(g.parts, b, yield)
+}
+
+func (p nullpart) enumerate(b []byte, yield func([]byte) bool) bool {
+	return yield(b)
+}
+
+func (p group) enumerate(b []byte, yield func([]byte) bool) bool {
+	return enumerateGroup(p, b, yield)
+}
+
+func (p repeat) enumerate(b []byte, yield func([]byte) bool) bool {
+	max := p.min + p.maxr - 1
+	for n := p.min; n <= max; n++ {
+		parts := make([]Part, 0, len(p.parts)*int(n))
+		for i := uint32(0); i < n; i++ {
+			parts = append(parts, p.parts...)
+		}
+
+		if !enumerateGroup(parts, b, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p potentially50) enumerate(b []byte, yield func([]byte) bool) bool {
+	return yield(b) && p.part.(enumerable).enumerate(b, yield)
+}
+
+func (p potentiallyP) enumerate(b []byte, yield func([]byte) bool) bool {
+	return yield(b) && p.part.(enumerable).enumerate(b, yield)
+}
+
+func (p literal) enumerate(b []byte, yield func([]byte) bool) bool {
+	return yield(append(b, p...))
+}
+
+func (p anyOf) enumerate(b []byte, yield func([]byte) bool) bool {
+	for _, p := range p.parts {
+		if !p.(enumerable).enumerate(b, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p anyOfString) enumerate(b []byte, yield func([]byte) bool) bool {
+	for _, s := range p.alphabet {
+		if !yield(append(b, s...)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p anyOfByte) enumerate(b []byte, yield func([]byte) bool) bool {
+	for _, c := range p.alphabet {
+		if !yield(append(b, c)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p byteRange) enumerate(b []byte, yield func([]byte) bool) bool {
+	for i := uint32(0); i < p.n; i++ {
+		if !yield(append(b, p.lo+byte(i))) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p anyOfRune) enumerate(b []byte, yield func([]byte) bool) bool {
+	for _, r := range p.alphabet {
+		if !yield(utf8.AppendRune(b, r)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p shuffle) enumerate(b []byte, yield func([]byte) bool) bool {
+	order := make([]Part, p.k)
+	used := make([]bool, len(p.parts))
+
+	// Enumerate the permutations in lexicographic order of the indices.
+	var permute func(i int) bool
+	permute = func(i int) bool {
+		if i == len(order) {
+			return enumerateGroup(order, b, yield)
+		}
+
+		for j, p := range p.parts {
+			if used[j] {
+				continue
+			}
+
+			used[j] = true
+			order[i] = p
+			ok := permute(i + 1)
+			used[j] = false
+
+			if !ok {
+				return false
+			}
+		}
+		return true
+	}
+
+	return permute(0)
+}
+
+func (p sequence) enumerate(b []byte, yield func([]byte) bool) bool {
+	// Number of steps in [start, max], 0 if it covers all uint64.
+	n := (p.max-p.start)/p.step + 1
+	for i := uint64(0); i < n || n == 0; i++ {
+		u := p.start + i*p.step
+		if p.desc {
+			u = p.max - i*p.step
+		}
+
+		if p.skip != nil && p.skip(u) {
+			continue
+		}
+
+		if !yield(p.format(b, u)) {
+			return false
+		}
+
+		if n == 0 && i == ^uint64(0) {
+			break
+		}
+	}
+	return true
+}
+
+func (p sequencePer) enumerate(b []byte, yield func([]byte) bool) bool {
+	for u := p.start; ; u++ {
+		if !yield(appendInt(b, u, p.width)) {
+			return false
+		}
+		if u == p.max {
+			return true
+		}
+	}
+}
+
+func (p permutation) enumerate(b []byte, yield func([]byte) bool) bool {
+	for i := uint64(0); ; i++ {
+		if !yield(appendInt(b, p.start+p.permute(i), p.width)) {
+			return false
+		}
+		if i == p.n-1 {
+			return true
+		}
+	}
+}
+
+func (p nanoIDMask) enumerate(b []byte, yield func([]byte) bool) bool {
+	return enumerateProduct(p.alphabet, p.size, b, yield)
+}
+
+func (p nanoID) enumerate(b []byte, yield func([]byte) bool) bool {
+	return enumerateProduct(p.alphabet, p.size, b, yield)
+}
+
+func (p randomHex) enumerate(b []byte, yield func([]byte) bool) bool {
+	return enumerateProduct([]byte(hexDigits), 2*p.n, b, yield)
+}
+
+// enumerateProduct calls yield with b extended by each string of n bytes of alphabet.
+func enumerateProduct(alphabet []byte, n int, b []byte, yield func([]byte) bool) bool {
+	if n == 0 {
+		return yield(b)
+	}
+
+	for _, c := range alphabet {
+		if !enumerateProduct(alphabet, n-1, append(b, c), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p runeRanges) enumerate(b []byte, yield func([]byte) bool) bool {
+	for i := 0; i < len(p.pairs); i += 2 {
+		for r := p.pairs[i]; r <= p.pairs[i+1]; r++ {
+			if !yield(utf8.AppendRune(b, r)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (p fpe) enumerate(b []byte, yield func([]byte) bool) bool {
+	start := len(b)
+	return p.part.(enumerable).enumerate(b, func(b []byte) bool {
+		// Encrypt a copy, since b may be shared with other outputs.
+		out := append([]byte(nil), b...)
+		p.encrypt(out[start:])
+		return yield(out)
+	})
+}
+
+func (p encoded) enumerate(b []byte, yield func([]byte) bool) bool {
+	start := len(b)
+	return p.part.(enumerable).enumerate(b, func(b []byte) bool {
+		// Encode a copy, since b may be shared with other outputs.
+		return yield(p.encode(append([]byte(nil), b...), start))
+	})
+}
+
+func (p pad) enumerate(b []byte, yield func([]byte) bool) bool {
+	start := len(b)
+	return p.part.(enumerable).enumerate(b, func(b []byte) bool {
+		// Pad a copy, since b may be shared with other outputs.
+		return yield(p.pad(append([]byte(nil), b...), start))
+	})
+}
+
+func (p exactLen) enumerate(b []byte, yield func([]byte) bool) bool {
+	start := len(b)
+	return p.part.(enumerable).enumerate(b, func(b []byte) bool {
+		// Fix a copy, since b may be shared with other outputs.
+		if out, ok := p.fix(append([]byte(nil), b...), start); ok {
+			return yield(out)
+		}
+		return true
+	})
+}
+
+func (p maxTotalLen) enumerate(b []byte, yield func([]byte) bool) bool {
+	start := len(b)
+	return enumerateGroup(p.parts, b, func(b []byte) bool {
+		if len(b)-start > p.n {
+			return true
+		}
+		return yield(b)
+	})
+}
+
+func (p cycle) enumerate(b []byte, yield func([]byte) bool) bool {
+	return p.oneOf().enumerate(b, yield)
+}
+
+func (p repeatNormal) enumerate(b []byte, yield func([]byte) bool) bool {
+	return p.uniform.(enumerable).enumerate(b, yield)
+}
+
+func (p balanced) enumerate(b []byte, yield func([]byte) bool) bool {
+	return p.nested.(enumerable).enumerate(b, yield)
+}
+
+func (p ifPart) enumerate(b []byte, yield func([]byte) bool) bool {
+	return p.oneOf().enumerate(b, yield)
+}
+
+func (p choose) enumerate(b []byte, yield func([]byte) bool) bool {
+	return p.oneOf().enumerate(b, yield)
+}
+
+func (p throttle) enumerate(b []byte, yield func([]byte) bool) bool {
+	return p.part.(enumerable).enumerate(b, yield)
+}
+
+// mulCount returns a*b or false if it overflows.
+func mulCount(a, b uint64) (uint64, bool) {
+	hi, lo := bits.Mul64(a, b)
+	return lo, hi == 0
+}
+
+// addCount returns a+b or false if it overflows.
+func addCount(a, b uint64) (uint64, bool) {
+	sum, carry := bits.Add64(a, b, 0)
+	return sum, carry == 0
+}
+
+// countGroup returns the number of outputs of parts in order.
+func countGroup(parts []Part) (uint64, bool) {
+	n := uint64(1)
+	for _, p := range parts {
+		c, ok := p.(countable).count()
+		if !ok {
+			return 0, false
+		}
+		if n, ok = mulCount(n, c); !ok {
+			return 0, false
+		}
+	}
+	return n, true
+}
+
+// unrankGroup appends the i-th output of parts in order to b.
+// The first part is the most significant digit, which matches enumerateGroup.
+func unrankGroup(parts []Part, b []byte, i uint64) []byte {
+	if len(parts) == 0 {
+		return b
+	}
+
+	rest, _ := countGroup(parts[1:])
+	b = parts[0].(countable).unrank(b, i/rest)
+	return unrankGroup(parts[1:], b, i%rest)
+}
+
+func (g gen) count() (uint64, bool) {
+	return countGroup(g.parts)
+}
+
+func (g gen) unrank(b []byte, i uint64) []byte {
+	return unrankGroup(g.parts, b, i)
+}
+
+func (p nullpart) count() (uint64, bool) {
+	return 1, true
+}
+
+func (p nullpart) unrank(b []byte, i uint64) []byte {
+	return b
+}
+
+func (p group) count() (uint64, bool) {
+	return countGroup(p)
+}
+
+func (p group) unrank(b []byte, i uint64) []byte {
+	return unrankGroup(p, b, i)
+}
+
+func (p repeat) count() (uint64, bool) {
+	c, ok := countGroup(p.parts)
+	if !ok {
+		return 0, false
+	}
+
+	// Sum of c^n for n in [min, max].
+	var sum uint64
+	pow := uint64(1)
+	max := p.min + p.maxr - 1
+	for n := uint32(0); n <= max; n++ {
+		if n >= p.min {
+			if sum, ok = addCount(sum, pow); !ok {
+				return 0, false
+			}
+		}
+		if n < max {
+			if pow, ok = mulCount(pow, c); !ok {
+				return 0, false
+			}
+		}
+	}
+	return sum, true
+}
+
+func (p repeat) unrank(b []byte, i uint64) []byte {
+	c, _ := countGroup(p.parts)
+
+	pow := uint64(1)
+	for n := uint32(0); n < p.min; n++ {
+		pow *= c
+	}
+
+	// Find the number of repetitions, then unrank each repetition as a digit in base c.
+	n := p.min
+	for i >= pow {
+		i -= pow
+		pow *= c
+		n++
+	}
+
+	for ; n > 0; n-- {
+		pow /= c
+		b = unrankGroup(p.parts, b, i/pow)
+		i %= pow
+	}
+	return b
+}
+
+func (p potentially50) count() (uint64, bool) {
+	c, ok := p.part.(countable).count()
+	if !ok {
+		return 0, false
+	}
+	return addCount(c, 1)
+}
+
+func (p potentially50) unrank(b []byte, i uint64) []byte {
+	if i == 0 {
+		return b
+	}
+	return p.part.(countable).unrank(b, i-1)
+}
+
+func (p potentiallyP) count() (uint64, bool) {
+	return potentially50{part: p.part}.count()
+}
+
+func (p potentiallyP) unrank(b []byte, i uint64) []byte {
+	return potentially50{part: p.part}.unrank(b, i)
+}
+
+func (p literal) count() (uint64, bool) {
+	return 1, true
+}
+
+func (p literal) unrank(b []byte, i uint64) []byte {
+	return append(b, p...)
+}
+
+func (p anyOf) count() (uint64, bool) {
+	var sum uint64
+	for _, p := range p.parts {
+		c, ok := p.(countable).count()
+		if !ok {
+			return 0, false
+		}
+		if sum, ok = addCount(sum, c); !ok {
+			return 0, false
+		}
+	}
+	return sum, true
+}
+
+func (p anyOf) unrank(b []byte, i uint64) []byte {
+	for _, p := range p.parts {
+		c, _ := p.(countable).count()
+		if i < c {
+			return p.(countable).unrank(b, i)
+		}
+		i -= c
+	}
+	panic("index out of range")
+}
+
+func (p anyOfString) count() (uint64, bool) {
+	return uint64(len(p.alphabet)), true
+}
+
+func (p anyOfString) unrank(b []byte, i uint64) []byte {
+	return append(b, p.alphabet[i]...)
+}
+
+func (p anyOfByte) count() (uint64, bool) {
+	return uint64(len(p.alphabet)), true
+}
+
+func (p anyOfByte) unrank(b []byte, i uint64) []byte {
+	return append(b, p.alphabet[i])
+}
+
+func (p byteRange) count() (uint64, bool) {
+	return uint64(p.n), true
+}
+
+func (p byteRange) unrank(b []byte, i uint64) []byte {
+	return append(b, p.lo+byte(i))
+}
+
+func (p anyOfRune) count() (uint64, bool) {
+	return uint64(len(p.alphabet)), true
+}
+
+func (p anyOfRune) unrank(b []byte, i uint64) []byte {
+	return utf8.AppendRune(b, p.alphabet[i])
+}
+
+func (p shuffle) count() (uint64, bool) {
+	counts := make([]uint64, len(p.parts))
+	for i, part := range p.parts {
+		c, ok := part.(countable).count()
+		if !ok {
+			return 0, false
+		}
+		counts[i] = c
+	}
+	return countSample(counts, int(p.k))
+}
+
+func (p shuffle) unrank(b []byte, i uint64) []byte {
+	counts := make([]uint64, len(p.parts))
+	for j, part := range p.parts {
+		counts[j], _ = part.(countable).count()
+	}
+
+	// Select the Parts in the lexicographic order of their indices like enumerate.
+	// prefix is the number of outputs of the Parts selected so far.
+	used := make([]bool, len(p.parts))
+	order := make([]Part, 0, p.k)
+	prefix := uint64(1)
+	rest := make([]uint64, 0, len(p.parts))
+	for len(order) < int(p.k) {
+		for j, part := range p.parts {
+			if used[j] {
+				continue
+			}
+
+			rest = rest[:0]
+			for l, c := range counts {
+				if !used[l] && l != j {
+					rest = append(rest, c)
+				}
+			}
+			w, _ := countSample(rest, int(p.k)-len(order)-1)
+
+			if block := prefix * counts[j] * w; i >= block {
+				i -= block
+				continue
+			}
+
+			used[j] = true
+			order = append(order, part)
+			prefix *= counts[j]
+			break
+		}
+	}
+
+	return unrankGroup(order, b, i)
+}
+
+// countSample returns the number of outputs of all ordered samples of k Parts with the given numbers of outputs,
+// which is k! times the elementary symmetric polynomial of degree k of counts, or false if it exceeds the range of uint64.
+func countSample(counts []uint64, k int) (uint64, bool) {
+	// e[j] is the elementary symmetric polynomial of degree j of the counts processed so far.
+	e := make([]uint64, k+1)
+	e[0] = 1
+	for _, c := range counts {
+		for j := k; j > 0; j-- {
+			t, ok := mulCount(e[j-1], c)
+			if !ok {
+				return 0, false
+			}
+			if e[j], ok = addCount(e[j], t); !ok {
+				return 0, false
+			}
+		}
+	}
+
+	n := e[k]
+	for j := uint64(2); j <= uint64(k); j++ {
+		var ok bool
+		if n, ok = mulCount(n, j); !ok {
+			return 0, false
+		}
+	}
+	return n, true
+}
+
+// sequences with skipped values are not countable, since the number of skipped values is unknown.
+func (p sequence) count() (uint64, bool) {
+	if p.skip != nil {
+		return 0, false
+	}
+
+	n := (p.max-p.start)/p.step + 1
+	return n, n != 0
+}
+
+func (p sequence) unrank(b []byte, i uint64) []byte {
+	u := p.start + i*p.step
+	if p.desc {
+		u = p.max - i*p.step
+	}
+	return p.format(b, u)
+}
+
+func (p sequencePer) count() (uint64, bool) {
+	n := p.max - p.start + 1
+	return n, n != 0
+}
+
+func (p sequencePer) unrank(b []byte, i uint64) []byte {
+	return appendInt(b, p.start+i, p.width)
+}
+
+func (p permutation) count() (uint64, bool) {
+	return p.n, p.n != 0
+}
+
+func (p permutation) unrank(b []byte, i uint64) []byte {
+	return appendInt(b, p.start+p.permute(i), p.width)
+}
+
+func (p nanoIDMask) count() (uint64, bool) {
+	return countProduct(len(p.alphabet), p.size)
+}
+
+func (p nanoIDMask) unrank(b []byte, i uint64) []byte {
+	return unrankProduct(p.alphabet, p.size, b, i)
+}
+
+func (p nanoID) count() (uint64, bool) {
+	return countProduct(len(p.alphabet), p.size)
+}
+
+func (p nanoID) unrank(b []byte, i uint64) []byte {
+	return unrankProduct(p.alphabet, p.size, b, i)
+}
+
+func (p randomHex) count() (uint64, bool) {
+	return countProduct(len(hexDigits), 2*p.n)
+}
+
+func (p randomHex) unrank(b []byte, i uint64) []byte {
+	return unrankProduct([]byte(hexDigits), 2*p.n, b, i)
+}
+
+// countProduct returns the number of strings of n bytes of an alphabet of size k.
+func countProduct(k int, n int) (uint64, bool) {
+	c, ok := uint64(1), true
+	for ; ok && n > 0; n-- {
+		c, ok = mulCount(c, uint64(k))
+	}
+	return c, ok
+}
+
+// unrankProduct appends the i-th string of n bytes of alphabet to b.
+func unrankProduct(alphabet []byte, n int, b []byte, i uint64) []byte {
+	start := len(b)
+	for j := 0; j < n; j++ {
+		b = append(b, 0)
+	}
+
+	k := uint64(len(alphabet))
+	for j := len(b) - 1; j >= start; j-- {
+		b[j] = alphabet[i%k]
+		i /= k
+	}
+	return b
+}
+
+func (p runeRanges) count() (uint64, bool) {
+	var c uint64
+	for i := 0; i < len(p.pairs); i += 2 {
+		c += uint64(p.pairs[i+1]-p.pairs[i]) + 1
+	}
+	return c, true
+}
+
+func (p runeRanges) unrank(b []byte, i uint64) []byte {
+	for j := 0; j < len(p.pairs); j += 2 {
+		c := uint64(p.pairs[j+1]-p.pairs[j]) + 1
+		if i < c {
+			return utf8.AppendRune(b, p.pairs[j]+rune(i))
+		}
+		i -= c
+	}
+	panic("index out of range")
+}
+
+func (p fpe) count() (uint64, bool) {
+	return p.part.(countable).count()
+}
+
+func (p fpe) unrank(b []byte, i uint64) []byte {
+	start := len(b)
+	b = p.part.(countable).unrank(b, i)
+	p.encrypt(b[start:])
+	return b
+}
+
+func (p encoded) count() (uint64, bool) {
+	return p.part.(countable).count()
+}
+
+func (p encoded) unrank(b []byte, i uint64) []byte {
+	start := len(b)
+	return p.encode(p.part.(countable).unrank(b, i), start)
+}
+
+func (p cycle) count() (uint64, bool) {
+	return p.oneOf().count()
+}
+
+func (p cycle) unrank(b []byte, i uint64) []byte {
+	return p.oneOf().unrank(b, i)
+}
+
+func (p repeatNormal) count() (uint64, bool) {
+	return p.uniform.(countable).count()
+}
+
+func (p repeatNormal) unrank(b []byte, i uint64) []byte {
+	return p.uniform.(countable).unrank(b, i)
+}
+
+func (p balanced) count() (uint64, bool) {
+	return p.nested.(countable).count()
+}
+
+func (p balanced) unrank(b []byte, i uint64) []byte {
+	return p.nested.(countable).unrank(b, i)
+}
+
+func (p ifPart) count() (uint64, bool) {
+	return p.oneOf().count()
+}
+
+func (p ifPart) unrank(b []byte, i uint64) []byte {
+	return p.oneOf().unrank(b, i)
+}
+
+func (p choose) count() (uint64, bool) {
+	return p.oneOf().count()
+}
+
+func (p choose) unrank(b []byte, i uint64) []byte {
+	return p.oneOf().unrank(b, i)
+}
+
+func (p throttle) count() (uint64, bool) {
+	return p.part.(countable).count()
+}
+
+func (p throttle) unrank(b []byte, i uint64) []byte {
+	return p.part.(countable).unrank(b, i)
+}
@@ -0,0 +1,109 @@
+package pattern
+
+import (
+	"fmt"
+)
+
+// exactLenRetries is the default number of times ExactLen regenerates outputs of the wrong length.
+const exactLenRetries = 100
+
+// ExactLen returns a Part that guarantees that the output of p is exactly n bytes long, e.g. for fixed-width ID columns.
+// Outputs of the wrong length are regenerated up to 100 times, unless they can be fixed with ExactLenTruncate or ExactLenPad.
+//
+// The Part panics with an error wrapping ErrRetriesExhausted if no output of length n is found within the retries.
+// Panics if n is < 0.
+func ExactLen(n int, p Part, opts ...ExactLenOption) Part {
+	if n < 0 {
+		panic("n must be >= 0")
+	}
+
+	e := exactLen{
+		part:    p,
+		n:       n,
+		retries: exactLenRetries,
+	}
+	for _, opt := range opts {
+		opt(&e)
+	}
+	return e
+}
+
+// ExactLenOption changes how ExactLen handles outputs of the wrong length.
+type ExactLenOption func(*exactLen)
+
+// ExactLenTruncate makes ExactLen cut longer outputs to n bytes instead of regenerating them.
+// Multi-byte characters may be split.
+func ExactLenTruncate() ExactLenOption {
+	return func(p *exactLen) {
+		p.truncate = true
+	}
+}
+
+// ExactLenPad makes ExactLen pad shorter outputs with fill on the right instead of regenerating them.
+func ExactLenPad(fill byte) ExactLenOption {
+	return func(p *exactLen) {
+		p.pad = true
+		p.fill = fill
+	}
+}
+
+// ExactLenRetries makes ExactLen regenerate outputs of the wrong length up to retries times.
+//
+// Panics if retries is < 0.
+func ExactLenRetries(retries int) ExactLenOption {
+	if retries < 0 {
+		panic("retries must be >= 0")
+	}
+
+	return func(p *exactLen) {
+		p.retries = retries
+	}
+}
+
+type exactLen struct {
+	part     Part
+	n        int
+	retries  int
+	truncate bool
+	pad      bool
+	fill     byte
+}
+
+func (p exactLen) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p exactLen) appendRun(r *run, b []byte) []byte {
+	defer r.restoreLimit(r.suspendLimit())
+	start := len(b)
+	for i := 0; ; i++ {
+		b = appendRun(r, p.part, b[:start])
+		if out, ok := p.fix(b, start); ok {
+			return out
+		}
+
+		if i == p.retries {
+			panic(fmt.Errorf("%w: output is %d bytes long after %d retries, want %d", ErrRetriesExhausted, len(b)-start, p.retries, p.n))
+		}
+	}
+}
+
+// fix returns b with b[start:] truncated or padded to n bytes or false if its length can not be fixed.
+func (p exactLen) fix(b []byte, start int) ([]byte, bool) {
+	switch n := len(b) - start; {
+	case n == p.n:
+		return b, true
+	case n > p.n && p.truncate:
+		return b[:start+p.n], true
+	case n < p.n && p.pad:
+		for ; n < p.n; n++ {
+			b = append(b, p.fill)
+		}
+		return b, true
+	}
+	return b, false
+}
+
+func (p exactLen) Children() []Part {
+	return []Part{p.part}
+}
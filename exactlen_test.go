@@ -0,0 +1,59 @@
+package pattern
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExactLen(t *testing.T) {
+	words := OneOfString([]string{"a", "bb", "ccc"})
+	tests := []struct {
+		name string
+		p    Part
+		want map[string]bool
+	}{
+		{"retry", ExactLen(2, words), map[string]bool{"bb": true}},
+		{"truncate", ExactLen(2, words, ExactLenTruncate()), map[string]bool{"bb": true, "cc": true}},
+		{"pad", ExactLen(2, words, ExactLenPad('.')), map[string]bool{"a.": true, "bb": true}},
+		{"both", ExactLen(2, words, ExactLenTruncate(), ExactLenPad('.')), map[string]bool{"a.": true, "bb": true, "cc": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(Literal("x"), tt.p)
+			seen := map[string]bool{}
+			for i := 0; i < 1000; i++ {
+				s := g.String()
+				if s[0] != 'x' || !tt.want[s[1:]] {
+					t.Fatalf("got %q", s)
+				}
+				seen[s[1:]] = true
+			}
+			if len(seen) != len(tt.want) {
+				t.Errorf("generated %d distinct values, want %d", len(seen), len(tt.want))
+			}
+
+			var all []string
+			g.enumerate(nil, func(b []byte) bool {
+				all = append(all, string(b[1:]))
+				return true
+			})
+			for _, s := range all {
+				if !tt.want[s] {
+					t.Errorf("enumerated %q", s)
+				}
+			}
+		})
+	}
+}
+
+func TestExactLenRetriesExhausted(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrRetriesExhausted) {
+			t.Errorf("got %v, want ErrRetriesExhausted", err)
+		}
+	}()
+
+	_ = New(ExactLen(3, Literal("ab"), ExactLenRetries(5))).String()
+}
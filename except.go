@@ -0,0 +1,109 @@
+package pattern
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Except returns a copy of the alphabet Part p without the characters of excluded,
+// e.g. Except(OneOfByte(URLSafe64), "aeiouAEIOU") to avoid accidental words.
+// p must be created by OneOfByte, OneOfRune, OneOfByteRange, OneOfRuneRange, OneOfUnicode, Class or NanoID;
+// for OneOfString, the values containing any of the characters are removed.
+//
+// Panics if p is not an alphabet Part or no character remains.
+func Except(p Part, excluded string) Part {
+	keep := func(r rune) bool {
+		return !strings.ContainsRune(excluded, r)
+	}
+
+	switch p := p.(type) {
+	case anyOfByte:
+		return OneOfByte(exceptBytes(p.alphabet, keep))
+
+	case byteRange:
+		alphabet := make([]byte, 0, p.n)
+		for i := uint32(0); i < p.n; i++ {
+			alphabet = append(alphabet, p.lo+byte(i))
+		}
+		return OneOfByte(exceptBytes(alphabet, keep))
+
+	case nanoID:
+		return NanoID(p.size, exceptBytes(p.alphabet, keep))
+
+	case nanoIDMask:
+		return NanoID(p.size, exceptBytes(p.alphabet, keep))
+
+	case anyOfRune:
+		alphabet := make([]rune, 0, len(p.alphabet))
+		for _, r := range p.alphabet {
+			if keep(r) {
+				alphabet = append(alphabet, r)
+			}
+		}
+		if len(alphabet) == 0 {
+			panic("all characters are excluded")
+		}
+		return OneOfRune(alphabet)
+
+	case runeRanges:
+		q, err := charClass(exceptRanges(p.pairs, excluded))
+		if err != nil {
+			panic("all characters are excluded")
+		}
+		return q
+
+	case anyOfString:
+		values := make([]string, 0, len(p.alphabet))
+		for _, s := range p.alphabet {
+			if !strings.ContainsAny(s, excluded) {
+				values = append(values, s)
+			}
+		}
+		if len(values) == 0 {
+			panic("all values are excluded")
+		}
+		return OneOfString(values)
+	}
+
+	panic(fmt.Sprintf("can not exclude characters from %T", p))
+}
+
+func exceptBytes(alphabet []byte, keep func(rune) bool) []byte {
+	kept := make([]byte, 0, len(alphabet))
+	for _, c := range alphabet {
+		if keep(rune(c)) {
+			kept = append(kept, c)
+		}
+	}
+	if len(kept) == 0 {
+		panic("all characters are excluded")
+	}
+	return kept
+}
+
+// exceptRanges returns the pairs of inclusive rune ranges without the runes of excluded.
+func exceptRanges(pairs []rune, excluded string) []rune {
+	runes := []rune(excluded)
+	sort.Slice(runes, func(i, j int) bool {
+		return runes[i] < runes[j]
+	})
+
+	var kept []rune
+	for i := 0; i < len(pairs); i += 2 {
+		lo, hi := pairs[i], pairs[i+1]
+		for _, r := range runes {
+			if r < lo || r > hi {
+				continue
+			}
+			if r > lo {
+				kept = append(kept, lo, r-1)
+			}
+			lo = r + 1
+		}
+		if lo <= hi {
+			kept = append(kept, lo, hi)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,67 @@
+package pattern
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestExcept(t *testing.T) {
+	tests := []struct {
+		name  string
+		p     Part
+		count uint64
+	}{
+		{"OneOfByte", OneOfByte(URLSafe64), 54},
+		{"OneOfByteRange", OneOfByteRange('a', 'z'), 21},
+		{"OneOfRune", OneOfRune([]rune("aäeëxy")), 2},
+		{"OneOfRuneRange", OneOfRuneRange('a', 'z'+1000), 1019},
+		{"Class", Class("[a-z]"), 21},
+		{"OneOfString", OneOfString([]string{"cat", "dog", "fly", "sky"}), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(Except(tt.p, "aeiouAEIOUäë"))
+			if n, ok := g.count(); !ok || n != tt.count {
+				t.Errorf("Except generates %d values, want %d", n, tt.count)
+			}
+			for i := 0; i < 1000; i++ {
+				if s := g.String(); strings.ContainsAny(s, "aeiouAEIOUäë") {
+					t.Fatalf("Except generated %q", s)
+				}
+			}
+		})
+	}
+}
+
+func TestExceptNanoID(t *testing.T) {
+	g := New(Except(NanoID(32, Hex), "abcdef"))
+	for i := 0; i < 100; i++ {
+		s := g.String()
+		if len(s) != 32 || strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) }) >= 0 {
+			t.Fatalf("Except generated %q", s)
+		}
+	}
+}
+
+func TestExceptPanic(t *testing.T) {
+	parts := []Part{
+		OneOfByte([]byte("ab")),
+		OneOfString([]string{"a", "ab"}),
+		Literal("x"),
+		Repeat(1, 2, OneOfByte([]byte("xy"))),
+	}
+
+	for _, p := range parts {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Except(%T) did not panic", p)
+				}
+			}()
+
+			Except(p, "abx")
+		}()
+	}
+}
@@ -0,0 +1,267 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxExplained is the number of alphabet members shown by Explain.
+const maxExplained = 16
+
+// Explain returns a description of the tree of Parts of the generator, one Part per line and indented by depth.
+// It shows the Parts as they are generated, after the constructors folded them,
+// e.g. a small Repeat with min == max is shown as a Group and a Repeat(0, 1) as Potentially(0.5).
+// Constructors that return their argument unchanged, like Potentially(1, p) or Group(p), leave no trace in the tree.
+func (g gen) Explain() string {
+	var sb strings.Builder
+	sb.WriteString("New")
+	if len(g.folded) > 0 {
+		sb.WriteString(" (" + strings.Join(g.folded, ", ") + ")")
+	}
+	sb.WriteByte('\n')
+
+	for _, p := range g.parts {
+		explainTree(&sb, p, 1)
+	}
+	return sb.String()
+}
+
+func explainTree(sb *strings.Builder, p Part, depth int) {
+	sb.WriteString(strings.Repeat("  ", depth))
+	sb.WriteString(explainPart(p))
+	sb.WriteByte('\n')
+
+	var children []Part
+	switch p := p.(type) {
+	case constRepeat:
+		// Only show a single copy of the repeated Parts.
+		children = p.group[:len(p.group)/int(p.n)]
+	case Parent:
+		children = p.Children()
+	}
+
+	for _, c := range children {
+		explainTree(sb, c, depth+1)
+	}
+}
+
+// explainPart returns a description of p without its children.
+func explainPart(p Part) string {
+	switch p := p.(type) {
+	case *gen:
+		return "New"
+	case gen:
+		return "New"
+	case nullpart:
+		if p.from != "" {
+			return "Empty (folded from " + p.from + ")"
+		}
+		return "Empty"
+	case group:
+		return "Group"
+	case constRepeat:
+		return fmt.Sprintf("Group ×%d (folded from %s)", p.n, explainRepeat(p.n, p.n))
+	case repeat:
+		return explainRepeat(p.min, p.min+p.maxr-1)
+	case potentially50:
+		if p.from != "" {
+			return "Potentially(0.5) (folded from " + p.from + ")"
+		}
+		return "Potentially(0.5)"
+	case potentiallyP:
+		return "Potentially(" + strconv.FormatFloat(p.percent, 'g', -1, 64) + ")"
+	case literal:
+		return "Literal(" + strconv.Quote(string(p)) + ")"
+	case anyOf:
+		return "OneOf"
+	case anyOfString:
+		return "OneOfString(" + explainStrings(p.alphabet) + ")"
+	case anyOfByte:
+		return "OneOfByte(" + explainAlphabet(string(p.alphabet)) + ")"
+	case anyOfRune:
+		return "OneOfRune(" + explainAlphabet(string(p.alphabet)) + ")"
+	case shuffle:
+		if p.k != p.len {
+			return fmt.Sprintf("SampleK(%d)", p.k)
+		}
+		return "Shuffle"
+	case sequence:
+		if p.alphabet != "" {
+			return fmt.Sprintf("SequenceBase(%d, %d, %d, %s)", p.start, p.max, p.width, explainAlphabet(p.alphabet))
+		}
+		return fmt.Sprintf("Sequence(%d, %d, %d)", p.start, p.max, p.width)
+	case sequencePer:
+		return fmt.Sprintf("SequencePer(%d, %d, %d)", p.start, p.max, p.width)
+	case sequenceBackend:
+		return fmt.Sprintf("SequenceBackend(%d)", p.width)
+	case permutation:
+		return fmt.Sprintf("PermutedSequence(%d, %d, %d)", p.start, p.start+p.n-1, p.width)
+	case nanoID:
+		return fmt.Sprintf("NanoID(%d, %s)", p.size, explainAlphabet(string(p.alphabet)))
+	case nanoIDMask:
+		return fmt.Sprintf("NanoID(%d, %s)", p.size, explainAlphabet(string(p.alphabet)))
+	case byteRange:
+		return fmt.Sprintf("OneOfByteRange(%q, %q)", p.lo, p.lo+byte(p.n-1))
+	case randomHex:
+		return fmt.Sprintf("RandomHex(%d)", p.n)
+	case markov:
+		return fmt.Sprintf("Markov(order %d, %d-%d characters, %d words)", p.order, p.minLen, p.maxLen, len(p.corpus))
+	case pronounceable:
+		if p.digits > 0 {
+			return fmt.Sprintf("Pronounceable(%d, %d digits)", p.syllables, p.digits)
+		}
+		return fmt.Sprintf("Pronounceable(%d)", p.syllables)
+	case crockford:
+		return fmt.Sprintf("RandomCrockford(%d, true)", p.n)
+	case randomEncoded:
+		return fmt.Sprintf("%s(%d)", p.name, p.n)
+	case runeRanges:
+		var sb strings.Builder
+		for i := 0; i < len(p.pairs); i += 2 {
+			sb.WriteRune(p.pairs[i])
+			if p.pairs[i+1] != p.pairs[i] {
+				sb.WriteByte('-')
+				sb.WriteRune(p.pairs[i+1])
+			}
+		}
+		return "Class(" + strconv.Quote("["+sb.String()+"]") + ")"
+	case fpe:
+		return "FPE(" + explainAlphabet(p.alphabet) + ")"
+	case throttle:
+		return "Throttle(" + strconv.FormatFloat(float64(time.Second)/float64(p.interval), 'g', 4, 64) + "/s)"
+	case uniqueBy:
+		return fmt.Sprintf("UniqueBy(%d)", p.maxRetries)
+	case avoid:
+		return fmt.Sprintf("Avoid(%d, %s)", p.maxRetries, explainStrings(p.blocklist))
+	case constrain:
+		return fmt.Sprintf("Constrain(%s, %s, %d)", explainRegexp(p.must), explainRegexp(p.mustNot), p.maxRetries)
+	case caseMap:
+		return p.mode.String()
+	case shuffleChars:
+		if p.runes {
+			return "ShuffleRunes"
+		}
+		return "ShuffleBytes"
+	case encoded:
+		return p.name
+	case pad:
+		if p.left {
+			return fmt.Sprintf("PadLeft(%d, %q)", p.width, p.fill)
+		}
+		return fmt.Sprintf("PadRight(%d, %q)", p.width, p.fill)
+	case cycle:
+		return "Cycle"
+	case repeatDist:
+		return "RepeatDist"
+	case ifPart:
+		return "If"
+	case repeatNormal:
+		return fmt.Sprintf("RepeatNormal(%g, %g, %d, %d)", p.mean, p.stddev, p.min, p.max)
+	case benfordDigit:
+		return "BenfordDigit"
+	case benfordAmount:
+		return fmt.Sprintf("BenfordAmount(%g, %g, %d)", p.min, p.max, p.decimals)
+	case zipf:
+		return "OneOfZipf(" + strconv.FormatFloat(p.s, 'g', -1, 64) + ", " + strconv.FormatFloat(p.v, 'g', -1, 64) + ")"
+	case choose:
+		weights := make([]string, len(p.weights))
+		for i, w := range p.weights {
+			weights[i] = strconv.FormatFloat(w, 'g', -1, 64)
+		}
+		return "Choose(" + strings.Join(weights, ", ") + ")"
+	case noConsecutive:
+		return "NoConsecutive"
+	case repeatDistinct:
+		return fmt.Sprintf("RepeatDistinct(%d, %d)", p.min, p.min+p.maxr-1)
+	case atLeastOneOf:
+		return fmt.Sprintf("AtLeastOneOf(%d, %d)", p.min, p.min+p.maxr-1)
+	case maxOutputLen:
+		if p.truncate {
+			return fmt.Sprintf("MaxOutputLen(%d, truncate)", p.n)
+		}
+		return fmt.Sprintf("MaxOutputLen(%d)", p.n)
+	case maxTotalLen:
+		return fmt.Sprintf("MaxTotalLen(%d)", p.n)
+	case exactLen:
+		return fmt.Sprintf("ExactLen(%d)", p.n)
+	case hashed:
+		return fmt.Sprintf("Hash(%d)", p.truncate)
+	case timestamp:
+		if p.utc {
+			return "TimestampUTC(" + strconv.Quote(p.layout) + ")"
+		}
+		return "Timestamp(" + strconv.Quote(p.layout) + ")"
+	case epoch:
+		return fmt.Sprintf("EpochBase(%s, %d)", time.Duration(p.unit), len(p.digits))
+	case ref:
+		return "Ref(" + strconv.Quote(string(p)) + ")"
+	case PartFunc:
+		return "PartFunc"
+	case placeholder:
+		return "Placeholder(" + strconv.Quote(string(p)) + ")"
+	case mirror:
+		if p.reversed {
+			return "MirrorReversed"
+		}
+		return "Mirror"
+	case balanced:
+		return fmt.Sprintf("Balanced(%s, %s, %d)", strconv.Quote(p.open), strconv.Quote(p.close), p.maxDepth)
+	case define:
+		return fmt.Sprintf("Define(%s, %d)", strconv.Quote(p.name), p.maxDepth)
+	case ruleRef:
+		return "RuleRef(" + strconv.Quote(string(p)) + ")"
+	}
+	return fmt.Sprintf("%T", p)
+}
+
+func explainRepeat(min uint32, max uint32) string {
+	return fmt.Sprintf("Repeat(%d, %d)", min, max)
+}
+
+// explainAlphabet returns the quoted alphabet, shortened to maxExplained runes.
+func explainAlphabet(s string) string {
+	n := utf8.RuneCountInString(s)
+	if n > maxExplained {
+		i := 0
+		for j := range s {
+			if i == maxExplained {
+				s = s[:j]
+				break
+			}
+			i++
+		}
+	}
+	return strconv.Quote(s) + explainMore(n)
+}
+
+// explainRegexp returns the quoted expression of re or nil.
+func explainRegexp(re *regexp.Regexp) string {
+	if re == nil {
+		return "nil"
+	}
+	return strconv.Quote(re.String())
+}
+
+// explainStrings returns the quoted strings, shortened to maxExplained strings.
+func explainStrings(s []string) string {
+	members := make([]string, 0, maxExplained)
+	for i, v := range s {
+		if i == maxExplained {
+			break
+		}
+		members = append(members, strconv.Quote(v))
+	}
+	return strings.Join(members, ", ") + explainMore(len(s))
+}
+
+// explainMore returns a note on the number of members not shown.
+func explainMore(n int) string {
+	if n > maxExplained {
+		return fmt.Sprintf(" … %d total", n)
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package pattern
+
+import (
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	gen := New(
+		Literal("id-"),
+		Repeat(2, 2, OneOfByte([]byte("ab"))),
+		Potentially(0, Literal("never")),
+		OneOf(
+			Repeat(0, 1, Literal("x")),
+			Potentially(1, Literal("y")),
+			Potentially(0.25, Sequence(1, 99, 2)),
+		),
+		Shuffle(Literal("a"), OneOfString([]string{"b", "c"})),
+		Repeat(1, 3, Repeat(3, 3, OneOfRune([]rune("äöü")))),
+		MustCompile(`[a-c0-9]|[\x{100}-\x{3ff}]`),
+	)
+
+	want := `New (unwrapped Repeat(2, 2), dropped Potentially(0))
+  Literal("id-")
+  OneOfByte("ab")
+  OneOfByte("ab")
+  OneOf
+    Potentially(0.5) (folded from Repeat(0, 1))
+      Literal("x")
+    Literal("y")
+    Potentially(0.25)
+      Sequence(1, 99, 2)
+  Shuffle
+    Literal("a")
+    OneOfString("b", "c")
+  Repeat(1, 3)
+    Group ×3 (folded from Repeat(3, 3))
+      OneOfRune("äöü")
+  New
+    Class("[0-9a-cĀ-Ͽ]")
+`
+
+	if got := gen.Explain(); got != want {
+		t.Errorf("Explain returned invalid value:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestExplainAlphabet(t *testing.T) {
+	gen := New(NanoID(21, nil))
+
+	want := "New\n  NanoID(21, \"useandom-26T1983\" … 64 total)\n"
+	if got := gen.Explain(); got != want {
+		t.Errorf("Explain returned invalid value:\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -0,0 +1,149 @@
+package pattern
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fillCache caches the generators compiled from struct tags.
+var fillCache sync.Map
+
+// Fill populates the fields of the struct pointed to by v based on their struct tags.
+//
+// Fields of kind string tagged with `pattern:"expr"` are set to a value generated by Compile(expr).
+// Tagged pointers to strings are set to a new pointer to a generated value.
+// Slices of strings tagged with `pattern:"expr"` are set to a new slice of generated values.
+// Nested structs and non-nil pointers to structs are filled recursively.
+// Slices of structs are set to a new slice of filled structs.
+//
+// The length of slices is set with a `patterncount:"n"` or `patterncount:"min,max"` tag and defaults to 1.
+func Fill(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("pattern: Fill requires a non-nil pointer to a struct")
+	}
+
+	return fillStruct(rv.Elem(), rv.Elem().Type().Name())
+}
+
+func fillStruct(v reflect.Value, path string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		if err := fillField(v.Field(i), f.Tag, path+"."+f.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fillField(v reflect.Value, tag reflect.StructTag, path string) error {
+	expr, tagged := tag.Lookup("pattern")
+
+	switch v.Kind() {
+	case reflect.String:
+		if !tagged {
+			return nil
+		}
+
+		g, err := fillGen(expr, path)
+		if err != nil {
+			return err
+		}
+		v.SetString(g.String())
+
+	case reflect.Struct:
+		return fillStruct(v, path)
+
+	case reflect.Pointer:
+		if tagged && v.Type().Elem().Kind() == reflect.String {
+			p := reflect.New(v.Type().Elem())
+			if err := fillField(p.Elem(), tag, path); err != nil {
+				return err
+			}
+			v.Set(p)
+			return nil
+		}
+
+		if tagged && v.Type().Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("pattern: %s: unsupported kind %v", path, v.Kind())
+		}
+		if v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			return nil
+		}
+		return fillStruct(v.Elem(), path)
+
+	case reflect.Slice:
+		elem := v.Type().Elem()
+		if elem.Kind() == reflect.String && !tagged || elem.Kind() != reflect.String && elem.Kind() != reflect.Struct {
+			return nil
+		}
+
+		n, err := fillCount(tag.Get("patterncount"), path)
+		if err != nil {
+			return err
+		}
+
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			if err := fillField(s.Index(i), tag, path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+
+	default:
+		if tagged {
+			return fmt.Errorf("pattern: %s: unsupported kind %v", path, v.Kind())
+		}
+	}
+
+	return nil
+}
+
+func fillGen(expr string, path string) (*gen, error) {
+	if g, ok := fillCache.Load(expr); ok {
+		return g.(*gen), nil
+	}
+
+	g, err := Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("pattern: %s: %w", path, err)
+	}
+
+	fillCache.Store(expr, g)
+	return g, nil
+}
+
+// fillCount returns the number of elements for a count tag of the form "n" or "min,max".
+func fillCount(tag string, path string) (int, error) {
+	if tag == "" {
+		return 1, nil
+	}
+
+	minStr, maxStr, found := strings.Cut(tag, ",")
+	if !found {
+		maxStr = minStr
+	}
+
+	min, err1 := strconv.ParseUint(strings.TrimSpace(minStr), 10, 31)
+	max, err2 := strconv.ParseUint(strings.TrimSpace(maxStr), 10, 31)
+	if err1 != nil || err2 != nil || max < min {
+		return 0, fmt.Errorf("pattern: %s: invalid count %q", path, tag)
+	}
+
+	if min == max {
+		return int(min), nil
+	}
+
+	var r *run
+	return int(min) + int(r.randN(uint32(max-min)+1)), nil
+}
@@ -0,0 +1,109 @@
+package pattern
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+type fillAddress struct {
+	Zip  string `pattern:"\\d{3}-\\d{4}"`
+	City string
+}
+
+type fillCode string
+
+type fillUser struct {
+	ID       string   `pattern:"usr_[a-z0-9]{8}"`
+	Code     fillCode `pattern:"[A-Z]{3}"`
+	Tags     []string `pattern:"#[a-z]{3,5}" patterncount:"2,4"`
+	Address  fillAddress
+	Previous []fillAddress `patterncount:"3"`
+	Manager  *fillAddress
+	Missing  *fillAddress
+	Nickname *string `pattern:"[a-z]{4}"`
+	Ignored  string
+	private  string `pattern:"x"`
+}
+
+func TestFill(t *testing.T) {
+	u := fillUser{
+		Manager: &fillAddress{},
+		Ignored: "keep",
+	}
+
+	if err := Fill(&u); err != nil {
+		t.Fatalf("Fill returned error: %v", err)
+	}
+
+	check := func(field string, v string, expr string) {
+		t.Helper()
+		if !regexp.MustCompile(`^` + expr + `$`).MatchString(v) {
+			t.Errorf("Fill set %s to invalid value %s", field, strconv.Quote(v))
+		}
+	}
+
+	check("ID", u.ID, `usr_[a-z0-9]{8}`)
+	check("Code", string(u.Code), `[A-Z]{3}`)
+	check("Address.Zip", u.Address.Zip, `\d{3}-\d{4}`)
+	check("Manager.Zip", u.Manager.Zip, `\d{3}-\d{4}`)
+
+	if u.Nickname == nil {
+		t.Errorf("Fill did not set Nickname")
+	} else {
+		check("Nickname", *u.Nickname, `[a-z]{4}`)
+	}
+
+	if len(u.Tags) < 2 || len(u.Tags) > 4 {
+		t.Errorf("Fill set Tags to invalid length: want [2,4], got %d", len(u.Tags))
+	}
+	for _, tag := range u.Tags {
+		check("Tags", tag, `#[a-z]{3,5}`)
+	}
+
+	if len(u.Previous) != 3 {
+		t.Errorf("Fill set Previous to invalid length: want 3, got %d", len(u.Previous))
+	}
+	for _, a := range u.Previous {
+		check("Previous.Zip", a.Zip, `\d{3}-\d{4}`)
+	}
+
+	if u.Missing != nil {
+		t.Errorf("Fill allocated nil pointer Missing")
+	}
+
+	if u.Ignored != "keep" || u.Address.City != "" || u.private != "" {
+		t.Errorf("Fill changed untagged fields")
+	}
+}
+
+func TestFillError(t *testing.T) {
+	var s string
+
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"nil", nil},
+		{"non-pointer", fillUser{}},
+		{"non-struct", &s},
+		{"invalid expression", &struct {
+			V string `pattern:"("`
+		}{}},
+		{"invalid count", &struct {
+			V []string `pattern:"a" patterncount:"5,1"`
+		}{}},
+		{"unsupported kind", &struct {
+			V int `pattern:"1"`
+		}{}},
+		{"unsupported pointer", &struct {
+			V *int `pattern:"1"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		if err := Fill(tt.v); err == nil {
+			t.Errorf("Fill with %s did not return an error", tt.name)
+		}
+	}
+}
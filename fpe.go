@@ -0,0 +1,203 @@
+package pattern
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"math/big"
+)
+
+// FPE returns a Part that encrypts the output of p with the format-preserving encryption FF1 using key.
+// Every byte generated by p must be part of alphabet, which is also the alphabet of the encrypted output.
+// The output of FPE has the same length as the output of p, and equal outputs of p result in equal encrypted outputs.
+// This allows deterministic inputs like a Sequence to be turned into random-looking but unique strings.
+//
+// The key must be a valid AES key of 16, 24 or 32 bytes.
+// The alphabet must consist of 2 to 256 unique bytes.
+// The Part panics if p generates fewer than 2 bytes or a byte that is not part of alphabet.
+// NIST recommends the output space (len(alphabet)^length) to be at least 1,000,000.
+//
+// https://csrc.nist.gov/publications/detail/sp/800-38g/rev-1/final
+func FPE(key []byte, alphabet string, p Part) Part {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		panic("invalid key: " + err.Error())
+	}
+
+	if len(alphabet) < 2 || len(alphabet) > 256 {
+		panic("alphabet must have between 2 and 256 bytes")
+	}
+
+	f := fpe{
+		ff1: ff1{
+			block: block,
+			radix: uint32(len(alphabet)),
+		},
+		alphabet: alphabet,
+		index:    make([]int16, 256),
+		part:     p,
+	}
+
+	for i := range f.index {
+		f.index[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		if f.index[alphabet[i]] != -1 {
+			panic("alphabet must not contain duplicate bytes")
+		}
+		f.index[alphabet[i]] = int16(i)
+	}
+
+	return f
+}
+
+type fpe struct {
+	ff1      ff1
+	alphabet string
+	// index maps a byte to its position in alphabet or -1.
+	index []int16
+	part  Part
+}
+
+func (p fpe) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p fpe) appendRun(r *run, b []byte) []byte {
+	defer r.restoreLimit(r.suspendLimit())
+	start := len(b)
+	b = appendRun(r, p.part, b)
+	p.encrypt(b[start:])
+	return b
+}
+
+// encrypt encrypts x in place.
+func (p fpe) encrypt(x []byte) {
+	if len(x) < 2 {
+		panic("fpe: input must be at least 2 bytes long")
+	}
+
+	nums := make([]uint32, len(x))
+	for i, c := range x {
+		if p.index[c] < 0 {
+			panic("fpe: input contains byte not in alphabet")
+		}
+		nums[i] = uint32(p.index[c])
+	}
+
+	nums = p.ff1.encrypt(nil, nums)
+
+	for i, n := range nums {
+		x[i] = p.alphabet[n]
+	}
+}
+
+func (p fpe) Children() []Part {
+	return []Part{p.part}
+}
+
+// ff1 implements the FF1 encryption of NIST SP 800-38G.
+type ff1 struct {
+	block cipher.Block
+	radix uint32
+}
+
+// encrypt encrypts the numeral string x with tweak.
+// The result is stored in x.
+func (f ff1) encrypt(tweak []byte, x []uint32) []uint32 {
+	n := len(x)
+	t := len(tweak)
+	u := n / 2
+	v := n - u
+
+	radix := big.NewInt(int64(f.radix))
+	radixU := new(big.Int).Exp(radix, big.NewInt(int64(u)), nil)
+	radixV := new(big.Int).Exp(radix, big.NewInt(int64(v)), nil)
+
+	// b = ceil(ceil(v*log2(radix))/8), where ceil(v*log2(radix)) is the bit length of radix^v-1.
+	b := (new(big.Int).Sub(radixV, big.NewInt(1)).BitLen() + 7) / 8
+	d := 4*((b+3)/4) + 4
+
+	// P || Q has a length that is a multiple of the block size.
+	pad := ((-t-b-1)%16 + 16) % 16
+	pq := make([]byte, 16+t+pad+1+b)
+	pq[0], pq[1], pq[2] = 1, 2, 1
+	pq[3], pq[4], pq[5] = byte(f.radix>>16), byte(f.radix>>8), byte(f.radix)
+	pq[6] = 10
+	pq[7] = byte(u)
+	binary.BigEndian.PutUint32(pq[8:12], uint32(n))
+	binary.BigEndian.PutUint32(pq[12:16], uint32(t))
+	copy(pq[16:], tweak)
+
+	s := make([]byte, ((d+15)/16)*16)
+	a, bb := f.num(x[:u]), f.num(x[u:])
+	y := new(big.Int)
+	for i := 0; i < 10; i++ {
+		// Q = T || [0]^pad || [i]^1 || [NUM(B)]^b
+		pq[16+t+pad] = byte(i)
+		bb.FillBytes(pq[len(pq)-b:])
+
+		// R = PRF(P || Q)
+		r := s[:16]
+		for j := range r {
+			r[j] = 0
+		}
+		for j := 0; j < len(pq); j += 16 {
+			for k := 0; k < 16; k++ {
+				r[k] ^= pq[j+k]
+			}
+			f.block.Encrypt(r, r)
+		}
+
+		// S = R || CIPH(R xor [1]^16) || CIPH(R xor [2]^16) ...
+		for j := 1; j < len(s)/16; j++ {
+			blk := s[j*16 : (j+1)*16]
+			copy(blk, r)
+			ctr := uint64(j)
+			for k := 15; k >= 8; k-- {
+				blk[k] ^= byte(ctr)
+				ctr >>= 8
+			}
+			f.block.Encrypt(blk, blk)
+		}
+		y.SetBytes(s[:d])
+
+		// c = (NUM(A) + y) mod radix^m
+		m := radixU
+		if i%2 == 1 {
+			m = radixV
+		}
+		a.Add(a, y).Mod(a, m)
+
+		a, bb = bb, a
+	}
+
+	return f.str(f.str(x[:0], a, u), bb, v)
+}
+
+// num returns the number represented by the numeral string x, most significant numeral first.
+func (f ff1) num(x []uint32) *big.Int {
+	radix := big.NewInt(int64(f.radix))
+	res := new(big.Int)
+	for _, n := range x {
+		res.Mul(res, radix).Add(res, big.NewInt(int64(n)))
+	}
+	return res
+}
+
+// str appends the numeral string of length m representing c to x.
+func (f ff1) str(x []uint32, c *big.Int, m int) []uint32 {
+	radix := big.NewInt(int64(f.radix))
+	c = new(big.Int).Set(c)
+	rem := new(big.Int)
+
+	start := len(x)
+	for i := 0; i < m; i++ {
+		x = append(x, 0)
+	}
+	for i := start + m - 1; i >= start; i-- {
+		c.DivMod(c, radix, rem)
+		x[i] = uint32(rem.Int64())
+	}
+	return x
+}
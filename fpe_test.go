@@ -0,0 +1,98 @@
+package pattern
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+)
+
+func TestFF1(t *testing.T) {
+	// Test vectors from https://csrc.nist.gov/CSRC/media/Projects/Cryptographic-Standards-and-Guidelines/documents/examples/FF1samples.pdf
+	tests := []struct {
+		name     string
+		key      string
+		tweak    string
+		alphabet string
+		in       string
+		want     string
+	}{
+		{"sample 1", "2B7E151628AED2A6ABF7158809CF4F3C", "", "0123456789", "0123456789", "2433477484"},
+		{"sample 2", "2B7E151628AED2A6ABF7158809CF4F3C", "39383736353433323130", "0123456789", "0123456789", "6124200773"},
+		{"sample 3", "2B7E151628AED2A6ABF7158809CF4F3C", "3737373770717273373737", digits36, "0123456789abcdefghi", "a9tv40mll9kdu509eum"},
+		{"sample 4", "2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F", "", "0123456789", "0123456789", "2830668132"},
+		{"sample 7", "2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F7F036D6F04FC6A94", "", "0123456789", "0123456789", "6657667009"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, _ := hex.DecodeString(tt.key)
+			tweak, _ := hex.DecodeString(tt.tweak)
+
+			f := FPE(key, tt.alphabet, nil).(fpe)
+
+			nums := make([]uint32, len(tt.in))
+			for i := range tt.in {
+				nums[i] = uint32(f.index[tt.in[i]])
+			}
+			nums = f.ff1.encrypt(tweak, nums)
+
+			got := make([]byte, len(nums))
+			for i, n := range nums {
+				got[i] = tt.alphabet[n]
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("FF1 returned invalid value: want %s, got %s", strconv.Quote(tt.want), strconv.Quote(string(got)))
+			}
+		})
+	}
+}
+
+func TestFPE(t *testing.T) {
+	key, _ := hex.DecodeString("2B7E151628AED2A6ABF7158809CF4F3C")
+
+	p := New(FPE(key, "0123456789", Literal("0123456789"))).String()
+	if p != "2433477484" {
+		t.Errorf("FPE returned invalid value: want \"2433477484\", got %s", strconv.Quote(p))
+	}
+
+	gen := New(Literal("C-"), FPE(key, "0123456789", Sequence(0, 9999, 4)))
+	seen := make(map[string]bool, 10000)
+	for i := 0; i < 10000; i++ {
+		v := gen.String()
+		if len(v) != 6 || v[:2] != "C-" {
+			t.Errorf("FPE returned invalid value: got %s", strconv.Quote(v))
+		}
+		if seen[v] {
+			t.Errorf("FPE returned duplicate value %s", strconv.Quote(v))
+		}
+		seen[v] = true
+	}
+}
+
+func TestFPEPanic(t *testing.T) {
+	key := make([]byte, 16)
+
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"invalid key", func() { FPE([]byte("key"), "01", Literal("01")) }},
+		{"short alphabet", func() { FPE(key, "0", Literal("00")) }},
+		{"duplicate alphabet", func() { FPE(key, "010", Literal("01")) }},
+		{"short input", func() { id = New(FPE(key, "01", Literal("0"))).String() }},
+		{"invalid input", func() { id = New(FPE(key, "01", Literal("012"))).String() }},
+	}
+
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("FPE with %s did not panic", tt.name)
+				}
+			}()
+
+			tt.f()
+		}()
+	}
+}
@@ -0,0 +1,98 @@
+package pattern
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// PartFunc is a function that implements the Part interface.
+// It is the extension point for logic that the built-in Parts do not cover, e.g. database lookups or domain rules,
+// without defining a new type. The function appends its output to the passed slice and returns the result.
+//
+// PartFuncs are opaque to the package: they use their own source of randomness,
+// and functions that analyze patterns, like Match, Shrink or Pattern, do not support them.
+type PartFunc func(b []byte) []byte
+
+// Append calls f(b).
+//
+// Implements the Part interface.
+func (f PartFunc) Append(b []byte) []byte {
+	return f(b)
+}
+
+// FromFunc returns a Part that outputs the string returned by f in each iteration.
+//
+// Panics if f is nil.
+func FromFunc(f func() string) Part {
+	if f == nil {
+		panic("function must not be nil")
+	}
+
+	return PartFunc(func(b []byte) []byte {
+		return append(b, f()...)
+	})
+}
+
+// FromStringer returns a Part that outputs s.String() in each iteration, e.g. a time.Duration or a uuid.UUID provider.
+//
+// Panics if s is nil.
+func FromStringer(s fmt.Stringer) Part {
+	if s == nil {
+		panic("stringer must not be nil")
+	}
+
+	return PartFunc(func(b []byte) []byte {
+		return append(b, s.String()...)
+	})
+}
+
+// FromSource returns a Part that outputs the string returned by f in each iteration.
+// Like SequenceBackend, the Part panics if f returns an error; the panic value is an error wrapping it,
+// so it can be recovered and inspected with errors.Is and errors.As.
+//
+// Panics if f is nil.
+func FromSource(f func() (string, error)) Part {
+	if f == nil {
+		panic("function must not be nil")
+	}
+
+	return PartFunc(func(b []byte) []byte {
+		s, err := f()
+		if err != nil {
+			panic(fmt.Errorf("source failed: %w", err))
+		}
+		return append(b, s...)
+	})
+}
+
+// ReadN returns a Part that appends the next n bytes read from r in each iteration,
+// e.g. from /dev/urandom, a hardware RNG or a file with recorded values.
+// Short reads are retried until n bytes are read, like io.ReadFull. If r returns an error before n bytes are read,
+// including io.EOF at the end of a file, the Part panics with an error wrapping it.
+// Reads are serialized, so the Part is safe for concurrent use.
+//
+// Panics if r is nil or n is < 0.
+func ReadN(r io.Reader, n int) Part {
+	if r == nil {
+		panic("reader must not be nil")
+	}
+
+	if n < 0 {
+		panic("n must be >= 0")
+	}
+
+	var mu sync.Mutex
+	return PartFunc(func(b []byte) []byte {
+		start := len(b)
+		b = append(b, make([]byte, n)...)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if _, err := io.ReadFull(r, b[start:]); err != nil {
+			panic(fmt.Errorf("read failed: %w", err))
+		}
+		return b
+	})
+}
@@ -0,0 +1,98 @@
+package pattern
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestPartFunc(t *testing.T) {
+	var n int
+	gen := New(
+		Literal("id-"),
+		PartFunc(func(b []byte) []byte {
+			n++
+			return strconv.AppendInt(b, int64(n), 10)
+		}),
+		Literal("-"),
+		FromFunc(func() string {
+			return "x" + strconv.Itoa(n)
+		}),
+	)
+
+	for _, want := range []string{"id-1-x1", "id-2-x2"} {
+		if v := gen.String(); v != want {
+			t.Errorf("got %q, want %q", v, want)
+		}
+	}
+
+	if v := explainPart(PartFunc(nil)); v != "PartFunc" {
+		t.Errorf("got %q, want PartFunc", v)
+	}
+}
+
+func TestFromFuncPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("FromFunc(nil) did not panic")
+		}
+	}()
+	FromFunc(nil)
+}
+
+func TestFromStringer(t *testing.T) {
+	gen := New(Literal("t="), FromStringer(1500*time.Millisecond))
+	if v := gen.String(); v != "t=1.5s" {
+		t.Errorf("got %q, want t=1.5s", v)
+	}
+}
+
+func TestFromSource(t *testing.T) {
+	values := []string{"a", "b"}
+	gen := New(Literal("v="), FromSource(func() (string, error) {
+		if len(values) == 0 {
+			return "", io.EOF
+		}
+		v := values[0]
+		values = values[1:]
+		return v, nil
+	}))
+
+	for _, want := range []string{"v=a", "v=b"} {
+		if v := gen.String(); v != want {
+			t.Errorf("got %q, want %q", v, want)
+		}
+	}
+
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, io.EOF) {
+			t.Errorf("got panic %v, want error wrapping io.EOF", err)
+		}
+	}()
+	_ = gen.String()
+}
+
+func TestReadN(t *testing.T) {
+	// iotest.OneByteReader forces short reads.
+	gen := New(Literal("<"), ReadN(iotest.OneByteReader(strings.NewReader("abcdefg")), 3), Literal(">"))
+
+	for _, want := range []string{"<abc>", "<def>"} {
+		if v := gen.String(); v != want {
+			t.Errorf("got %q, want %q", v, want)
+		}
+	}
+
+	// Only one byte is left.
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("got panic %v, want error wrapping io.ErrUnexpectedEOF", err)
+		}
+	}()
+	_ = gen.String()
+}
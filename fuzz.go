@@ -0,0 +1,71 @@
+package pattern
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// FuzzArg is an argument of a fuzz target, see FuzzString and FuzzBytes.
+type FuzzArg struct {
+	part  Part
+	bytes bool
+}
+
+// FuzzString returns a FuzzArg for an argument of type string generated by p.
+func FuzzString(p Part) FuzzArg {
+	return FuzzArg{part: p}
+}
+
+// FuzzBytes returns a FuzzArg for an argument of type []byte generated by p.
+func FuzzBytes(p Part) FuzzArg {
+	return FuzzArg{part: p, bytes: true}
+}
+
+// WriteFuzzCorpus writes n corpus entries in the format of `go test -fuzz` to dir.
+// Each entry holds one generated value for each argument of the fuzz target in args.
+// dir is usually testdata/fuzz/FuzzXxx, which `go test` reads as the seed corpus of the fuzz target FuzzXxx.
+// Like `go test`, the file names are derived from the content, so equal entries are only written once.
+// WriteFuzzCorpus panics if args is empty.
+func WriteFuzzCorpus(dir string, n int, args ...FuzzArg) error {
+	if len(args) == 0 {
+		panic("fuzz target must have at least one argument")
+	}
+
+	if err := os.MkdirAll(dir, 0o777); err != nil {
+		return err
+	}
+
+	// out is large enough for the output of every argument.
+	var hint int
+	for _, arg := range args {
+		if h := capacityHint([]Part{arg.part}); h > hint {
+			hint = h
+		}
+	}
+	out := make([]byte, 0, hint)
+
+	b := make([]byte, 0, 100)
+	for i := 0; i < n; i++ {
+		b = append(b[:0], "go test fuzz v1\n"...)
+		for _, arg := range args {
+			out = arg.part.Append(out[:0])
+			v := string(out)
+			if arg.bytes {
+				b = append(b, "[]byte("...)
+			} else {
+				b = append(b, "string("...)
+			}
+			b = strconv.AppendQuote(b, v)
+			b = append(b, ")\n"...)
+		}
+
+		name := fmt.Sprintf("%x", sha256.Sum256(b))[:16]
+		if err := os.WriteFile(filepath.Join(dir, name), b, 0o666); err != nil {
+			return err
+		}
+	}
+	return nil
+}
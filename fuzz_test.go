@@ -0,0 +1,58 @@
+package pattern
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestWriteFuzzCorpus(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "testdata", "fuzz", "FuzzParse")
+
+	err := WriteFuzzCorpus(dir, 20,
+		FuzzString(New(Literal("id-"), Sequence(1, 999, 3))),
+		FuzzBytes(New(OneOfString([]string{"a\"b", "c\n"}))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 20 {
+		t.Fatalf("WriteFuzzCorpus wrote invalid number of files: want 20, got %d", len(entries))
+	}
+
+	re := regexp.MustCompile(`^go test fuzz v1\nstring\("id-\d{3}"\)\n\[\]byte\(("a\\"b"|"c\\n")\)\n$`)
+	for _, e := range entries {
+		b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !re.Match(b) {
+			t.Errorf("WriteFuzzCorpus wrote invalid entry: %s", strconv.Quote(string(b)))
+		}
+
+		if name := fmt.Sprintf("%x", sha256.Sum256(b))[:16]; e.Name() != name {
+			t.Errorf("WriteFuzzCorpus wrote invalid file name: want %s, got %s", name, e.Name())
+		}
+	}
+}
+
+func TestWriteFuzzCorpusPanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("WriteFuzzCorpus did not panic without arguments")
+			}
+		}()
+		WriteFuzzCorpus(t.TempDir(), 1)
+	}()
+}
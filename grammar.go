@@ -0,0 +1,179 @@
+package pattern
+
+import (
+	"fmt"
+)
+
+// defineMaxDepth is the default number of times a rule of Define can be expanded recursively.
+const defineMaxDepth = 8
+
+// ruleRetries is the number of times a rule is regenerated at the maximum depth if it tries to expand itself again.
+const ruleRetries = 100
+
+// Define returns a Part that outputs p, where each RuleRef(name) inside p outputs p again,
+// e.g. for nested expressions, paths or balanced structures to fuzz parsers:
+//
+//	expr := Define("expr", OneOf(
+//		OneOfByteRange('0', '9'),
+//		Group(Literal("("), RuleRef("expr"), OneOfByte([]byte("+*")), RuleRef("expr"), Literal(")")),
+//	))
+//
+// A RuleRef refers to the innermost Define with the same name, so rules can be nested and mutually recursive.
+// The rule is expanded at most 8 levels deep, unless changed with DefineMaxDepth.
+// Expansions at the maximum depth that try to expand the rule again are regenerated up to 100 times,
+// which guarantees termination.
+//
+// The Part panics with an error wrapping ErrRetriesExhausted if no expansion at the maximum depth terminates within the retries.
+// Panics if name is empty.
+func Define(name string, p Part, opts ...DefineOption) Part {
+	if name == "" {
+		panic("name must not be empty")
+	}
+
+	d := define{
+		name:     name,
+		part:     p,
+		maxDepth: defineMaxDepth,
+	}
+	for _, opt := range opts {
+		opt(&d)
+	}
+	return d
+}
+
+// DefineOption changes how Define expands its rule.
+type DefineOption func(*define)
+
+// DefineMaxDepth makes Define expand its rule at most maxDepth levels deep.
+// With a maxDepth of 0, every RuleRef of the rule is rejected.
+//
+// Panics if maxDepth is < 0.
+func DefineMaxDepth(maxDepth int) DefineOption {
+	if maxDepth < 0 {
+		panic("maxDepth must be >= 0")
+	}
+
+	return func(p *define) {
+		p.maxDepth = maxDepth
+	}
+}
+
+type define struct {
+	name     string
+	part     Part
+	maxDepth int
+}
+
+// rule is the state of a Define during a run.
+type rule struct {
+	define
+	depth int
+}
+
+// ruleAbort is panicked by a RuleRef that exceeds the maximum depth of its rule
+// and recovered by the expansion of the rule at the maximum depth.
+type ruleAbort struct {
+	rule *rule
+}
+
+func (p define) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p define) appendRun(r *run, b []byte) []byte {
+	if r == nil {
+		r = &run{}
+	}
+	if r.rules == nil {
+		r.rules = make(map[string]*rule)
+	}
+
+	outer := r.rules[p.name]
+	defer func() {
+		if outer == nil {
+			delete(r.rules, p.name)
+		} else {
+			r.rules[p.name] = outer
+		}
+	}()
+
+	rl := &rule{define: p}
+	r.rules[p.name] = rl
+	return rl.expand(r, b)
+}
+
+// expand appends the rule at its current depth to b.
+// At the maximum depth, expansions that try to expand the rule again are regenerated.
+func (rl *rule) expand(r *run, b []byte) []byte {
+	if rl.depth < rl.maxDepth {
+		return appendRun(r, rl.part, b)
+	}
+
+	defer r.restoreLimit(r.suspendLimit())
+	for i := 0; ; i++ {
+		if out, ok := rl.expandLeaf(r, b); ok {
+			return out
+		}
+
+		if i == ruleRetries {
+			panic(fmt.Errorf("%w: no expansion of rule %s terminated at depth %d after %d retries", ErrRetriesExhausted, rl.name, rl.depth, ruleRetries))
+		}
+	}
+}
+
+// expandLeaf appends the rule to b or returns false if it tried to expand itself again.
+func (rl *rule) expandLeaf(r *run, b []byte) (out []byte, ok bool) {
+	defer func() {
+		if v := recover(); v != nil {
+			if a, isAbort := v.(ruleAbort); isAbort && a.rule == rl {
+				out, ok = b, false
+				return
+			}
+			panic(v)
+		}
+	}()
+
+	return appendRun(r, rl.part, b), true
+}
+
+func (p define) Children() []Part {
+	return []Part{p.part}
+}
+
+// RuleRef returns a Part that outputs the rule name of the innermost enclosing Define again.
+// RuleRef can only be used inside a Define of name.
+//
+// Panics if name is empty.
+func RuleRef(name string) Part {
+	if name == "" {
+		panic("name must not be empty")
+	}
+
+	return ruleRef(name)
+}
+
+type ruleRef string
+
+func (p ruleRef) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p ruleRef) appendRun(r *run, b []byte) []byte {
+	var rl *rule
+	if r != nil {
+		rl = r.rules[string(p)]
+	}
+	if rl == nil {
+		panic("RuleRef used outside of Define " + string(p))
+	}
+
+	if rl.depth >= rl.maxDepth {
+		panic(ruleAbort{rule: rl})
+	}
+
+	rl.depth++
+	defer func() {
+		rl.depth--
+	}()
+	return rl.expand(r, b)
+}
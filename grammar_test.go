@@ -0,0 +1,99 @@
+package pattern
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDefine(t *testing.T) {
+	expr := New(Define("expr", OneOf(
+		OneOfByteRange('0', '9'),
+		Group(Literal("("), RuleRef("expr"), OneOfByte([]byte("+*")), RuleRef("expr"), Literal(")")),
+	), DefineMaxDepth(4)))
+
+	nested := false
+	for i := 0; i < 1000; i++ {
+		v := expr.String()
+		depth, maxDepth := 0, 0
+		for _, c := range v {
+			switch c {
+			case '(':
+				depth++
+				if depth > maxDepth {
+					maxDepth = depth
+				}
+			case ')':
+				depth--
+			}
+			if depth < 0 {
+				t.Fatalf("Define returned unbalanced value %q", v)
+			}
+		}
+		if depth != 0 {
+			t.Fatalf("Define returned unbalanced value %q", v)
+		}
+		if maxDepth > 4 {
+			t.Fatalf("Define exceeded the maximum depth: %q", v)
+		}
+		if maxDepth > 1 {
+			nested = true
+		}
+	}
+
+	if !nested {
+		t.Errorf("Define never expanded its rule recursively")
+	}
+}
+
+func TestDefineTerminates(t *testing.T) {
+	// Without DefineMaxDepth, the rule would recurse forever.
+	gen := New(Define("a", Group(Literal("a"), OneOf(RuleRef("a"), RuleRef("a"), RuleRef("a"), Literal(""))), DefineMaxDepth(3)))
+	for i := 0; i < 100; i++ {
+		if v := gen.String(); len(v) < 1 || len(v) > 4 || strings.Trim(v, "a") != "" {
+			t.Fatalf("Define returned invalid value %q", v)
+		}
+	}
+
+	if v := New(Define("a", Group(Literal("a"), Potentially(0.5, RuleRef("a"))), DefineMaxDepth(0))).String(); v != "a" {
+		t.Errorf("Define returned invalid value: want \"a\", got %q", v)
+	}
+}
+
+func TestDefineNested(t *testing.T) {
+	// Mutually recursive rules, where "list" refers to the outer Define inside "item".
+	gen := New(Define("list", Group(
+		Literal("["),
+		Define("item", OneOf(Literal("x"), RuleRef("list"))),
+		Potentially(0.5, RuleRef("list")),
+		Literal("]"),
+	), DefineMaxDepth(3)))
+
+	for i := 0; i < 100; i++ {
+		v := gen.String()
+		if strings.Count(v, "[") != strings.Count(v, "]") || strings.Trim(v, "[]x") != "" {
+			t.Fatalf("Define returned invalid value %q", v)
+		}
+	}
+}
+
+func TestDefineRetriesExhausted(t *testing.T) {
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, ErrRetriesExhausted) {
+			t.Errorf("Define did not panic with ErrRetriesExhausted, got %v", err)
+		}
+	}()
+
+	_ = New(Define("a", Group(Literal("a"), RuleRef("a")))).String()
+}
+
+func TestRuleRefOutsideDefine(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("RuleRef did not panic")
+		}
+	}()
+
+	_ = New(Define("a", Literal("a")), RuleRef("a")).String()
+}
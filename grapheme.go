@@ -0,0 +1,110 @@
+package pattern
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// OneOfGrapheme returns a Part that will output one grapheme cluster of s randomly in each iteration,
+// so combining marks, emoji modifiers, ZWJ sequences and flags are never torn from their base.
+//
+// The clusters are split with a simplified version of the extended grapheme cluster rules of UAX #29,
+// which handles combining sequences, variation selectors, emoji modifiers and tags, ZWJ sequences,
+// regional indicator pairs, Hangul syllables and CRLF.
+//
+// https://unicode.org/reports/tr29/
+func OneOfGrapheme(s string) Part {
+	return OneOfString(splitGraphemes(s))
+}
+
+// splitGraphemes splits s into grapheme clusters.
+func splitGraphemes(s string) []string {
+	var clusters []string
+	for len(s) > 0 {
+		n := graphemeLen(s)
+		clusters = append(clusters, s[:n])
+		s = s[n:]
+	}
+	return clusters
+}
+
+// graphemeLen returns the length in bytes of the grapheme cluster at the start of s.
+func graphemeLen(s string) int {
+	prev, n := utf8.DecodeRuneInString(s)
+	if prev == '\r' && len(s) > 1 && s[1] == '\n' {
+		return 2
+	}
+	if unicode.IsControl(prev) {
+		return n
+	}
+
+	// Regional indicators pair up to flags.
+	regional := isRegionalIndicator(prev)
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		switch {
+		case isGraphemeExtend(r):
+		case regional && isRegionalIndicator(r):
+			regional = false
+		case prev == '\u200d' && isPictographic(r):
+		case hangulJoins(prev, r):
+		default:
+			return n
+		}
+		if !isRegionalIndicator(r) {
+			regional = false
+		}
+		prev = r
+		n += size
+	}
+	return n
+}
+
+// isGraphemeExtend reports whether r extends the preceding grapheme cluster.
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		unicode.Is(unicode.Variation_Selector, r) ||
+		r == '\u200c' || r == '\u200d' ||
+		(r >= 0x1F3FB && r <= 0x1F3FF) || // emoji modifiers
+		(r >= 0xE0020 && r <= 0xE007F) // tags
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// isPictographic approximates the Extended_Pictographic property joined by ZWJ sequences.
+func isPictographic(r rune) bool {
+	return unicode.Is(unicode.So, r) || (r >= 0x1F000 && r <= 0x1FAFF)
+}
+
+// hangulType returns the Hangul syllable type of r: L, V, T, LV, LVT or 0.
+func hangulType(r rune) string {
+	switch {
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return "L"
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return "V"
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return "T"
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return "LV"
+		}
+		return "LVT"
+	}
+	return ""
+}
+
+// hangulJoins reports whether the Hangul jamo or syllables prev and r form one syllable.
+func hangulJoins(prev rune, r rune) bool {
+	switch t := hangulType(r); hangulType(prev) {
+	case "L":
+		return t == "L" || t == "V" || t == "LV" || t == "LVT"
+	case "V", "LV":
+		return t == "V" || t == "T"
+	case "T", "LVT":
+		return t == "T"
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package pattern
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitGraphemes(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{"ascii", "ab+", []string{"a", "b", "+"}},
+		{"crlf", "a\r\nb", []string{"a", "\r\n", "b"}},
+		{"combining", "éạ̈", []string{"é", "ạ̈"}},
+		{"zalgo", "t̵̡̛͈̪͙͎̙̘̙̥͇̉̂̈̒͊͋̑͒͘ë̸̢̟͇͓̲̝̣̗̳́̂͆̉̐͂͗͊̈́̓͝s̴̬̳͖̝͊͗̒͊̏t̸͉̬̼̳̯̞͖̯͚̦̎̂̊̓̆̚̚͝+/", []string{"t̵̡̛͈̪͙͎̙̘̙̥͇̉̂̈̒͊͋̑͒͘", "ë̸̢̟͇͓̲̝̣̗̳́̂͆̉̐͂͗͊̈́̓͝", "s̴̬̳͖̝͊͗̒͊̏", "t̸͉̬̼̳̯̞͖̯͚̦̎̂̊̓̆̚̚͝", "+", "/"}},
+		{"skin tone", "👍🏽👍", []string{"👍🏽", "👍"}},
+		{"zwj", "👩‍💻x", []string{"👩‍💻", "x"}},
+		{"variation selector", "❤️!", []string{"❤️", "!"}},
+		{"flags", "🇯🇵🇩🇪🇫", []string{"🇯🇵", "🇩🇪", "🇫"}},
+		{"tags", "🏴\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007Fa", []string{"🏴\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F", "a"}},
+		{"hangul jamo", "각한", []string{"각", "한"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitGraphemes(tt.s)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitGraphemes(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOneOfGrapheme(t *testing.T) {
+	clusters := []string{"👩‍💻", "é", "🇯🇵", "x"}
+	g := New(OneOfGrapheme("👩‍💻é🇯🇵x"))
+
+	seen := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		seen[g.String()] = true
+	}
+
+	for _, c := range clusters {
+		if !seen[c] {
+			t.Errorf("cluster %q was never generated", c)
+		}
+	}
+	if len(seen) != len(clusters) {
+		t.Errorf("generated %d distinct values, want %d", len(seen), len(clusters))
+	}
+}
@@ -0,0 +1,67 @@
+package pattern
+
+import (
+	"hash"
+	"sync"
+)
+
+// Hash returns a Part that replaces the output of p with the first truncate characters of its hex digest,
+// e.g. Hash(sha256.New, 12, p) for content-addressed looking IDs or cache keys.
+// If truncate is <= 0, the full digest is used.
+//
+// Panics if truncate is longer than the hex digest of h.
+func Hash(h func() hash.Hash, truncate int, p Part) Part {
+	size := 2 * h().Size()
+	if truncate > size {
+		panic("truncate must be <= the length of the hex digest")
+	}
+	if truncate <= 0 {
+		truncate = size
+	}
+
+	return hashed{
+		part:     p,
+		truncate: truncate,
+		pool: &sync.Pool{New: func() any {
+			return h()
+		}},
+	}
+}
+
+type hashed struct {
+	part     Part
+	truncate int
+	// pool holds the hashes, which can not be used concurrently.
+	pool *sync.Pool
+}
+
+func (p hashed) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p hashed) appendRun(r *run, b []byte) []byte {
+	defer r.restoreLimit(r.suspendLimit())
+	start := len(b)
+	b = appendRun(r, p.part, b)
+
+	h := p.pool.Get().(hash.Hash)
+	h.Reset()
+	h.Write(b[start:])
+	var buf [64]byte
+	sum := h.Sum(buf[:0])
+	p.pool.Put(h)
+
+	b = b[:start]
+	for i := 0; i < p.truncate; i++ {
+		v := sum[i/2]
+		if i%2 == 0 {
+			v >>= 4
+		}
+		b = append(b, hexDigits[v&0x0f])
+	}
+	return b
+}
+
+func (p hashed) Children() []Part {
+	return []Part{p.part}
+}
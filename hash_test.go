@@ -0,0 +1,66 @@
+package pattern
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	sum := sha256.Sum256([]byte("hello"))
+	digest := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name     string
+		truncate int
+		want     string
+	}{
+		{"full", 0, digest},
+		{"even", 12, digest[:12]},
+		{"odd", 7, digest[:7]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(Literal("id-"), Hash(sha256.New, tt.truncate, Literal("hello")))
+			if got := g.String(); got != "id-"+tt.want {
+				t.Errorf("got %q, want %q", got, "id-"+tt.want)
+			}
+		})
+	}
+}
+
+func TestHashPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Hash with truncate > digest length did not panic")
+		}
+	}()
+
+	Hash(md5.New, 33, Literal("x"))
+}
+
+func TestHashConcurrent(t *testing.T) {
+	g := New(Hash(md5.New, 0, Literal("x")))
+	sum := md5.Sum([]byte("x"))
+	want := hex.EncodeToString(sum[:])
+
+	done := make(chan string)
+	for i := 0; i < 8; i++ {
+		go func() {
+			for j := 0; j < 100; j++ {
+				if s := g.String(); s != want {
+					done <- s
+					return
+				}
+			}
+			done <- want
+		}()
+	}
+	for i := 0; i < 8; i++ {
+		if s := <-done; s != want {
+			t.Errorf("got %q, want %q", s, want)
+		}
+	}
+}
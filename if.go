@@ -0,0 +1,55 @@
+package pattern
+
+// If returns a Part that outputs then if pred returns true and otherwise els, e.g. to branch on feature flags or per-tenant config
+// without rebuilding the generator. pred is called once per iteration and may be called concurrently.
+// A nil els outputs nothing.
+//
+// Panics if pred or then is nil.
+func If(pred func() bool, then Part, els Part) Part {
+	if pred == nil {
+		panic("pred must not be nil")
+	}
+
+	if then == nil {
+		panic("then must not be nil")
+	}
+
+	if els == nil {
+		els = nullpart{}
+	}
+
+	return ifPart{
+		pred: pred,
+		then: then,
+		els:  els,
+	}
+}
+
+type ifPart struct {
+	pred func() bool
+	then Part
+	els  Part
+}
+
+func (p ifPart) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p ifPart) appendRun(r *run, b []byte) []byte {
+	if p.pred() {
+		return appendRun(r, p.then, b)
+	}
+	return appendRun(r, p.els, b)
+}
+
+// oneOf returns the OneOf with the same outputs as the If.
+func (p ifPart) oneOf() anyOf {
+	return anyOf{
+		parts: []Part{p.then, p.els},
+		len:   2,
+	}
+}
+
+func (p ifPart) Children() []Part {
+	return []Part{p.then, p.els}
+}
@@ -0,0 +1,46 @@
+package pattern
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestIf(t *testing.T) {
+	var flag atomic.Bool
+	gen := New(Literal("id-"), If(flag.Load, Literal("new"), Literal("old")), If(flag.Load, Literal("!"), nil))
+
+	if v := gen.String(); v != "id-old" {
+		t.Errorf("If returned invalid value: want \"id-old\", got %q", v)
+	}
+
+	flag.Store(true)
+	if v := gen.String(); v != "id-new!" {
+		t.Errorf("If returned invalid value: want \"id-new!\", got %q", v)
+	}
+
+	p := If(flag.Load, Literal("a"), Literal("b"))
+	if !Match(p, "a") || !Match(p, "b") || Match(p, "c") {
+		t.Errorf("Match returned invalid result")
+	}
+}
+
+func TestIfPanic(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"pred", func() { If(nil, Literal("a"), nil) }},
+		{"then", func() { If(func() bool { return true }, nil, nil) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("If did not panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
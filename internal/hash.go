@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+)
+
+// HashSource is a deterministic Source that derives its values from HMAC-SHA256 keyed with a key.
+// The values are HMAC(key, counter) for an increasing counter.
+type HashSource struct {
+	mac     hash.Hash
+	counter uint64
+	block   [sha256.Size]byte
+	// n is the number of unused bytes in block.
+	n int
+}
+
+// NewHashSource returns a HashSource keyed with key.
+func NewHashSource(key []byte) *HashSource {
+	return &HashSource{
+		mac: hmac.New(sha256.New, key),
+	}
+}
+
+// NewSecretHashSource returns a HashSource keyed with HMAC(secret, key),
+// so its values can't be derived from key without knowing secret.
+func NewSecretHashSource(secret []byte, key []byte) *HashSource {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(key)
+	return NewHashSource(mac.Sum(nil))
+}
+
+// Uint64 returns the next value of the stream.
+func (s *HashSource) Uint64() uint64 {
+	if s.n < 8 {
+		var c [8]byte
+		binary.BigEndian.PutUint64(c[:], s.counter)
+		s.counter++
+
+		s.mac.Reset()
+		s.mac.Write(c[:])
+		s.mac.Sum(s.block[:0])
+		s.n = len(s.block)
+	}
+
+	v := binary.LittleEndian.Uint64(s.block[len(s.block)-s.n:])
+	s.n -= 8
+	return v
+}
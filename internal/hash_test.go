@@ -0,0 +1,29 @@
+package internal
+
+import "testing"
+
+func TestHashSource(t *testing.T) {
+	s1 := NewHashSource([]byte("key"))
+	s2 := NewHashSource([]byte("key"))
+	s3 := NewHashSource([]byte("other"))
+
+	differ := false
+	seen := make(map[uint64]bool, 100)
+	for i := 0; i < 100; i++ {
+		v1, v2, v3 := s1.Uint64(), s2.Uint64(), s3.Uint64()
+		if v1 != v2 {
+			t.Errorf("HashSource with the same key returned different values: %d != %d", v1, v2)
+		}
+		if v1 != v3 {
+			differ = true
+		}
+		if seen[v1] {
+			t.Errorf("HashSource returned duplicate value %d", v1)
+		}
+		seen[v1] = true
+	}
+
+	if !differ {
+		t.Errorf("HashSource with different keys returned the same values")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/binary"
 	"math/bits"
+	"sync"
 )
 
 const (
@@ -11,15 +12,30 @@ const (
 	f53Mul    = 0x1.0p-53
 )
 
+// Source is a source of random uint64 values.
+type Source interface {
+	Uint64() uint64
+}
+
 // RandN returns a random uint32 in [0, n).
 func RandN(n uint32) uint32 {
-	res, _ := bits.Mul64(uint64(n), Fastrand())
+	return Reduce(n, Fastrand())
+}
+
+// Reduce maps the random value x to [0, n).
+func Reduce(n uint32, x uint64) uint32 {
+	res, _ := bits.Mul64(uint64(n), x)
 	return uint32(res)
 }
 
 // Float64 returns a random float64 in [0.0, 1.0).
 func RandFloat64() float64 {
-	return float64(Fastrand()&int53Mask) * f53Mul
+	return Float64(Fastrand())
+}
+
+// Float64 maps the random value x to [0.0, 1.0).
+func Float64(x uint64) float64 {
+	return float64(x&int53Mask) * f53Mul
 }
 
 func SecureRandomReader(b []byte) int {
@@ -44,3 +60,78 @@ func FastRandomReader(b []byte) int {
 
 	return n
 }
+
+// UniformN returns a uniformly distributed random uint32 in [0, n).
+// Unlike RandN, the result is free of any modulo bias.
+//
+// https://arxiv.org/abs/1805.10941
+func UniformN(n uint32) uint32 {
+	hi, lo := bits.Mul64(uint64(n), Fastrand())
+	if lo < uint64(n) {
+		t := -uint64(n) % uint64(n)
+		for lo < t {
+			hi, lo = bits.Mul64(uint64(n), Fastrand())
+		}
+	}
+	return uint32(hi)
+}
+
+// UniformNSource is like UniformN, but uses s as the source of randomness.
+func UniformNSource(s Source, n uint32) uint32 {
+	hi, lo := bits.Mul64(uint64(n), s.Uint64())
+	if lo < uint64(n) {
+		t := -uint64(n) % uint64(n)
+		for lo < t {
+			hi, lo = bits.Mul64(uint64(n), s.Uint64())
+		}
+	}
+	return uint32(hi)
+}
+
+// SecureSource is a Source that reads from crypto/rand.
+type SecureSource struct{}
+
+func (SecureSource) Uint64() uint64 {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// SeedSource is a deterministic Source that is safe for concurrent use.
+// It implements SplitMix64: https://prng.di.unimi.it/splitmix64.c
+type SeedSource struct {
+	mu    sync.Mutex
+	state uint64
+}
+
+// NewSeedSource returns a SeedSource seeded with seed.
+func NewSeedSource(seed uint64) *SeedSource {
+	return &SeedSource{state: seed}
+}
+
+// State returns the current state of s.
+func (s *SeedSource) State() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// SetState restores a state returned by State.
+func (s *SeedSource) SetState(state uint64) {
+	s.mu.Lock()
+	s.state = state
+	s.mu.Unlock()
+}
+
+func (s *SeedSource) Uint64() uint64 {
+	s.mu.Lock()
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	s.mu.Unlock()
+
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
@@ -9,3 +9,9 @@ func BenchmarkRandN(b *testing.B) {
 		v = RandN(100)
 	}
 }
+
+func BenchmarkUniformN(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		v = UniformN(100)
+	}
+}
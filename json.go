@@ -0,0 +1,1497 @@
+package pattern
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// The JSON representation of a Part is an object with the name of its constructor in the field "type",
+// e.g. {"type":"Repeat","min":1,"max":3,"parts":[{"type":"Literal","value":"a"}]}.
+// Parts that hold functions or external state, like FPE, UniqueBy, SequencePer, SequenceBackend
+// or a Sequence with SequenceSkipFunc or SequenceOnUpdate, can not be marshalled.
+// Sequences and PermutedSequences start over when they are unmarshalled.
+
+// UnmarshalFunc returns the Part encoded in data.
+type UnmarshalFunc func(data []byte) (Part, error)
+
+var (
+	partTypesMu sync.RWMutex
+	partTypes   map[string]UnmarshalFunc
+)
+
+func init() {
+	partTypes = map[string]UnmarshalFunc{
+		"New":              unmarshalNew,
+		"Empty":            unmarshalEmpty,
+		"Group":            unmarshalGroup,
+		"Repeat":           unmarshalRepeat,
+		"Potentially":      unmarshalPotentially,
+		"Literal":          unmarshalLiteral,
+		"OneOf":            unmarshalOneOf,
+		"OneOfString":      unmarshalOneOfString,
+		"OneOfByte":        unmarshalOneOfByte,
+		"OneOfRune":        unmarshalOneOfRune,
+		"OneOfByteRange":   unmarshalOneOfByteRange,
+		"Class":            unmarshalClass,
+		"Shuffle":          unmarshalShuffle,
+		"Sequence":         unmarshalSequence,
+		"PermutedSequence": unmarshalPermutedSequence,
+		"NanoID":           unmarshalNanoID,
+		"RandomHex":        unmarshalRandomHex,
+		"RandomBase64URL":  unmarshalRandomBase64URL,
+		"RandomBase32":     unmarshalRandomBase32,
+		"RandomCrockford":  unmarshalRandomCrockford,
+		"Timestamp":        unmarshalTimestamp,
+		"EpochBase":        unmarshalEpochBase,
+		"Throttle":         unmarshalThrottle,
+		"Ref":              unmarshalRef,
+		"Placeholder":      unmarshalPlaceholder,
+		"Upper":            unmarshalWrapper(Upper),
+		"Lower":            unmarshalWrapper(Lower),
+		"Title":            unmarshalWrapper(Title),
+		"HexEncode":        unmarshalWrapper(HexEncode),
+		"Base64Encode":     unmarshalWrapper(func(p Part) Part { return Base64Encode(p, nil) }),
+		"Base32Encode":     unmarshalWrapper(func(p Part) Part { return Base32Encode(p, nil) }),
+		"PadLeft":          unmarshalPad,
+		"PadRight":         unmarshalPad,
+		"ExactLen":         unmarshalExactLen,
+		"MaxTotalLen":      unmarshalMaxTotalLen,
+		"Cycle":            unmarshalCycle,
+		"NoConsecutive":    unmarshalWrapper(NoConsecutive),
+		"RepeatDistinct":   unmarshalRepeatDistinct,
+		"SampleK":          unmarshalSampleK,
+		"ShuffleBytes":     unmarshalWrapper(ShuffleBytes),
+		"ShuffleRunes":     unmarshalWrapper(ShuffleRunes),
+		"AtLeastOneOf":     unmarshalAtLeastOneOf,
+		"Pronounceable":    unmarshalPronounceable,
+		"Markov":           unmarshalMarkov,
+		"Choose":           unmarshalChoose,
+		"OneOfZipf":        unmarshalZipf,
+		"BenfordDigit":     unmarshalBenfordDigit,
+		"BenfordAmount":    unmarshalBenfordAmount,
+		"RepeatNormal":     unmarshalRepeatNormal,
+		"Define":           unmarshalDefine,
+		"Balanced":         unmarshalBalanced,
+		"Mirror":           unmarshalMirror,
+		"MirrorReversed":   unmarshalMirror,
+		"Avoid":            unmarshalAvoid,
+		"Constrain":        unmarshalConstrain,
+		"MaxOutputLen":     unmarshalMaxOutputLen,
+		"RuleRef":          unmarshalRuleRef,
+	}
+}
+
+// RegisterPart makes a custom Part type available to UnmarshalPart.
+// The MarshalJSON method of the custom Part must return an object with name in the field "type".
+// Custom Parts that contain other Parts can encode them with json.Marshal and decode them with UnmarshalPart.
+//
+// Panics if name is empty or already registered, or if unmarshal is nil.
+func RegisterPart(name string, unmarshal UnmarshalFunc) {
+	if name == "" {
+		panic("name must not be empty")
+	}
+
+	if unmarshal == nil {
+		panic("unmarshal must not be nil")
+	}
+
+	partTypesMu.Lock()
+	defer partTypesMu.Unlock()
+
+	if _, ok := partTypes[name]; ok {
+		panic("part type " + name + " is already registered")
+	}
+	partTypes[name] = unmarshal
+}
+
+// UnmarshalPart returns the Part encoded in data by its MarshalJSON method.
+// Invalid arguments of the constructors are returned as errors.
+func UnmarshalPart(data []byte) (Part, error) {
+	p, err := unmarshalPart(data)
+	if err != nil {
+		return nil, fmt.Errorf("pattern: %w", err)
+	}
+	return p, nil
+}
+
+// UnmarshalJSON replaces the Parts of the generator with the pattern encoded in data.
+// Use it together with New, e.g. json.Unmarshal(data, pattern.New()).
+func (g *gen) UnmarshalJSON(data []byte) error {
+	p, err := UnmarshalPart(data)
+	if err != nil {
+		return err
+	}
+
+	n, ok := p.(*gen)
+	if !ok {
+		n = New(p)
+	}
+	*g = *n
+	return nil
+}
+
+func unmarshalPart(data []byte) (Part, error) {
+	var v struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	partTypesMu.RLock()
+	f, ok := partTypes[v.Type]
+	partTypesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown part type %q", v.Type)
+	}
+
+	p, err := f(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", v.Type, err)
+	}
+	return p, nil
+}
+
+func unmarshalParts(data []json.RawMessage) ([]Part, error) {
+	parts := make([]Part, 0, len(data))
+	for _, d := range data {
+		p, err := unmarshalPart(d)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, p)
+	}
+	return parts, nil
+}
+
+// decode decodes data into v and rejects unknown fields, which are usually misspelled.
+func decode(data []byte, v any) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	return d.Decode(v)
+}
+
+// construct calls f and returns the panic of a constructor as an error.
+// Runtime errors are bugs rather than invalid arguments, so they are not recovered.
+func construct(f func() Part) (p Part, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(runtime.Error); ok {
+				panic(r)
+			}
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return f(), nil
+}
+
+func marshalPart(p Part) (json.RawMessage, error) {
+	m, ok := p.(json.Marshaler)
+	if !ok {
+		return nil, fmt.Errorf("pattern: %s can not be marshalled", explainPart(p))
+	}
+	return m.MarshalJSON()
+}
+
+func marshalParts(parts []Part) ([]json.RawMessage, error) {
+	data := make([]json.RawMessage, 0, len(parts))
+	for _, p := range parts {
+		d, err := marshalPart(p)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, d)
+	}
+	return data, nil
+}
+
+// splitBytes returns b as a string if it is valid UTF-8, otherwise as bytes, which are encoded in base64.
+func splitBytes(b []byte) (string, []byte) {
+	if utf8.Valid(b) {
+		return string(b), nil
+	}
+	return "", b
+}
+
+// joinBytes reverses splitBytes.
+func joinBytes(s string, b []byte) []byte {
+	if b != nil {
+		return b
+	}
+	return []byte(s)
+}
+
+type typeJSON struct {
+	Type string `json:"type"`
+}
+
+type partsJSON struct {
+	Type  string            `json:"type"`
+	Parts []json.RawMessage `json:"parts"`
+}
+
+func marshalContainer(typ string, parts []Part) ([]byte, error) {
+	data, err := marshalParts(parts)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(partsJSON{Type: typ, Parts: data})
+}
+
+func unmarshalContainer(data []byte) ([]Part, error) {
+	var v partsJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+	return unmarshalParts(v.Parts)
+}
+
+// MarshalJSON encodes the Parts of the generator, which can be decoded with UnmarshalJSON or UnmarshalPart.
+// It returns an error if the pattern contains Parts that can not be marshalled.
+func (g gen) MarshalJSON() ([]byte, error) {
+	return marshalContainer("New", g.parts)
+}
+
+func unmarshalNew(data []byte) (Part, error) {
+	parts, err := unmarshalContainer(data)
+	if err != nil {
+		return nil, err
+	}
+	return New(parts...), nil
+}
+
+func (p nullpart) MarshalJSON() ([]byte, error) {
+	return json.Marshal(typeJSON{Type: "Empty"})
+}
+
+func unmarshalEmpty(data []byte) (Part, error) {
+	var v typeJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+	return nullpart{}, nil
+}
+
+func (p group) MarshalJSON() ([]byte, error) {
+	return marshalContainer("Group", p)
+}
+
+func unmarshalGroup(data []byte) (Part, error) {
+	parts, err := unmarshalContainer(data)
+	if err != nil {
+		return nil, err
+	}
+	return Group(parts...), nil
+}
+
+type repeatJSON struct {
+	Type  string            `json:"type"`
+	Min   uint32            `json:"min"`
+	Max   uint32            `json:"max"`
+	Parts []json.RawMessage `json:"parts"`
+}
+
+func marshalRepeat(min uint32, max uint32, parts []Part) ([]byte, error) {
+	data, err := marshalParts(parts)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(repeatJSON{Type: "Repeat", Min: min, Max: max, Parts: data})
+}
+
+func (p constRepeat) MarshalJSON() ([]byte, error) {
+	return marshalRepeat(p.n, p.n, p.group[:len(p.group)/int(p.n)])
+}
+
+func (p repeat) MarshalJSON() ([]byte, error) {
+	return marshalRepeat(p.min, p.min+p.maxr-1, p.parts)
+}
+
+func unmarshalRepeat(data []byte) (Part, error) {
+	var v repeatJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	parts, err := unmarshalParts(v.Parts)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return Repeat(v.Min, v.Max, parts...)
+	})
+}
+
+func (p atLeastOneOf) MarshalJSON() ([]byte, error) {
+	data, err := marshalParts(p.classes)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(repeatJSON{Type: "AtLeastOneOf", Min: p.min, Max: p.min + p.maxr - 1, Parts: data})
+}
+
+func unmarshalAtLeastOneOf(data []byte) (Part, error) {
+	var v repeatJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	parts, err := unmarshalParts(v.Parts)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return AtLeastOneOf(v.Min, v.Max, parts...)
+	})
+}
+
+type potentiallyJSON struct {
+	Type string          `json:"type"`
+	P    float64         `json:"p"`
+	Part json.RawMessage `json:"part"`
+}
+
+func marshalPotentially(c float64, p Part) ([]byte, error) {
+	data, err := marshalPart(p)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(potentiallyJSON{Type: "Potentially", P: c, Part: data})
+}
+
+func (p potentially50) MarshalJSON() ([]byte, error) {
+	if p.from != "" {
+		return marshalRepeat(0, 1, []Part{p.part})
+	}
+	return marshalPotentially(0.5, p.part)
+}
+
+func (p potentiallyP) MarshalJSON() ([]byte, error) {
+	return marshalPotentially(p.percent, p.part)
+}
+
+func unmarshalPotentially(data []byte) (Part, error) {
+	var v potentiallyJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	part, err := unmarshalPart(v.Part)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return Potentially(v.P, part)
+	})
+}
+
+type literalJSON struct {
+	Type  string `json:"type"`
+	Value string `json:"value,omitempty"`
+	Bytes []byte `json:"bytes,omitempty"`
+}
+
+func (p literal) MarshalJSON() ([]byte, error) {
+	v := literalJSON{Type: "Literal"}
+	v.Value, v.Bytes = splitBytes(p)
+	return json.Marshal(v)
+}
+
+func unmarshalLiteral(data []byte) (Part, error) {
+	var v literalJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+	return Literal(string(joinBytes(v.Value, v.Bytes))), nil
+}
+
+func (p anyOf) MarshalJSON() ([]byte, error) {
+	return marshalContainer("OneOf", p.parts)
+}
+
+func unmarshalOneOf(data []byte) (Part, error) {
+	parts, err := unmarshalContainer(data)
+	if err != nil {
+		return nil, err
+	}
+	return OneOf(parts...), nil
+}
+
+type stringsJSON struct {
+	Type   string   `json:"type"`
+	Values []string `json:"values"`
+}
+
+func (p anyOfString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(stringsJSON{Type: "OneOfString", Values: p.alphabet})
+}
+
+func unmarshalOneOfString(data []byte) (Part, error) {
+	var v stringsJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+	return OneOfString(v.Values), nil
+}
+
+type alphabetJSON struct {
+	Type     string `json:"type"`
+	Alphabet string `json:"alphabet,omitempty"`
+	Bytes    []byte `json:"bytes,omitempty"`
+}
+
+func (p anyOfByte) MarshalJSON() ([]byte, error) {
+	v := alphabetJSON{Type: "OneOfByte"}
+	v.Alphabet, v.Bytes = splitBytes(p.alphabet)
+	return json.Marshal(v)
+}
+
+func unmarshalOneOfByte(data []byte) (Part, error) {
+	var v alphabetJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+	return OneOfByte(joinBytes(v.Alphabet, v.Bytes)), nil
+}
+
+func (p anyOfRune) MarshalJSON() ([]byte, error) {
+	return json.Marshal(alphabetJSON{Type: "OneOfRune", Alphabet: string(p.alphabet)})
+}
+
+func unmarshalOneOfRune(data []byte) (Part, error) {
+	var v alphabetJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+	return OneOfRune([]rune(v.Alphabet)), nil
+}
+
+type byteRangeJSON struct {
+	Type string `json:"type"`
+	Lo   byte   `json:"lo"`
+	Hi   byte   `json:"hi"`
+}
+
+func (p byteRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(byteRangeJSON{Type: "OneOfByteRange", Lo: p.lo, Hi: p.lo + byte(p.n-1)})
+}
+
+func unmarshalOneOfByteRange(data []byte) (Part, error) {
+	var v byteRangeJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return OneOfByteRange(v.Lo, v.Hi)
+	})
+}
+
+type classJSON struct {
+	Type   string    `json:"type"`
+	Ranges [][2]rune `json:"ranges"`
+}
+
+func (p runeRanges) MarshalJSON() ([]byte, error) {
+	v := classJSON{Type: "Class"}
+	for i := 0; i < len(p.pairs); i += 2 {
+		v.Ranges = append(v.Ranges, [2]rune{p.pairs[i], p.pairs[i+1]})
+	}
+	return json.Marshal(v)
+}
+
+func unmarshalClass(data []byte) (Part, error) {
+	var v classJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	pairs := make([]rune, 0, 2*len(v.Ranges))
+	for _, r := range v.Ranges {
+		if r[0] < 0 || r[1] > utf8.MaxRune || r[1] < r[0] {
+			return nil, fmt.Errorf("invalid range [%d, %d]", r[0], r[1])
+		}
+		pairs = append(pairs, r[0], r[1])
+	}
+	return charClass(pairs)
+}
+
+type sampleKJSON struct {
+	Type  string            `json:"type"`
+	K     int               `json:"k"`
+	Parts []json.RawMessage `json:"parts"`
+}
+
+func (p shuffle) MarshalJSON() ([]byte, error) {
+	if p.k == p.len {
+		return marshalContainer("Shuffle", p.parts)
+	}
+
+	data, err := marshalParts(p.parts)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(sampleKJSON{Type: "SampleK", K: int(p.k), Parts: data})
+}
+
+func unmarshalShuffle(data []byte) (Part, error) {
+	parts, err := unmarshalContainer(data)
+	if err != nil {
+		return nil, err
+	}
+	return Shuffle(parts...), nil
+}
+
+func unmarshalSampleK(data []byte) (Part, error) {
+	var v sampleKJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	parts, err := unmarshalParts(v.Parts)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return SampleK(v.K, parts...)
+	})
+}
+
+type sequenceJSON struct {
+	Type       string   `json:"type"`
+	Start      uint64   `json:"start"`
+	Max        uint64   `json:"max"`
+	Width      int      `json:"width"`
+	Alphabet   string   `json:"alphabet,omitempty"`
+	Bytes      []byte   `json:"bytes,omitempty"`
+	Step       uint64   `json:"step,omitempty"`
+	Descending bool     `json:"descending,omitempty"`
+	Skip       []uint64 `json:"skip,omitempty"`
+}
+
+func (p sequence) MarshalJSON() ([]byte, error) {
+	if (p.skip != nil && p.reserved == nil) || p.onUpdate != nil {
+		return nil, errors.New("pattern: Sequence with SequenceSkipFunc or SequenceOnUpdate can not be marshalled")
+	}
+
+	v := sequenceJSON{
+		Type:       "Sequence",
+		Start:      p.start,
+		Max:        p.max,
+		Width:      p.width,
+		Descending: p.desc,
+		Skip:       p.reserved,
+	}
+	v.Alphabet, v.Bytes = splitBytes([]byte(p.alphabet))
+	if p.step != 1 {
+		v.Step = p.step
+	}
+	return json.Marshal(v)
+}
+
+func unmarshalSequence(data []byte) (Part, error) {
+	var v sequenceJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		var opts []SequenceOption
+		if v.Step != 0 {
+			opts = append(opts, SequenceStep(v.Step))
+		}
+		if v.Descending {
+			opts = append(opts, SequenceDescending())
+		}
+		if v.Skip != nil {
+			opts = append(opts, SequenceSkip(v.Skip...))
+		}
+
+		if alphabet := joinBytes(v.Alphabet, v.Bytes); len(alphabet) > 0 {
+			return SequenceBase(v.Start, v.Max, v.Width, alphabet, opts...)
+		}
+		return Sequence(v.Start, v.Max, v.Width, opts...)
+	})
+}
+
+type permutedSequenceJSON struct {
+	Type  string `json:"type"`
+	Start uint64 `json:"start"`
+	Max   uint64 `json:"max"`
+	Width int    `json:"width"`
+	Key   uint64 `json:"key"`
+}
+
+func (p permutation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(permutedSequenceJSON{
+		Type:  "PermutedSequence",
+		Start: p.start,
+		Max:   p.start + p.n - 1,
+		Width: p.width,
+		Key:   p.key,
+	})
+}
+
+func unmarshalPermutedSequence(data []byte) (Part, error) {
+	var v permutedSequenceJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return PermutedSequence(v.Start, v.Max, v.Width, v.Key)
+	})
+}
+
+type nanoIDJSON struct {
+	Type     string `json:"type"`
+	Size     int    `json:"size"`
+	Alphabet string `json:"alphabet,omitempty"`
+	Bytes    []byte `json:"bytes,omitempty"`
+}
+
+func marshalNanoID(size int, alphabet []byte) ([]byte, error) {
+	v := nanoIDJSON{Type: "NanoID", Size: size}
+	v.Alphabet, v.Bytes = splitBytes(alphabet)
+	return json.Marshal(v)
+}
+
+func (p nanoIDMask) MarshalJSON() ([]byte, error) {
+	return marshalNanoID(p.size, p.alphabet)
+}
+
+func (p nanoID) MarshalJSON() ([]byte, error) {
+	return marshalNanoID(p.size, p.alphabet)
+}
+
+func unmarshalNanoID(data []byte) (Part, error) {
+	var v nanoIDJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return NanoID(v.Size, joinBytes(v.Alphabet, v.Bytes))
+	})
+}
+
+type randomJSON struct {
+	Type  string `json:"type"`
+	Bytes int    `json:"bytes"`
+}
+
+type markovJSON struct {
+	Type   string   `json:"type"`
+	Corpus []string `json:"corpus"`
+	Order  int      `json:"order"`
+	MinLen int      `json:"min"`
+	MaxLen int      `json:"max"`
+}
+
+func (p markov) MarshalJSON() ([]byte, error) {
+	return json.Marshal(markovJSON{Type: "Markov", Corpus: p.corpus, Order: p.order, MinLen: p.minLen, MaxLen: p.maxLen})
+}
+
+func unmarshalMarkov(data []byte) (Part, error) {
+	var v markovJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return Markov(v.Corpus, v.Order, v.MinLen, v.MaxLen)
+	})
+}
+
+type pronounceableJSON struct {
+	Type      string `json:"type"`
+	Syllables int    `json:"syllables"`
+	Digits    int    `json:"digits,omitempty"`
+}
+
+func (p pronounceable) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pronounceableJSON{Type: "Pronounceable", Syllables: p.syllables, Digits: p.digits})
+}
+
+func unmarshalPronounceable(data []byte) (Part, error) {
+	var v pronounceableJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return Pronounceable(v.Syllables, PronounceableDigits(v.Digits))
+	})
+}
+
+func (p randomHex) MarshalJSON() ([]byte, error) {
+	return json.Marshal(randomJSON{Type: "RandomHex", Bytes: p.n})
+}
+
+func unmarshalRandomHex(data []byte) (Part, error) {
+	var v randomJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return RandomHex(v.Bytes)
+	})
+}
+
+func (p randomEncoded) MarshalJSON() ([]byte, error) {
+	if p.name == "RandomBase32" && !p.std {
+		return nil, errors.New("pattern: RandomBase32 with a custom encoding can not be marshalled")
+	}
+	return json.Marshal(randomJSON{Type: p.name, Bytes: p.n})
+}
+
+func unmarshalRandomBase64URL(data []byte) (Part, error) {
+	var v randomJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return RandomBase64URL(v.Bytes)
+	})
+}
+
+func unmarshalRandomBase32(data []byte) (Part, error) {
+	var v randomJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return RandomBase32(v.Bytes, nil)
+	})
+}
+
+type crockfordJSON struct {
+	Type  string `json:"type"`
+	Size  int    `json:"size"`
+	Check bool   `json:"check"`
+}
+
+func (p crockford) MarshalJSON() ([]byte, error) {
+	return json.Marshal(crockfordJSON{Type: "RandomCrockford", Size: p.n, Check: true})
+}
+
+func unmarshalRandomCrockford(data []byte) (Part, error) {
+	var v crockfordJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return RandomCrockford(v.Size, v.Check)
+	})
+}
+
+type timestampJSON struct {
+	Type   string `json:"type"`
+	Layout string `json:"layout"`
+	UTC    bool   `json:"utc,omitempty"`
+}
+
+func (p timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(timestampJSON{Type: "Timestamp", Layout: p.layout, UTC: p.utc})
+}
+
+func unmarshalTimestamp(data []byte) (Part, error) {
+	var v timestampJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	if v.UTC {
+		return TimestampUTC(v.Layout), nil
+	}
+	return Timestamp(v.Layout), nil
+}
+
+type epochJSON struct {
+	Type string `json:"type"`
+	Unit string `json:"unit"`
+	Base int    `json:"base"`
+}
+
+func (p epoch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(epochJSON{Type: "EpochBase", Unit: time.Duration(p.unit).String(), Base: len(p.digits)})
+}
+
+func unmarshalEpochBase(data []byte) (Part, error) {
+	var v epochJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	unit, err := time.ParseDuration(v.Unit)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return EpochBase(unit, v.Base)
+	})
+}
+
+type throttleJSON struct {
+	Type string          `json:"type"`
+	Rate float64         `json:"rate"`
+	Part json.RawMessage `json:"part"`
+}
+
+func (p throttle) MarshalJSON() ([]byte, error) {
+	data, err := marshalPart(p.part)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(throttleJSON{Type: "Throttle", Rate: float64(time.Second) / float64(p.interval), Part: data})
+}
+
+func unmarshalThrottle(data []byte) (Part, error) {
+	var v throttleJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	part, err := unmarshalPart(v.Part)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return Throttle(v.Rate, part)
+	})
+}
+
+type refJSON struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+func (p ref) MarshalJSON() ([]byte, error) {
+	return json.Marshal(refJSON{Type: "Ref", Name: string(p)})
+}
+
+func unmarshalRef(data []byte) (Part, error) {
+	var v refJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+	return Ref(v.Name), nil
+}
+
+func (p placeholder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(refJSON{Type: "Placeholder", Name: string(p)})
+}
+
+func unmarshalPlaceholder(data []byte) (Part, error) {
+	var v refJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return Placeholder(v.Name)
+	})
+}
+
+// wrapperJSON is the JSON of Parts that only wrap a single Part.
+type wrapperJSON struct {
+	Type string          `json:"type"`
+	Part json.RawMessage `json:"part"`
+}
+
+func (p caseMap) MarshalJSON() ([]byte, error) {
+	data, err := marshalPart(p.part)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(wrapperJSON{Type: p.mode.String(), Part: data})
+}
+
+func (p shuffleChars) MarshalJSON() ([]byte, error) {
+	data, err := marshalPart(p.part)
+	if err != nil {
+		return nil, err
+	}
+
+	name := "ShuffleBytes"
+	if p.runes {
+		name = "ShuffleRunes"
+	}
+	return json.Marshal(wrapperJSON{Type: name, Part: data})
+}
+
+func (p encoded) MarshalJSON() ([]byte, error) {
+	if !p.std {
+		return nil, errors.New("pattern: " + p.name + " with a custom encoding can not be marshalled")
+	}
+
+	data, err := marshalPart(p.part)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(wrapperJSON{Type: p.name, Part: data})
+}
+
+// unmarshalWrapper returns an UnmarshalFunc for the wrapperJSON of Parts created by f.
+func unmarshalWrapper(f func(Part) Part) UnmarshalFunc {
+	return func(data []byte) (Part, error) {
+		var v wrapperJSON
+		if err := decode(data, &v); err != nil {
+			return nil, err
+		}
+
+		part, err := unmarshalPart(v.Part)
+		if err != nil {
+			return nil, err
+		}
+		return f(part), nil
+	}
+}
+
+type padJSON struct {
+	Type  string          `json:"type"`
+	Width int             `json:"width"`
+	Fill  string          `json:"fill,omitempty"`
+	Bytes []byte          `json:"bytes,omitempty"`
+	Part  json.RawMessage `json:"part"`
+}
+
+func (p pad) MarshalJSON() ([]byte, error) {
+	data, err := marshalPart(p.part)
+	if err != nil {
+		return nil, err
+	}
+
+	v := padJSON{Type: "PadRight", Width: p.width, Part: data}
+	if p.left {
+		v.Type = "PadLeft"
+	}
+	v.Fill, v.Bytes = splitBytes([]byte{p.fill})
+	return json.Marshal(v)
+}
+
+func unmarshalPad(data []byte) (Part, error) {
+	var v padJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	fill := joinBytes(v.Fill, v.Bytes)
+	if len(fill) != 1 {
+		return nil, fmt.Errorf("fill must be a single byte, got %q", fill)
+	}
+
+	part, err := unmarshalPart(v.Part)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		if v.Type == "PadLeft" {
+			return PadLeft(part, v.Width, fill[0])
+		}
+		return PadRight(part, v.Width, fill[0])
+	})
+}
+
+type exactLenJSON struct {
+	Type     string          `json:"type"`
+	N        int             `json:"n"`
+	Retries  int             `json:"retries"`
+	Truncate bool            `json:"truncate,omitempty"`
+	Pad      bool            `json:"pad,omitempty"`
+	Fill     string          `json:"fill,omitempty"`
+	Bytes    []byte          `json:"bytes,omitempty"`
+	Part     json.RawMessage `json:"part"`
+}
+
+func (p exactLen) MarshalJSON() ([]byte, error) {
+	data, err := marshalPart(p.part)
+	if err != nil {
+		return nil, err
+	}
+
+	v := exactLenJSON{Type: "ExactLen", N: p.n, Retries: p.retries, Truncate: p.truncate, Pad: p.pad, Part: data}
+	if p.pad {
+		v.Fill, v.Bytes = splitBytes([]byte{p.fill})
+	}
+	return json.Marshal(v)
+}
+
+func unmarshalExactLen(data []byte) (Part, error) {
+	var v exactLenJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	part, err := unmarshalPart(v.Part)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []ExactLenOption
+	if v.Truncate {
+		opts = append(opts, ExactLenTruncate())
+	}
+	if v.Pad {
+		fill := joinBytes(v.Fill, v.Bytes)
+		if len(fill) != 1 {
+			return nil, fmt.Errorf("fill must be a single byte, got %q", fill)
+		}
+		opts = append(opts, ExactLenPad(fill[0]))
+	}
+
+	return construct(func() Part {
+		return ExactLen(v.N, part, append(opts, ExactLenRetries(v.Retries))...)
+	})
+}
+
+type maxTotalLenJSON struct {
+	Type  string            `json:"type"`
+	N     int               `json:"n"`
+	Parts []json.RawMessage `json:"parts"`
+}
+
+func (p maxTotalLen) MarshalJSON() ([]byte, error) {
+	data, err := marshalParts(p.parts)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(maxTotalLenJSON{Type: "MaxTotalLen", N: p.n, Parts: data})
+}
+
+func unmarshalMaxTotalLen(data []byte) (Part, error) {
+	var v maxTotalLenJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	parts, err := unmarshalParts(v.Parts)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return New(parts...).WithMaxTotalLen(v.N).parts[0]
+	})
+}
+
+type maxOutputLenJSON struct {
+	Type     string            `json:"type"`
+	N        int               `json:"n"`
+	Truncate bool              `json:"truncate,omitempty"`
+	Parts    []json.RawMessage `json:"parts"`
+}
+
+func (p maxOutputLen) MarshalJSON() ([]byte, error) {
+	data, err := marshalParts(p.parts)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(maxOutputLenJSON{Type: "MaxOutputLen", N: p.n, Truncate: p.truncate, Parts: data})
+}
+
+func unmarshalMaxOutputLen(data []byte) (Part, error) {
+	var v maxOutputLenJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	parts, err := unmarshalParts(v.Parts)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return New(parts...).WithMaxOutputLen(v.N, v.Truncate).parts[0]
+	})
+}
+
+func (p cycle) MarshalJSON() ([]byte, error) {
+	return marshalContainer("Cycle", p.parts)
+}
+
+func unmarshalCycle(data []byte) (Part, error) {
+	parts, err := unmarshalContainer(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return Cycle(parts...)
+	})
+}
+
+type chooseJSON struct {
+	Type    string            `json:"type"`
+	Weights []float64         `json:"weights"`
+	Parts   []json.RawMessage `json:"parts"`
+}
+
+func (p choose) MarshalJSON() ([]byte, error) {
+	data, err := marshalParts(p.parts)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(chooseJSON{Type: "Choose", Weights: p.weights, Parts: data})
+}
+
+func unmarshalChoose(data []byte) (Part, error) {
+	var v chooseJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	if len(v.Weights) != len(v.Parts) {
+		return nil, fmt.Errorf("got %d weights for %d parts", len(v.Weights), len(v.Parts))
+	}
+
+	parts, err := unmarshalParts(v.Parts)
+	if err != nil {
+		return nil, err
+	}
+
+	choices := make([]WeightedChoice, len(parts))
+	for i, part := range parts {
+		choices[i] = WeightedChoice{Weight: v.Weights[i], Part: part}
+	}
+	return construct(func() Part {
+		return Choose(choices...)
+	})
+}
+
+func (p benfordDigit) MarshalJSON() ([]byte, error) {
+	return json.Marshal(typeJSON{Type: "BenfordDigit"})
+}
+
+func unmarshalBenfordDigit(data []byte) (Part, error) {
+	var v typeJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+	return BenfordDigit(), nil
+}
+
+type benfordAmountJSON struct {
+	Type     string  `json:"type"`
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Decimals int     `json:"decimals"`
+}
+
+func (p benfordAmount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(benfordAmountJSON{Type: "BenfordAmount", Min: p.min, Max: p.max, Decimals: p.decimals})
+}
+
+func unmarshalBenfordAmount(data []byte) (Part, error) {
+	var v benfordAmountJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return BenfordAmount(v.Min, v.Max, v.Decimals)
+	})
+}
+
+type repeatNormalJSON struct {
+	Type   string            `json:"type"`
+	Mean   float64           `json:"mean"`
+	Stddev float64           `json:"stddev"`
+	Min    uint32            `json:"min"`
+	Max    uint32            `json:"max"`
+	Parts  []json.RawMessage `json:"parts"`
+}
+
+func (p repeatNormal) MarshalJSON() ([]byte, error) {
+	data, err := marshalParts(p.parts)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(repeatNormalJSON{Type: "RepeatNormal", Mean: p.mean, Stddev: p.stddev, Min: p.min, Max: p.max, Parts: data})
+}
+
+func unmarshalRepeatNormal(data []byte) (Part, error) {
+	var v repeatNormalJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	parts, err := unmarshalParts(v.Parts)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return RepeatNormal(v.Mean, v.Stddev, v.Min, v.Max, parts...)
+	})
+}
+
+type zipfJSON struct {
+	Type  string            `json:"type"`
+	S     float64           `json:"s"`
+	V     float64           `json:"v"`
+	Parts []json.RawMessage `json:"parts"`
+}
+
+func (p zipf) MarshalJSON() ([]byte, error) {
+	data, err := marshalParts(p.parts)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(zipfJSON{Type: "OneOfZipf", S: p.s, V: p.v, Parts: data})
+}
+
+func unmarshalZipf(data []byte) (Part, error) {
+	var v zipfJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	parts, err := unmarshalParts(v.Parts)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return OneOfZipf(v.S, v.V, parts...)
+	})
+}
+
+func (p noConsecutive) MarshalJSON() ([]byte, error) {
+	data, err := marshalPart(p.part)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(wrapperJSON{Type: "NoConsecutive", Part: data})
+}
+
+type repeatDistinctJSON struct {
+	Type string          `json:"type"`
+	Min  uint32          `json:"min"`
+	Max  uint32          `json:"max"`
+	Part json.RawMessage `json:"part"`
+}
+
+func (p repeatDistinct) MarshalJSON() ([]byte, error) {
+	data, err := marshalPart(p.part)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(repeatDistinctJSON{Type: "RepeatDistinct", Min: p.min, Max: p.min + p.maxr - 1, Part: data})
+}
+
+func unmarshalRepeatDistinct(data []byte) (Part, error) {
+	var v repeatDistinctJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	part, err := unmarshalPart(v.Part)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return RepeatDistinct(v.Min, v.Max, part)
+	})
+}
+
+type defineJSON struct {
+	Type     string          `json:"type"`
+	Name     string          `json:"name"`
+	MaxDepth int             `json:"depth"`
+	Part     json.RawMessage `json:"part"`
+}
+
+func (p define) MarshalJSON() ([]byte, error) {
+	data, err := marshalPart(p.part)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(defineJSON{Type: "Define", Name: p.name, MaxDepth: p.maxDepth, Part: data})
+}
+
+func unmarshalDefine(data []byte) (Part, error) {
+	var v defineJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	part, err := unmarshalPart(v.Part)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return Define(v.Name, part, DefineMaxDepth(v.MaxDepth))
+	})
+}
+
+func (p ruleRef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(refJSON{Type: "RuleRef", Name: string(p)})
+}
+
+func unmarshalRuleRef(data []byte) (Part, error) {
+	var v refJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return RuleRef(v.Name)
+	})
+}
+
+type balancedJSON struct {
+	Type     string          `json:"type"`
+	Open     string          `json:"open"`
+	Close    string          `json:"close"`
+	MaxDepth int             `json:"depth"`
+	Part     json.RawMessage `json:"part"`
+}
+
+func (p balanced) MarshalJSON() ([]byte, error) {
+	data, err := marshalPart(p.inner)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(balancedJSON{Type: "Balanced", Open: p.open, Close: p.close, MaxDepth: p.maxDepth, Part: data})
+}
+
+func unmarshalBalanced(data []byte) (Part, error) {
+	var v balancedJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	part, err := unmarshalPart(v.Part)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return Balanced(v.Open, v.Close, part, v.MaxDepth)
+	})
+}
+
+func (p mirror) MarshalJSON() ([]byte, error) {
+	if p.reversed {
+		return json.Marshal(typeJSON{Type: "MirrorReversed"})
+	}
+	return json.Marshal(typeJSON{Type: "Mirror"})
+}
+
+func unmarshalMirror(data []byte) (Part, error) {
+	var v typeJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	if v.Type == "MirrorReversed" {
+		return MirrorReversed(), nil
+	}
+	return Mirror(), nil
+}
+
+type avoidJSON struct {
+	Type      string          `json:"type"`
+	Blocklist []string        `json:"blocklist"`
+	Retries   int             `json:"retries"`
+	Part      json.RawMessage `json:"part"`
+}
+
+func (p avoid) MarshalJSON() ([]byte, error) {
+	data, err := marshalPart(p.part)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(avoidJSON{Type: "Avoid", Blocklist: p.blocklist, Retries: p.maxRetries, Part: data})
+}
+
+func unmarshalAvoid(data []byte) (Part, error) {
+	var v avoidJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	part, err := unmarshalPart(v.Part)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct(func() Part {
+		return Avoid(part, v.Blocklist, v.Retries)
+	})
+}
+
+type constrainJSON struct {
+	Type    string          `json:"type"`
+	Must    *string         `json:"must,omitempty"`
+	MustNot *string         `json:"not,omitempty"`
+	Retries int             `json:"retries"`
+	Part    json.RawMessage `json:"part"`
+}
+
+func (p constrain) MarshalJSON() ([]byte, error) {
+	data, err := marshalPart(p.part)
+	if err != nil {
+		return nil, err
+	}
+
+	v := constrainJSON{Type: "Constrain", Retries: p.maxRetries, Part: data}
+	if p.must != nil {
+		s := p.must.String()
+		v.Must = &s
+	}
+	if p.mustNot != nil {
+		s := p.mustNot.String()
+		v.MustNot = &s
+	}
+	return json.Marshal(v)
+}
+
+func unmarshalConstrain(data []byte) (Part, error) {
+	var v constrainJSON
+	if err := decode(data, &v); err != nil {
+		return nil, err
+	}
+
+	part, err := unmarshalPart(v.Part)
+	if err != nil {
+		return nil, err
+	}
+
+	var must, mustNot *regexp.Regexp
+	if v.Must != nil {
+		if must, err = regexp.Compile(*v.Must); err != nil {
+			return nil, err
+		}
+	}
+	if v.MustNot != nil {
+		if mustNot, err = regexp.Compile(*v.MustNot); err != nil {
+			return nil, err
+		}
+	}
+
+	return construct(func() Part {
+		return Constrain(part, must, mustNot, v.Retries)
+	})
+}
@@ -0,0 +1,227 @@
+package pattern
+
+import (
+	"encoding/json"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSON(t *testing.T) {
+	gen := New(
+		Literal("id-"),
+		Repeat(2, 2, OneOfByte([]byte("ab"))),
+		Repeat(0, 1, Literal("x"), Literal("y")),
+		Potentially(0.25, Literal("\xff")),
+		OneOf(
+			Repeat(1, 3, OneOfRune([]rune("äöü"))),
+			OneOfString([]string{"foo", "bar"}),
+		),
+		Shuffle(Literal("a"), Literal("b")),
+		SampleK(1, Literal("a"), Literal("b")),
+		ShuffleBytes(Literal("ab")),
+		ShuffleRunes(Literal("äb")),
+		Pronounceable(3, PronounceableDigits(2)),
+		Markov([]string{"anna", "hanna"}, 2, 2, 8),
+		Choose(WeightedChoice{Weight: 3, Part: Literal("a")}, WeightedChoice{Weight: 0.5}),
+		OneOfZipf(1.5, 2, Literal("a"), Literal("b"), Literal("c")),
+		BenfordDigit(),
+		BenfordAmount(1, 1000, 2),
+		RepeatNormal(3, 1.5, 1, 6, Literal("a")),
+		Group(Literal("ab"), Mirror(), MirrorReversed()),
+		Constrain(Repeat(1, 3, OneOfByte([]byte("ab"))), regexp.MustCompile("a"), nil, 100),
+		Avoid(Repeat(1, 3, OneOfByte([]byte("ab"))), []string{"aa", "bb"}, 100),
+		Balanced("(", ")", OneOfByte([]byte("ab")), 3),
+		Define("list", Group(Literal("["), Potentially(0.5, RuleRef("list")), Literal("]")), DefineMaxDepth(3)),
+		AtLeastOneOf(3, 4, OneOfByte([]byte("ab")), OneOfString([]string{"1", "22"})),
+		Sequence(10, 99, 3, SequenceStep(2), SequenceDescending(), SequenceSkip(50)),
+		SequenceBase(0, 1000, 4, []byte("01")),
+		PermutedSequence(0, 999, 3, 42),
+		NanoID(10, nil),
+		NanoID(5, []byte("abc")),
+		Title(Lower(OneOfString([]string{"foo bar", "BAZ"}))),
+		PadLeft(PadRight(OneOfString([]string{"a", "bb"}), 3, '.'), 5, 0xff),
+		ExactLen(2, OneOfString([]string{"a", "bb", "ccc"}), ExactLenTruncate(), ExactLenPad('.'), ExactLenRetries(3)),
+		Cycle(Literal("a"), OneOfByte([]byte("xy"))),
+		NoConsecutive(OneOfByte([]byte("xyz"))),
+		RepeatDistinct(1, 3, OneOfByte([]byte("xyz"))),
+		HexEncode(Base64Encode(Base32Encode(OneOfByte([]byte{0, 1, 2}), nil), nil)),
+		MustCompile(`[\x{100}-\x{3ff}]`),
+		TimestampUTC(time.RFC3339),
+		EpochBase(time.Millisecond, 36),
+		Throttle(1e9, Literal("t")),
+	)
+
+	data, err := json.Marshal(gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := New()
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("got %s, want %s", again, data)
+	}
+
+	// Compare the generated values, except the time-dependent ones.
+	gen.parts = gen.parts[:len(gen.parts)-3]
+	got.parts = got.parts[:len(got.parts)-3]
+	for i := 0; i < 100; i++ {
+		seed := rand.Int63()
+		want := StringFrom(gen, rand.New(rand.NewSource(seed)))
+		if v := StringFrom(got, rand.New(rand.NewSource(seed))); v != want {
+			t.Fatalf("got %q, want %q", v, want)
+		}
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	data, err := json.Marshal(New(Literal("a"), Repeat(1, 3, OneOfByte([]byte("xy")))))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"type":"New","parts":[{"type":"Literal","value":"a"},{"type":"Repeat","min":1,"max":3,"parts":[{"type":"OneOfByte","alphabet":"xy"}]}]}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
+
+func TestJSONPart(t *testing.T) {
+	p, err := UnmarshalPart([]byte(`{"type":"OneOf","parts":[{"type":"Literal","value":"a"},{"type":"Literal","value":"b"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := string(p.Append(nil)); v != "a" && v != "b" {
+		t.Errorf("got %q, want a or b", v)
+	}
+
+	// A Part that is not a generator is wrapped into one.
+	gen := New()
+	if err := json.Unmarshal([]byte(`{"type":"Literal","value":"a"}`), gen); err != nil {
+		t.Fatal(err)
+	}
+	if v := gen.String(); v != "a" {
+		t.Errorf("got %q, want a", v)
+	}
+}
+
+func TestJSONLargeRepeat(t *testing.T) {
+	data := `{"type":"Repeat","min":4294967295,"max":4294967295,"parts":[{"type":"Literal","value":"a"}]}`
+	p, err := UnmarshalPart([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The repetitions must not be folded into a Group of copies.
+	if v, ok := p.(repeat); !ok || v.min != 4294967295 || v.maxr != 1 {
+		t.Errorf("got %#v, want a repeat of 4294967295", p)
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != data {
+		t.Errorf("got %s, want %s", b, data)
+	}
+}
+
+func TestJSONMarshalError(t *testing.T) {
+	tests := []struct {
+		name string
+		part Part
+		want string
+	}{
+		{"FPE", FPE(make([]byte, 16), "0123456789", Literal("1")), "FPE"},
+		{"SkipFunc", Sequence(1, 9, 1, SequenceSkipFunc(func(uint64) bool { return false })), "SequenceSkipFunc"},
+		{"Custom", custom{Literal("a")}, "pattern.custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := json.Marshal(New(Literal("a"), tt.part))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONUnmarshalError(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{`{"type":"Foo"}`, `pattern: unknown part type "Foo"`},
+		{`{"type":"New","parts":[{"type":"Repeat","min":3,"max":2,"parts":[]}]}`, "pattern: New: Repeat: max must be >= min"},
+		{`{"type":"Literal","vaule":"a"}`, `pattern: Literal: json: unknown field "vaule"`},
+		{`{"type":"Class","ranges":[[5,1]]}`, "pattern: Class: invalid range [5, 1]"},
+		{`{"type":"EpochBase","unit":"1x","base":10}`, `pattern: EpochBase: time: unknown unit "x" in duration "1x"`},
+	}
+
+	for _, tt := range tests {
+		_, err := UnmarshalPart([]byte(tt.data))
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("%s: got %v, want %s", tt.data, err, tt.want)
+		}
+	}
+}
+
+// upper is a custom Part that converts the output of its Part to upper case.
+type upper struct {
+	part Part
+}
+
+func (p upper) Append(b []byte) []byte {
+	return append(b, strings.ToUpper(string(p.part.Append(nil)))...)
+}
+
+func (p upper) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		Part Part   `json:"part"`
+	}{"test.Upper", p.part})
+}
+
+func TestRegisterPart(t *testing.T) {
+	RegisterPart("test.Upper", func(data []byte) (Part, error) {
+		var v struct {
+			Part json.RawMessage `json:"part"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		p, err := UnmarshalPart(v.Part)
+		return upper{p}, err
+	})
+
+	data, err := json.Marshal(New(Literal("a"), upper{Literal("b")}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gen := New()
+	if err := json.Unmarshal(data, gen); err != nil {
+		t.Fatal(err)
+	}
+	if v := gen.String(); v != "aB" {
+		t.Errorf("got %q, want aB", v)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("registering a name twice did not panic")
+		}
+	}()
+	RegisterPart("Literal", unmarshalLiteral)
+}
@@ -0,0 +1,252 @@
+// Package jsongen composes patterns into JSON documents.
+//
+// All functions of this package return Parts that output valid JSON values,
+// so documents can be generated with pattern.New and combined with the Parts of package pattern, e.g. pattern.OneOf.
+// Parts that output JSON values are called values below.
+//
+// Parts outside package pattern can't use the state of a generation, so Object, Array, String, Int and Float
+// draw their random numbers from the default source and call Append of their children directly.
+// Inside of them, the source of WithSeed, WithRand or StringFor, the limit of WithMaxOutputLen
+// and the state of Parts like Mirror or NoConsecutive do not apply.
+package jsongen
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"math"
+	"math/bits"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/sollniss/pattern"
+	"github.com/sollniss/pattern/internal"
+)
+
+// String returns a value that outputs the output of p as a JSON string.
+func String(p ...pattern.Part) pattern.Part {
+	return str{
+		part: pattern.Group(p...),
+	}
+}
+
+type str struct {
+	part pattern.Part
+}
+
+func (p str) Append(b []byte) []byte {
+	b = append(b, '"')
+	start := len(b)
+	b = p.part.Append(b)
+
+	// Only copy the output if it needs escaping.
+	for i := start; i < len(b); i++ {
+		if c := b[i]; c < 0x20 || c == '"' || c == '\\' || c >= utf8.RuneSelf {
+			raw := append([]byte(nil), b[i:]...)
+			return append(internal.AppendJSONEscaped(b[:i], raw), '"')
+		}
+	}
+	return append(b, '"')
+}
+
+// Number returns a value that outputs the output of p unquoted.
+// The output of p must be a valid JSON number.
+func Number(p ...pattern.Part) pattern.Part {
+	return pattern.Group(p...)
+}
+
+// Int returns a value that outputs a random integer in [min, max].
+//
+// Panics if max < min.
+func Int(min int64, max int64) pattern.Part {
+	if max < min {
+		panic("max must be >= min")
+	}
+
+	return integer{
+		min:  min,
+		span: uint64(max-min) + 1,
+	}
+}
+
+type integer struct {
+	min int64
+	// span is the size of the range or 0 if it covers all int64.
+	span uint64
+}
+
+func (p integer) Append(b []byte) []byte {
+	r := internal.Fastrand()
+	if p.span != 0 {
+		r, _ = bits.Mul64(p.span, r)
+	}
+	return strconv.AppendInt(b, p.min+int64(r), 10)
+}
+
+// Float returns a value that outputs a random floating point number in [min, max).
+//
+// Panics if max < min or either of them is not finite.
+func Float(min float64, max float64) pattern.Part {
+	if math.IsNaN(min) || math.IsInf(min, 0) || math.IsNaN(max) || math.IsInf(max, 0) {
+		panic("min and max must be finite")
+	}
+
+	if max < min {
+		panic("max must be >= min")
+	}
+
+	return float{
+		min:  min,
+		span: max - min,
+	}
+}
+
+type float struct {
+	min  float64
+	span float64
+}
+
+func (p float) Append(b []byte) []byte {
+	return strconv.AppendFloat(b, p.min+internal.RandFloat64()*p.span, 'g', -1, 64)
+}
+
+// Bool returns a value that outputs true or false randomly.
+func Bool() pattern.Part {
+	return pattern.OneOf(pattern.Literal("true"), pattern.Literal("false"))
+}
+
+// Null returns a value that always outputs null.
+func Null() pattern.Part {
+	return pattern.Literal("null")
+}
+
+// Const returns a value that always outputs v encoded as JSON.
+//
+// Panics if v can not be encoded.
+func Const(v any) pattern.Part {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic("invalid value: " + err.Error())
+	}
+	return pattern.Literal(string(b))
+}
+
+// Member is a name/value pair of an Object.
+type Member struct {
+	name   []byte
+	value  pattern.Part
+	chance float64
+}
+
+// Field returns a Member that is always part of the Object.
+func Field(name string, v pattern.Part) Member {
+	return OptionalField(1, name, v)
+}
+
+// OptionalField returns a Member that is part of the Object with probability c.
+func OptionalField(c float64, name string, v pattern.Part) Member {
+	if c < 0 {
+		panic("chance must be > 0")
+	}
+
+	return Member{
+		name:   append(internal.AppendJSONEscaped([]byte{'"'}, []byte(name)), '"', ':'),
+		value:  v,
+		chance: c,
+	}
+}
+
+// Object returns a value that outputs a JSON object with members.
+func Object(members ...Member) pattern.Part {
+	return object(members)
+}
+
+type object []Member
+
+func (p object) Append(b []byte) []byte {
+	b = append(b, '{')
+	first := true
+	for _, m := range p {
+		if m.chance < 1 && internal.RandFloat64() >= m.chance {
+			continue
+		}
+
+		if !first {
+			b = append(b, ',')
+		}
+		first = false
+
+		b = append(b, m.name...)
+		b = m.value.Append(b)
+	}
+	return append(b, '}')
+}
+
+// Array returns a value that outputs a JSON array with between min and max elements generated by v.
+//
+// Panics if max < min.
+func Array(min uint32, max uint32, v pattern.Part) pattern.Part {
+	if max < min {
+		panic("max must be >= min")
+	}
+
+	return array{
+		value: v,
+		min:   min,
+		maxr:  max - min + 1,
+	}
+}
+
+type array struct {
+	value pattern.Part
+	min   uint32
+	// maxr is the value needed to generate [min, max] with the RNG.
+	maxr uint32
+}
+
+func (p array) Append(b []byte) []byte {
+	n := internal.RandN(p.maxr) + p.min
+	b = append(b, '[')
+	for i := uint32(0); i < n; i++ {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = p.value.Append(b)
+	}
+	return append(b, ']')
+}
+
+// Write writes n documents generated by doc to w, each followed by a newline (JSON Lines).
+func Write(w io.Writer, n int, doc pattern.Part) error {
+	bw := bufio.NewWriter(w)
+	var b []byte
+	for i := 0; i < n; i++ {
+		b = doc.Append(b[:0])
+		b = append(b, '\n')
+		if _, err := bw.Write(b); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteArray writes a single JSON array with n documents generated by doc to w.
+func WriteArray(w io.Writer, n int, doc pattern.Part) error {
+	bw := bufio.NewWriter(w)
+	b := []byte{'['}
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = doc.Append(b)
+		if _, err := bw.Write(b); err != nil {
+			return err
+		}
+		b = b[:0]
+	}
+	b = append(b, ']')
+	if _, err := bw.Write(b); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
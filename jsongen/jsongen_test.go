@@ -0,0 +1,160 @@
+package jsongen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/sollniss/pattern"
+)
+
+var doc = Object(
+	Field("id", String(pattern.Literal("usr_"), pattern.Repeat(8, 8, pattern.OneOfByte([]byte("0123456789abcdef"))))),
+	Field("name", String(pattern.OneOfString([]string{"Alice", "Bob", "\"quoted\"", "tab\there", "ユーザー", "\x00"}))),
+	Field("age", Int(18, 99)),
+	Field("score", Float(0, 1)),
+	Field("active", Bool()),
+	Field("manager", pattern.OneOf(Null(), Const(map[string]int{"id": 1}))),
+	OptionalField(0.5, "nickname", String(pattern.Literal("nick"))),
+	Field("tags", Array(0, 3, String(pattern.Repeat(3, 5, pattern.OneOfByte([]byte("abc")))))),
+	Field("amount", Number(pattern.Repeat(1, 3, pattern.OneOfByte([]byte("123456789"))), pattern.Literal(".5"))),
+)
+
+type testDoc struct {
+	ID       string         `json:"id"`
+	Name     string         `json:"name"`
+	Age      int64          `json:"age"`
+	Score    float64        `json:"score"`
+	Active   bool           `json:"active"`
+	Manager  map[string]int `json:"manager"`
+	Nickname *string        `json:"nickname"`
+	Tags     []string       `json:"tags"`
+	Amount   float64        `json:"amount"`
+}
+
+func TestDocument(t *testing.T) {
+	gen := pattern.New(doc)
+
+	names := make(map[string]bool)
+	nicknames := 0
+	for i := 0; i < 1000; i++ {
+		v := gen.String()
+
+		var d testDoc
+		dec := json.NewDecoder(bytes.NewReader([]byte(v)))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&d); err != nil {
+			t.Fatalf("generated invalid document %s: %v", strconv.Quote(v), err)
+		}
+
+		if len(d.ID) != 12 {
+			t.Errorf("generated invalid id %s", strconv.Quote(d.ID))
+		}
+		if d.Age < 18 || d.Age > 99 {
+			t.Errorf("generated invalid age: want [18,99], got %d", d.Age)
+		}
+		if d.Score < 0 || d.Score >= 1 {
+			t.Errorf("generated invalid score: want [0,1), got %f", d.Score)
+		}
+		if len(d.Tags) > 3 {
+			t.Errorf("generated invalid number of tags: want [0,3], got %d", len(d.Tags))
+		}
+		if d.Nickname != nil {
+			nicknames++
+		}
+		names[d.Name] = true
+	}
+
+	for _, name := range []string{"Alice", "\"quoted\"", "tab\there", "ユーザー", "\x00"} {
+		if !names[name] {
+			t.Errorf("never generated name %s", strconv.Quote(name))
+		}
+	}
+
+	if nicknames == 0 || nicknames == 1000 {
+		t.Errorf("optional field was always or never generated")
+	}
+}
+
+func TestStringInvalidUTF8(t *testing.T) {
+	v := pattern.New(String(pattern.Literal("a\xffb"))).String()
+	if v != `"a�b"` {
+		t.Errorf("String returned invalid value: want %s, got %s", strconv.Quote(`"a�b"`), strconv.Quote(v))
+	}
+}
+
+func TestIntFullRange(t *testing.T) {
+	gen := pattern.New(Int(-1<<63, 1<<63-1))
+	for i := 0; i < 100; i++ {
+		if _, err := strconv.ParseInt(gen.String(), 10, 64); err != nil {
+			t.Errorf("Int returned invalid value: %v", err)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, 100, doc); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	lines := 0
+	s := bufio.NewScanner(&buf)
+	for s.Scan() {
+		if !json.Valid(s.Bytes()) {
+			t.Errorf("Write generated invalid document %s", strconv.Quote(s.Text()))
+		}
+		lines++
+	}
+
+	if lines != 100 {
+		t.Errorf("Write generated invalid number of documents: want 100, got %d", lines)
+	}
+}
+
+func TestWriteArray(t *testing.T) {
+	for _, n := range []int{0, 1, 100} {
+		var buf bytes.Buffer
+		if err := WriteArray(&buf, n, doc); err != nil {
+			t.Fatalf("WriteArray returned error: %v", err)
+		}
+
+		var docs []testDoc
+		if err := json.Unmarshal(buf.Bytes(), &docs); err != nil {
+			t.Fatalf("WriteArray generated invalid JSON: %v", err)
+		}
+
+		if len(docs) != n {
+			t.Errorf("WriteArray generated invalid number of documents: want %d, got %d", n, len(docs))
+		}
+	}
+}
+
+func TestPanic(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"Int with max < min", func() { Int(2, 1) }},
+		{"Float with max < min", func() { Float(2, 1) }},
+		{"Float with infinite max", func() { Float(0, math.Inf(1)) }},
+		{"Const with invalid value", func() { Const(func() {}) }},
+		{"OptionalField with negative chance", func() { OptionalField(-1, "a", Null()) }},
+		{"Array with max < min", func() { Array(2, 1, Null()) }},
+	}
+
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic", tt.name)
+				}
+			}()
+
+			tt.f()
+		}()
+	}
+}
@@ -0,0 +1,167 @@
+package pattern
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// markovRetries is the number of times Markov restarts a word that ends too early or runs too long.
+const markovRetries = 100
+
+// Markov returns a Part that outputs words of minLen to maxLen characters in the style of corpus,
+// e.g. plausible but fake names.
+// It trains a character-level Markov chain, where each character depends on the order characters before it.
+// A higher order resembles the corpus more closely, but also reproduces more of its words.
+//
+// Words that end before minLen characters or exceed maxLen characters are restarted.
+// The Part panics with an error wrapping ErrRetriesExhausted if no word of the right length is found within 100 retries.
+// Panics if corpus has no words, order is < 1, minLen is < 0, maxLen is 0 or maxLen < minLen.
+func Markov(corpus []string, order int, minLen int, maxLen int) Part {
+	if order < 1 {
+		panic("order must be >= 1")
+	}
+
+	if minLen < 0 {
+		panic("minLen must be >= 0")
+	}
+
+	if maxLen == 0 {
+		panic("maxLen must be > 0")
+	}
+
+	if maxLen < minLen {
+		panic("maxLen must be >= minLen")
+	}
+
+	p := markov{
+		corpus: corpus,
+		order:  order,
+		minLen: minLen,
+		maxLen: maxLen,
+		states: make(map[string]*markovState),
+	}
+	p.train()
+	if len(p.states) == 0 {
+		panic("corpus must contain words")
+	}
+	return p
+}
+
+type markov struct {
+	corpus []string
+	order  int
+	minLen int
+	maxLen int
+	// states maps the last order characters, padded with zeros at the start of a word, to their successors.
+	states map[string]*markovState
+}
+
+type markovState struct {
+	next []rune
+	// cum holds the cumulative number of occurrences of next.
+	cum []uint32
+	// end is the number of words that ended in the state.
+	end uint32
+}
+
+func (p markov) train() {
+	counts := make(map[string]map[rune]uint32)
+	ends := make(map[string]uint32)
+	for _, w := range p.corpus {
+		if w == "" {
+			continue
+		}
+
+		window := make([]rune, p.order)
+		for _, c := range w {
+			key := string(window)
+			if counts[key] == nil {
+				counts[key] = make(map[rune]uint32)
+			}
+			counts[key][c]++
+			window = append(window[1:], c)
+		}
+		ends[string(window)]++
+	}
+
+	for key, next := range counts {
+		s := &markovState{end: ends[key]}
+		for c := range next {
+			s.next = append(s.next, c)
+		}
+		// Sort for a deterministic order of the choices.
+		sort.Slice(s.next, func(i, j int) bool { return s.next[i] < s.next[j] })
+
+		total := uint32(0)
+		for _, c := range s.next {
+			total += next[c]
+			s.cum = append(s.cum, total)
+		}
+		p.states[key] = s
+	}
+
+	// States that only end words.
+	for key, n := range ends {
+		if p.states[key] == nil {
+			p.states[key] = &markovState{end: n}
+		}
+	}
+}
+
+func (p markov) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p markov) appendRun(r *run, b []byte) []byte {
+	start := len(b)
+	window := make([]rune, p.order)
+	for i := 0; ; i++ {
+		if out, ok := p.word(r, b[:start], window); ok {
+			return out
+		}
+
+		if i == markovRetries {
+			panic(fmt.Errorf("%w: no word of %d to %d characters after %d retries", ErrRetriesExhausted, p.minLen, p.maxLen, markovRetries))
+		}
+	}
+}
+
+// word appends a word to b or returns false if it ended before minLen characters or exceeded maxLen characters.
+func (p markov) word(r *run, b []byte, window []rune) ([]byte, bool) {
+	for i := range window {
+		window[i] = 0
+	}
+
+	for n := 0; ; n++ {
+		s := p.states[string(window)]
+		if s == nil {
+			return b, false
+		}
+
+		total := uint32(0)
+		if len(s.cum) > 0 {
+			total = s.cum[len(s.cum)-1]
+		}
+		choices := total
+		if n >= p.minLen {
+			choices += s.end
+		}
+		if choices == 0 {
+			return b, false
+		}
+
+		v := r.randN(choices)
+		if v >= total {
+			return b, true
+		}
+		if n == p.maxLen {
+			return b, false
+		}
+
+		c := s.next[sort.Search(len(s.cum), func(i int) bool { return v < s.cum[i] })]
+		b = utf8.AppendRune(b, c)
+		copy(window, window[1:])
+		window[len(window)-1] = c
+	}
+}
@@ -0,0 +1,62 @@
+package pattern
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMarkov(t *testing.T) {
+	corpus := []string{"anna", "hannah", "johanna", "marianne", "joanne", "jana", "hanne", "annika"}
+	gen := New(Markov(corpus, 2, 3, 8))
+
+	// Every pair of consecutive characters of an output occurs in the corpus.
+	joined := " " + strings.Join(corpus, " ") + " "
+	for i := 0; i < 500; i++ {
+		v := gen.String()
+		if n := utf8.RuneCountInString(v); n < 3 || n > 8 {
+			t.Fatalf("Markov returned word of invalid length: %s", strconv.Quote(v))
+		}
+
+		w := " " + v + " "
+		for j := 0; j+2 <= len(w); j++ {
+			if !strings.Contains(joined, w[j:j+2]) {
+				t.Errorf("Markov returned %s with %s, which is not in the corpus", strconv.Quote(v), strconv.Quote(w[j:j+2]))
+			}
+		}
+	}
+}
+
+func TestMarkovUnicode(t *testing.T) {
+	gen := New(Markov([]string{"äöü", "üöä"}, 1, 1, 10))
+	for i := 0; i < 100; i++ {
+		if v := gen.String(); !utf8.ValidString(v) || strings.Trim(v, "äöü") != "" {
+			t.Fatalf("Markov returned invalid value: %s", strconv.Quote(v))
+		}
+	}
+}
+
+func TestMarkovPanic(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"empty corpus", func() { Markov([]string{""}, 1, 1, 5) }},
+		{"order", func() { Markov([]string{"a"}, 0, 1, 5) }},
+		{"minLen", func() { Markov([]string{"a"}, 1, -1, 5) }},
+		{"maxLen", func() { Markov([]string{"a"}, 1, 5, 4) }},
+		{"retries", func() { _ = New(Markov([]string{"ab"}, 1, 3, 5)).String() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Markov did not panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package pattern
+
+// Match reports whether s is a possible output of p.
+// Ambiguous patterns, whose outputs can be generated by several choices, are matched in polynomial time in the length of s.
+// Match panics if p contains Parts whose choices can not be recovered from an output, like custom Parts or Timestamp.
+func Match(p Part, s string) bool {
+	if !canDerive(p) {
+		panic("pattern contains parts that can not be matched")
+	}
+
+	return deriveAll(p.(derivable), []byte(s)) != nil
+}
@@ -0,0 +1,47 @@
+package pattern
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	gen := New(
+		Literal("id-"),
+		Repeat(1, 3, OneOfByte([]byte("ab"))),
+		Potentially(0.5, Literal("-")),
+		Sequence(1, 99, 2),
+	)
+
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"id-a01", true},
+		{"id-bab-99", true},
+		{"id-01", false},
+		{"id-abab01", false},
+		{"id-a100", false},
+		{"id-a1", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		if got := Match(gen, test.s); got != test.want {
+			t.Errorf("Match returned invalid value for %s: want %v, got %v", strconv.Quote(test.s), test.want, got)
+		}
+	}
+}
+
+func TestMatchAmbiguous(t *testing.T) {
+	p := Repeat(1, 60, OneOfString([]string{"a", "aa"}))
+
+	// The number of ways to split the a's grows exponentially.
+	if Match(p, strings.Repeat("a", 32)+"b") {
+		t.Error("Match returned true for invalid value")
+	}
+	if !Match(p, strings.Repeat("a", 32)) {
+		t.Error("Match returned false for valid value")
+	}
+}
@@ -0,0 +1,168 @@
+package pattern
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxTotalLenRetries is the number of times a generator with WithMaxTotalLen regenerates outputs that exceed the budget.
+const maxTotalLenRetries = 100
+
+// WithMaxTotalLen makes the generator never output more than n bytes, e.g. to fit a database column.
+// Outputs that exceed n bytes are regenerated up to 100 times, so the budget should only cut off rare, long outputs.
+//
+// The generator panics with an error wrapping ErrRetriesExhausted if no output fits within the retries.
+// Panics if n is < 0. Applying the option panics if the shortest possible output is already longer than n bytes.
+func WithMaxTotalLen(n int) Option {
+	if n < 0 {
+		panic("n must be >= 0")
+	}
+
+	return func(g *gen) {
+		if min := minLen(*g); min > n {
+			panic(fmt.Sprintf("the shortest output has %d bytes, which exceeds the budget of %d bytes", min, n))
+		}
+
+		g.parts = []Part{maxTotalLen{parts: g.parts, n: n}}
+		g.hint = capacityHint(g.parts)
+		g.folded = append([]string(nil), g.folded...)
+	}
+}
+
+// WithMaxTotalLen returns a generator like the generator with the option WithMaxTotalLen(n).
+// The original generator is not changed, but both share their Parts.
+func (g gen) WithMaxTotalLen(n int) *gen {
+	WithMaxTotalLen(n)(&g)
+	return &g
+}
+
+type maxTotalLen struct {
+	parts []Part
+	n     int
+}
+
+func (p maxTotalLen) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p maxTotalLen) appendRun(r *run, b []byte) []byte {
+	defer r.restoreLimit(r.suspendLimit())
+	start := len(b)
+	for i := 0; ; i++ {
+		b = b[:start]
+		for _, part := range p.parts {
+			b = appendRun(r, part, b)
+		}
+		if len(b)-start <= p.n {
+			return b
+		}
+
+		if i == maxTotalLenRetries {
+			panic(fmt.Errorf("%w: output exceeds %d bytes after %d retries", ErrRetriesExhausted, p.n, maxTotalLenRetries))
+		}
+	}
+}
+
+func (p maxTotalLen) Children() []Part {
+	return p.parts
+}
+
+// ErrOutputTooLong is wrapped by the panics of generators with WithMaxOutputLen whose output exceeds the limit.
+var ErrOutputTooLong = errors.New("pattern: output too long")
+
+// WithMaxOutputLen makes the generator abort generating as soon as the output exceeds n bytes,
+// e.g. to protect against deeply nested Repeats from user configuration that would output megabytes.
+// Unlike WithMaxTotalLen, long outputs are not regenerated.
+// If truncate is true, the output is cut to n bytes, which may split multi-byte characters;
+// otherwise the generator panics with an error wrapping ErrOutputTooLong.
+//
+// The limit is checked after each Part, so a single Part still has to fit into memory.
+// Parts that rewrite or regenerate the output of their children, like HexEncode, Hash, ExactLen or Avoid,
+// are only checked once they finish, so the limit always applies to the final output.
+// Their children may output up to 64 KiB more than n bytes; beyond that no prefix of the final output is known,
+// so the generator panics with an error wrapping ErrOutputTooLong even if truncate is true.
+// Use StringE to get these errors returned instead.
+// Panics if n is < 0.
+func WithMaxOutputLen(n int, truncate bool) Option {
+	if n < 0 {
+		panic("n must be >= 0")
+	}
+
+	return func(g *gen) {
+		g.parts = []Part{maxOutputLen{parts: g.parts, n: n, truncate: truncate}}
+		g.hint = capacityHint(g.parts)
+		g.folded = append([]string(nil), g.folded...)
+	}
+}
+
+// WithMaxOutputLen returns a generator like the generator with the option WithMaxOutputLen(n, truncate).
+// The original generator is not changed, but both share their Parts.
+func (g gen) WithMaxOutputLen(n int, truncate bool) *gen {
+	WithMaxOutputLen(n, truncate)(&g)
+	return &g
+}
+
+type maxOutputLen struct {
+	parts    []Part
+	n        int
+	truncate bool
+}
+
+// maxOutputSlack is how many bytes the children of Parts that suspend the limit may output beyond the limit.
+const maxOutputSlack = 64 << 10
+
+// outputLimit is panicked by appendRun when the output exceeds the limit of the run.
+type outputLimit struct {
+	b []byte
+	// ceiling reports whether the output exceeded the ceiling instead of the limit.
+	ceiling bool
+}
+
+func (p maxOutputLen) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p maxOutputLen) appendRun(r *run, b []byte) (out []byte) {
+	if r == nil {
+		r = &run{start: len(b)}
+	}
+
+	start := len(b)
+	limit := start + p.n + 1
+	ceiling := limit + maxOutputSlack
+	outer, outerCeiling := r.limit, r.ceiling
+	if outer > 0 && outer < limit {
+		// An outer limit is stricter.
+		limit = outer
+	}
+	if outerCeiling > 0 && outerCeiling < ceiling {
+		ceiling = outerCeiling
+	}
+	r.limit, r.ceiling = limit, ceiling
+
+	defer func() {
+		r.limit, r.ceiling = outer, outerCeiling
+		if v := recover(); v != nil {
+			l, ok := v.(outputLimit)
+			if !ok || (l.ceiling && outerCeiling > 0 && len(l.b) >= outerCeiling) || (!l.ceiling && outer > 0 && len(l.b) >= outer) {
+				panic(v)
+			}
+			if l.ceiling {
+				panic(fmt.Errorf("%w: intermediate output exceeds %d bytes", ErrOutputTooLong, ceiling-start))
+			}
+			if !p.truncate {
+				panic(fmt.Errorf("%w: output exceeds %d bytes", ErrOutputTooLong, p.n))
+			}
+			out = l.b[:start+p.n]
+		}
+	}()
+
+	for _, part := range p.parts {
+		b = appendRun(r, part, b)
+	}
+	return b
+}
+
+func (p maxOutputLen) Children() []Part {
+	return p.parts
+}
@@ -0,0 +1,155 @@
+package pattern
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithMaxTotalLen(t *testing.T) {
+	base := New(Literal("id-"), Repeat(1, 20, OneOfByte([]byte("ab"))))
+	g := base.WithMaxTotalLen(8)
+
+	long := false
+	for i := 0; i < 1000; i++ {
+		if s := g.String(); len(s) > 8 || len(s) < 4 {
+			t.Fatalf("got %q with %d bytes, want 4 to 8", s, len(s))
+		}
+		if len(base.String()) > 8 {
+			long = true
+		}
+	}
+	if !long {
+		t.Errorf("WithMaxTotalLen changed the original generator")
+	}
+
+	n := 0
+	g.enumerate(nil, func(b []byte) bool {
+		if len(b) > 8 {
+			t.Fatalf("enumerated %q", b)
+		}
+		n++
+		return true
+	})
+	if want := 2 + 4 + 8 + 16 + 32; n != want {
+		t.Errorf("enumerated %d values, want %d", n, want)
+	}
+}
+
+func TestWithMaxTotalLenPanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("WithMaxTotalLen with a too small budget did not panic")
+			}
+		}()
+
+		New(Literal("id-"), Repeat(3, 5, RandomHex(1))).WithMaxTotalLen(8)
+	}()
+
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, ErrRetriesExhausted) {
+				t.Errorf("got %v, want ErrRetriesExhausted", err)
+			}
+		}()
+
+		// The length of a PartFunc is unknown, so the budget is only checked during generation.
+		_ = New(PartFunc(func(b []byte) []byte { return append(b, "0123456789"...) })).WithMaxTotalLen(5).String()
+	}()
+}
+
+func TestMinLen(t *testing.T) {
+	tests := []struct {
+		p    Part
+		want int
+	}{
+		{New(Literal("abc"), Repeat(2, 4, Literal("x"))), 5},
+		{OneOf(Literal("abc"), Literal("d")), 1},
+		{Potentially(0.5, Literal("abc")), 0},
+		{OneOfRune([]rune("äx€")), 1},
+		{PadLeft(Literal("x"), 4, ' '), 4},
+		{HexEncode(RandomBase64URL(3)), 8},
+		{PartFunc(func(b []byte) []byte { return append(b, 'x') }), 0},
+	}
+
+	for _, tt := range tests {
+		if got := minLen(tt.p); got != tt.want {
+			t.Errorf("minLen(%s) = %d, want %d", Describe(tt.p), got, tt.want)
+		}
+	}
+}
+
+func TestWithMaxOutputLen(t *testing.T) {
+	// Up to 10^6 bytes without the limit.
+	nested := Repeat(100, 100, Repeat(100, 100, Repeat(1, 100, Literal("a"))))
+
+	tr := New(Literal("id-"), nested).WithMaxOutputLen(10, true)
+	for _, v := range []string{tr.String(), string(tr.Append([]byte("prefix")))} {
+		if !strings.HasSuffix(v, "id-aaaaaaa") || len(strings.TrimPrefix(v, "prefix")) != 10 {
+			t.Errorf("WithMaxOutputLen returned invalid value %q", v)
+		}
+	}
+
+	if v := New(Literal("short")).WithMaxOutputLen(10, false).String(); v != "short" {
+		t.Errorf("WithMaxOutputLen returned invalid value %q", v)
+	}
+
+	func() {
+		defer func() {
+			err, ok := recover().(error)
+			if !ok || !errors.Is(err, ErrOutputTooLong) {
+				t.Errorf("WithMaxOutputLen did not panic with ErrOutputTooLong, got %v", err)
+			}
+		}()
+		_ = New(nested).WithMaxOutputLen(1000, false).String()
+	}()
+}
+
+func TestWithMaxOutputLenNested(t *testing.T) {
+	inner := New(Repeat(50, 50, Literal("b"))).WithMaxOutputLen(100, true)
+	outer := New(Literal("a"), inner, Literal("c")).WithMaxOutputLen(20, true)
+	if v := outer.String(); v != "a"+strings.Repeat("b", 19) {
+		t.Errorf("WithMaxOutputLen returned invalid value %q", v)
+	}
+
+	inner = New(Repeat(50, 50, Literal("b"))).WithMaxOutputLen(5, true)
+	outer = New(Literal("a"), inner, Literal("c")).WithMaxOutputLen(20, true)
+	if v := outer.String(); v != "abbbbbc" {
+		t.Errorf("WithMaxOutputLen returned invalid value %q", v)
+	}
+}
+
+func TestWithMaxOutputLenWrappers(t *testing.T) {
+	long := Repeat(200, 200, OneOfByte(AlphaLower))
+
+	if v := New(Hash(sha256.New, 8, long)).WithMaxOutputLen(100, false).String(); len(v) != 8 {
+		t.Errorf("WithMaxOutputLen returned invalid value %q", v)
+	}
+
+	if v := New(ExactLen(5, long, ExactLenTruncate())).WithMaxOutputLen(100, false).String(); len(v) != 5 {
+		t.Errorf("WithMaxOutputLen returned invalid value %q", v)
+	}
+
+	v := New(HexEncode(long)).WithMaxOutputLen(10, true).String()
+	if _, err := hex.DecodeString(v); err != nil || len(v) != 10 {
+		t.Errorf("WithMaxOutputLen returned invalid value %q", v)
+	}
+}
+
+func TestWithMaxOutputLenCeiling(t *testing.T) {
+	// Upper suspends the limit, but its child must not generate 50 MB.
+	gen := New(Upper(Repeat(49_999_999, 50_000_000, OneOfByte([]byte("ab"))))).WithMaxOutputLen(16, true)
+
+	v, err := gen.StringE()
+	if !errors.Is(err, ErrOutputTooLong) || v != "" {
+		t.Errorf("StringE returned invalid value: want ErrOutputTooLong, got %q, %v", v, err)
+	}
+
+	if v, err := New(Upper(Literal("a"))).WithMaxOutputLen(16, false).StringE(); err != nil || v != "A" {
+		t.Errorf("StringE returned invalid value: want \"A\", got %q, %v", v, err)
+	}
+}
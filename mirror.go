@@ -0,0 +1,45 @@
+package pattern
+
+import (
+	"unicode/utf8"
+)
+
+// Mirror returns a Part that outputs a copy of everything generated so far in the current call,
+// e.g. New(p, Literal("|"), Mirror()) for prefix-echo formats like "abc|abc|".
+func Mirror() Part {
+	return mirror{}
+}
+
+// MirrorReversed returns a Part that outputs the characters of everything generated so far in the current call in reverse order,
+// e.g. New(p, MirrorReversed()) for palindromes like "abccba".
+// Invalid UTF-8 is reversed byte by byte.
+func MirrorReversed() Part {
+	return mirror{reversed: true}
+}
+
+type mirror struct {
+	reversed bool
+}
+
+func (p mirror) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p mirror) appendRun(r *run, b []byte) []byte {
+	start := 0
+	if r != nil {
+		start = r.start
+	}
+
+	if !p.reversed {
+		return append(b, b[start:]...)
+	}
+
+	// The appended runes are read from the old backing array if b grows.
+	for s := b[start:]; len(s) > 0; {
+		_, n := utf8.DecodeLastRune(s)
+		b = append(b, s[len(s)-n:]...)
+		s = s[:len(s)-n]
+	}
+	return b
+}
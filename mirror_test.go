@@ -0,0 +1,44 @@
+package pattern
+
+import (
+	"testing"
+)
+
+func TestMirror(t *testing.T) {
+	tests := []struct {
+		name string
+		gen  *gen
+		want string
+	}{
+		{"Mirror", New(Literal("ab"), Literal("|"), Mirror()), "ab|ab|"},
+		{"MirrorReversed", New(Literal("abc"), MirrorReversed()), "abccba"},
+		{"runes", New(Literal("aあ\xff"), MirrorReversed()), "aあ\xff\xffあa"},
+		{"nested", New(Literal("a"), New(Literal("b"), Mirror())), "abab"},
+		{"twice", New(Literal("a"), Mirror(), Mirror()), "aaaa"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if v := tt.gen.String(); v != tt.want {
+				t.Errorf("String returned invalid value: want %q, got %q", tt.want, v)
+			}
+
+			// Output before the call is not mirrored.
+			if v := string(tt.gen.Append([]byte("prefix"))); v != "prefix"+tt.want {
+				t.Errorf("Append returned invalid value: want %q, got %q", "prefix"+tt.want, v)
+			}
+		})
+	}
+}
+
+func TestMirrorPalindrome(t *testing.T) {
+	gen := New(Repeat(1, 10, OneOfRuneRange('a', 'z')), MirrorReversed())
+	for i := 0; i < 100; i++ {
+		v := []rune(gen.String())
+		for j := range v {
+			if v[j] != v[len(v)-1-j] {
+				t.Fatalf("MirrorReversed returned no palindrome %q", string(v))
+			}
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package pattern
+
+// Mutate returns up to n distinct outputs of the pattern that differ from s by a single choice,
+// e.g. a different OneOf member, a different Sequence number or one repetition more or less.
+// The neighbors are selected at random if there are more than n, and none are returned if n is <= 0.
+// Mutate returns nil if s is not an output of the pattern.
+// Mutate panics if the pattern contains Parts whose choices can not be recovered from an output, like custom Parts or Timestamp.
+func (g gen) Mutate(s string, n int) []string {
+	if !canDerive(g) {
+		panic("pattern contains parts that can not be mutated")
+	}
+
+	d := deriveAll(g, []byte(s))
+	if d == nil {
+		return nil
+	}
+
+	seen := map[string]bool{s: true}
+	var out []string
+	b := make([]byte, 0, g.bufferCap())
+	g.mutate(d, nil, func(d *derivation) bool {
+		b = g.build(b[:0], d)
+		if !seen[string(b)] {
+			v := string(b)
+			seen[v] = true
+			out = append(out, v)
+		}
+		return true
+	})
+
+	// Select n neighbors with a partial Fisher-Yates shuffle.
+	var r *run
+	if n > len(out) {
+		n = len(out)
+	}
+	if n < 0 {
+		n = 0
+	}
+	for i := 0; i < n; i++ {
+		j := i + int(r.uint64N(uint64(len(out)-i)))
+		out[i], out[j] = out[j], out[i]
+	}
+	return out[:n]
+}
+
+// maxMutations is the number of values above which mutateValue samples values at random.
+const maxMutations = 16
+
+// mutateValue calls yield with values in [0, n) other than v, where n == 0 means all uint64.
+// Large ranges are sampled: the adjacent values and maxMutations random values.
+func mutateValue(v uint64, n uint64, r *run, yield func(uint64) bool) bool {
+	if n != 0 && n <= maxMutations {
+		for i := uint64(0); i < n; i++ {
+			if i != v && !yield(i) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if v > 0 && !yield(v-1) {
+		return false
+	}
+	if v+1 != n && !yield(v+1) {
+		return false
+	}
+	for i := 0; i < maxMutations; i++ {
+		if u := r.uint64N(n); u != v && !yield(u) {
+			return false
+		}
+	}
+	return true
+}
+
+// mutateGroup calls yield with copies of sub where a single child has been mutated.
+func mutateGroup(parts []Part, sub []*derivation, r *run, yield func([]*derivation) bool) bool {
+	for i, p := range parts {
+		ok := p.(derivable).mutate(sub[i], r, func(d *derivation) bool {
+			c := append([]*derivation(nil), sub...)
+			c[i] = d
+			return yield(c)
+		})
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// mutateIndex calls yield with derivations of other members of an alphabet of size n than d.
+func mutateIndex(d *derivation, n int, r *run, yield func(*derivation) bool) bool {
+	return mutateValue(d.value, uint64(n), r, func(i uint64) bool {
+		return yield(&derivation{value: i})
+	})
+}
+
+func (g gen) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return mutateGroup(g.parts, d.sub, r, func(sub []*derivation) bool {
+		return yield(&derivation{sub: sub})
+	})
+}
+
+func (p nullpart) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return true
+}
+
+func (p group) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return gen{parts: p}.mutate(d, r, yield)
+}
+
+func (p repeat) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	l := uint64(len(p.parts))
+
+	// Remove the last repetition.
+	if d.value > uint64(p.min) {
+		if !yield(&derivation{value: d.value - 1, sub: d.sub[:(d.value-1)*l]}) {
+			return false
+		}
+	}
+
+	// Repeat the last repetition once more.
+	if d.value < uint64(p.min+p.maxr-1) {
+		sub := append([]*derivation(nil), d.sub...)
+		if d.value > 0 {
+			sub = append(sub, d.sub[(d.value-1)*l:]...)
+		} else {
+			sub = append(sub, firstGroup(p.parts)...)
+		}
+
+		if !yield(&derivation{value: d.value + 1, sub: sub}) {
+			return false
+		}
+	}
+
+	return mutateGroup(p.repeated(d.value), d.sub, r, func(sub []*derivation) bool {
+		return yield(&derivation{value: d.value, sub: sub})
+	})
+}
+
+func (p optional) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	if d.value == 0 {
+		return yield(&derivation{value: 1, sub: []*derivation{p.part.(derivable).first()}})
+	}
+
+	if !yield(&derivation{}) {
+		return false
+	}
+
+	return p.part.(derivable).mutate(d.sub[0], r, func(sub *derivation) bool {
+		return yield(&derivation{value: 1, sub: []*derivation{sub}})
+	})
+}
+
+func (p potentially50) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return optional{p.part}.mutate(d, r, yield)
+}
+
+func (p potentiallyP) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return optional{p.part}.mutate(d, r, yield)
+}
+
+func (p literal) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return true
+}
+
+func (p anyOf) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	ok := mutateValue(d.value, uint64(len(p.parts)), r, func(i uint64) bool {
+		return yield(&derivation{value: i, sub: []*derivation{p.parts[i].(derivable).first()}})
+	})
+	if !ok {
+		return false
+	}
+
+	return p.parts[d.value].(derivable).mutate(d.sub[0], r, func(sub *derivation) bool {
+		return yield(&derivation{value: d.value, sub: []*derivation{sub}})
+	})
+}
+
+func (p anyOfString) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return mutateIndex(d, len(p.alphabet), r, yield)
+}
+
+func (p anyOfByte) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return mutateIndex(d, len(p.alphabet), r, yield)
+}
+
+func (p byteRange) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return mutateIndex(d, int(p.n), r, yield)
+}
+
+func (p anyOfRune) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return mutateIndex(d, len(p.alphabet), r, yield)
+}
+
+func (p runeRanges) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	n, _ := p.count()
+	return mutateValue(d.value, n, r, func(i uint64) bool {
+		return yield(&derivation{value: i})
+	})
+}
+
+func (p shuffle) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	// Swap two adjacent children.
+	for i := 1; i < len(d.order); i++ {
+		order := append([]int(nil), d.order...)
+		sub := append([]*derivation(nil), d.sub...)
+		order[i-1], order[i] = order[i], order[i-1]
+		sub[i-1], sub[i] = sub[i], sub[i-1]
+
+		if !yield(&derivation{order: order, sub: sub}) {
+			return false
+		}
+	}
+
+	return mutateGroup(p.ordered(d.order), d.sub, r, func(sub []*derivation) bool {
+		return yield(&derivation{order: d.order, sub: sub})
+	})
+}
+
+func (p sequence) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	// Number of steps in [start, max], 0 if it covers all uint64.
+	n := (p.max-p.start)/p.step + 1
+	return mutateValue(d.value, n, r, func(k uint64) bool {
+		if p.skip != nil && p.skip(p.number(k)) {
+			return true
+		}
+		return yield(&derivation{value: k})
+	})
+}
+
+func (p rangeNumber) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return mutateValue(d.value, p.max-p.start+1, r, func(i uint64) bool {
+		return yield(&derivation{value: i})
+	})
+}
+
+func (p sequencePer) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return rangeNumber{p.start, p.max, p.width}.mutate(d, r, yield)
+}
+
+func (p permutation) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return p.rangeNumber().mutate(d, r, yield)
+}
+
+func (p fixedString) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	for i, c := range d.sub {
+		ok := mutateIndex(c, len(p.alphabet), r, func(c *derivation) bool {
+			sub := append([]*derivation(nil), d.sub...)
+			sub[i] = c
+			return yield(&derivation{sub: sub})
+		})
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (p nanoIDMask) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return fixedString{p.alphabet, p.size}.mutate(d, r, yield)
+}
+
+func (p nanoID) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return fixedString{p.alphabet, p.size}.mutate(d, r, yield)
+}
+
+func (p randomHex) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return p.fixedString().mutate(d, r, yield)
+}
+
+func (p cycle) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return p.oneOf().mutate(d, r, yield)
+}
+
+func (p repeatNormal) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return p.uniform.(derivable).mutate(d, r, yield)
+}
+
+func (p balanced) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return p.nested.(derivable).mutate(d, r, yield)
+}
+
+func (p ifPart) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return p.oneOf().mutate(d, r, yield)
+}
+
+func (p choose) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return p.oneOf().mutate(d, r, yield)
+}
+
+func (p throttle) mutate(d *derivation, r *run, yield func(*derivation) bool) bool {
+	return p.part.(derivable).mutate(d, r, yield)
+}
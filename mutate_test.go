@@ -0,0 +1,101 @@
+package pattern
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMutate(t *testing.T) {
+	gen := New(
+		OneOfString([]string{"red", "green"}),
+		Literal("-"),
+		Repeat(1, 3, OneOfByte([]byte("ab"))),
+		Potentially(0.5, Literal("!")),
+	)
+
+	got := gen.Mutate("red-ab", 100)
+	sort.Strings(got)
+
+	want := []string{
+		"green-ab", // other OneOf member
+		"red-a",    // one repetition less
+		"red-ab!",  // optional Part added
+		"red-abb",  // one repetition more
+		"red-bb",   // other byte
+		"red-aa",
+	}
+	sort.Strings(want)
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Mutate returned invalid values: want %q, got %q", want, got)
+	}
+
+	if got := gen.Mutate("red-ab", 2); len(got) != 2 {
+		t.Errorf("Mutate returned invalid number of values: want 2, got %d", len(got))
+	}
+
+	if got := gen.Mutate("blue-ab", 10); got != nil {
+		t.Errorf("Mutate returned values for invalid input: %q", got)
+	}
+}
+
+func TestMutateNegative(t *testing.T) {
+	gen := New(OneOfByte([]byte("abc")))
+	if got := gen.Mutate("a", -1); len(got) != 0 {
+		t.Errorf("Mutate with n < 0 returned values: %q", got)
+	}
+}
+
+func TestMutateLarge(t *testing.T) {
+	gen := New(Sequence(0, 999999999999, 12), NanoID(8, []byte("0123456789abcdefghijklmnopqrstuvwxyz")))
+
+	s := "000000001000" + "abcdefgh"
+	got := gen.Mutate(s, 1000)
+	if len(got) == 0 {
+		t.Fatal("Mutate returned no values")
+	}
+
+	for _, v := range got {
+		if len(v) != len(s) {
+			t.Errorf("Mutate returned invalid value: %s", strconv.Quote(v))
+			continue
+		}
+
+		// Exactly one of the two Parts differs.
+		if (v[:12] != s[:12]) == (v[12:] != s[12:]) {
+			t.Errorf("Mutate changed more than one choice: %s", strconv.Quote(v))
+		}
+	}
+
+	// The adjacent numbers are always included.
+	for _, want := range []string{"000000000999abcdefgh", "000000001001abcdefgh"} {
+		found := false
+		for _, v := range got {
+			found = found || v == want
+		}
+		if !found {
+			t.Errorf("Mutate did not return %s", strconv.Quote(want))
+		}
+	}
+}
+
+func TestMutateAmbiguous(t *testing.T) {
+	gen := New(Repeat(1, 60, OneOfString([]string{"a", "aa"})))
+
+	// The number of ways to split the a's grows exponentially.
+	if got := gen.Mutate(strings.Repeat("a", 32)+"b", 10); got != nil {
+		t.Errorf("Mutate returned values for invalid input: %q", got)
+	}
+
+	got := gen.Mutate(strings.Repeat("a", 32), 10)
+	if len(got) == 0 {
+		t.Fatal("Mutate returned no values")
+	}
+	for _, v := range got {
+		if !Match(gen, v) {
+			t.Errorf("Mutate returned invalid value: %s", strconv.Quote(v))
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package pattern
+
+import (
+	"math/bits"
+)
+
+const (
+	nanoIDSize     = 21
+	nanoIDAlphabet = "useandom-26T198340PX75pxJACKVERYMINDBUSHWOLF_GQZbfghjklqvwyzrict"
+)
+
+// NanoID returns a Part that generates a NanoID-compatible string of size characters selected from alphabet.
+// If size is <= 0, the default size of 21 is used.
+// If alphabet is empty, the default URL-safe alphabet of NanoID is used.
+// Characters are selected without modulo bias.
+//
+// https://github.com/ai/nanoid
+func NanoID(size int, alphabet []byte) Part {
+	if size <= 0 {
+		size = nanoIDSize
+	}
+
+	if len(alphabet) == 0 {
+		alphabet = []byte(nanoIDAlphabet)
+	}
+
+	if len(alphabet) > 256 {
+		panic("alphabet must not be longer than 256 bytes")
+	}
+
+	// Alphabets with a length that is a power of two can be masked.
+	if bits.OnesCount(uint(len(alphabet))) == 1 {
+		return nanoIDMask{
+			alphabet: alphabet,
+			mask:     byte(len(alphabet) - 1),
+			size:     size,
+		}
+	}
+
+	return nanoID{
+		alphabet: alphabet,
+		len:      uint32(len(alphabet)),
+		size:     size,
+	}
+}
+
+type nanoIDMask struct {
+	alphabet []byte
+	mask     byte
+	size     int
+}
+
+func (p nanoIDMask) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p nanoIDMask) appendRun(r *run, b []byte) []byte {
+	// Use all 8 bytes of each random number.
+	var v uint64
+	for i := 0; i < p.size; i++ {
+		if i%8 == 0 {
+			v = r.uint64()
+		}
+		b = append(b, p.alphabet[byte(v)&p.mask])
+		v >>= 8
+	}
+	return b
+}
+
+type nanoID struct {
+	alphabet []byte
+	len      uint32
+	size     int
+}
+
+func (p nanoID) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p nanoID) appendRun(r *run, b []byte) []byte {
+	for i := 0; i < p.size; i++ {
+		b = append(b, p.alphabet[r.uniformN(p.len)])
+	}
+	return b
+}
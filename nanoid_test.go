@@ -0,0 +1,64 @@
+package pattern
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNanoID(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		alphabet string
+		wantSize int
+		want     string
+	}{
+		{"default", 0, "", 21, nanoIDAlphabet},
+		{"power of two", 10, "abcd", 10, "abcd"},
+		{"custom", 5, "0123456789", 5, "0123456789"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := New(NanoID(tt.size, []byte(tt.alphabet)))
+
+			hitmap := make(map[rune]bool, len(tt.want))
+			for i := 0; i < 1000; i++ {
+				v := gen.String()
+				if len(v) != tt.wantSize {
+					t.Errorf("NanoID has invalid length: want %d, got %d", tt.wantSize, len(v))
+				}
+				for _, c := range v {
+					if !strings.ContainsRune(tt.want, c) {
+						t.Errorf("NanoID returned invalid character: want one of %s, got %s", strconv.Quote(tt.want), strconv.Quote(string(c)))
+					}
+					hitmap[c] = true
+				}
+			}
+
+			if len(hitmap) != len(tt.want) {
+				t.Errorf("NanoID did not use the whole alphabet: want %d characters, got %d", len(tt.want), len(hitmap))
+			}
+		})
+	}
+}
+
+func TestNanoIDPanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("NanoID with alphabet longer than 256 bytes did not panic")
+			}
+		}()
+
+		New(NanoID(0, make([]byte, 257)))
+	}()
+}
+
+func BenchmarkNanoID(b *testing.B) {
+	gen := New(NanoID(0, nil))
+	for i := 0; i < b.N; i++ {
+		id = gen.String()
+	}
+}
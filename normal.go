@@ -0,0 +1,63 @@
+package pattern
+
+import (
+	"math"
+)
+
+// RepeatNormal returns a Part that repeats p a number of times drawn from a normal distribution with mean and stddev,
+// clamped to [min, max], e.g. RepeatNormal(6, 2, 2, 12, OneOfByte(AlphaLower)) for word lengths that cluster around 6.
+// The outputs are the same as those of Repeat(min, max, p...), only their probabilities differ.
+//
+// Panics if mean or stddev is not finite, stddev is < 0, max is 0 or max < min.
+func RepeatNormal(mean float64, stddev float64, min uint32, max uint32, p ...Part) Part {
+	if math.IsNaN(mean) || math.IsInf(mean, 0) {
+		panic("mean must be finite")
+	}
+
+	if !(stddev >= 0) || math.IsInf(stddev, 0) {
+		panic("stddev must be finite and >= 0")
+	}
+
+	return repeatNormal{
+		parts:   p,
+		mean:    mean,
+		stddev:  stddev,
+		min:     min,
+		max:     max,
+		uniform: Repeat(min, max, p...),
+	}
+}
+
+type repeatNormal struct {
+	parts  []Part
+	mean   float64
+	stddev float64
+	min    uint32
+	max    uint32
+	// uniform is the Repeat with the same outputs.
+	uniform Part
+}
+
+func (p repeatNormal) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p repeatNormal) appendRun(r *run, b []byte) []byte {
+	// Box-Muller transform.
+	u1 := 1 - r.float64()
+	u2 := r.float64()
+	z := math.Sqrt(-2*math.Log(u1)) * math.Cos(2*math.Pi*u2)
+
+	n := math.Round(p.mean + p.stddev*z)
+	n = math.Max(float64(p.min), math.Min(float64(p.max), n))
+	for i := uint32(0); i < uint32(n); i++ {
+		for _, part := range p.parts {
+			b = appendRun(r, part, b)
+		}
+	}
+	return b
+}
+
+func (p repeatNormal) Children() []Part {
+	return p.parts
+}
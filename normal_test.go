@@ -0,0 +1,64 @@
+package pattern
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestRepeatNormal(t *testing.T) {
+	gen := New(RepeatNormal(6, 2, 2, 9, Literal("a")))
+
+	const n = 100000
+	counts := make([]int, 10)
+	sum := 0
+	for i := 0; i < n; i++ {
+		v := gen.String()
+		if len(v) < 2 || len(v) > 9 {
+			t.Fatalf("RepeatNormal returned invalid value: %s", strconv.Quote(v))
+		}
+		counts[len(v)]++
+		sum += len(v)
+	}
+
+	// The clamped tails are slightly asymmetric, but the mean stays close to 6.
+	if mean := float64(sum) / n; math.Abs(mean-6) > 0.1 {
+		t.Errorf("RepeatNormal has invalid mean: want about 6, got %v", mean)
+	}
+	if counts[6] < counts[4] || counts[6] < counts[8] {
+		t.Errorf("RepeatNormal does not cluster around the mean: %v", counts)
+	}
+
+	// The outputs are those of Repeat(2, 9).
+	p := RepeatNormal(6, 2, 2, 9, Literal("a"))
+	if c, ok := p.(countable).count(); !ok || c != 8 {
+		t.Errorf("count returned invalid value: want 8, got %d", c)
+	}
+	if !Match(p, "aaaaaaaaa") || Match(p, "a") {
+		t.Errorf("Match returned invalid result")
+	}
+}
+
+func TestRepeatNormalPanic(t *testing.T) {
+	tests := []struct {
+		name         string
+		mean, stddev float64
+		min, max     uint32
+	}{
+		{"mean", math.NaN(), 1, 1, 2},
+		{"stddev", 1, -1, 1, 2},
+		{"max 0", 1, 1, 0, 0},
+		{"max < min", 1, 1, 3, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("RepeatNormal did not panic")
+				}
+			}()
+			RepeatNormal(tt.mean, tt.stddev, tt.min, tt.max, Literal("a"))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Package openapi builds generators from OpenAPI string schemas, e.g. to return conformant example values from mock servers.
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sollniss/pattern"
+)
+
+// MaxRetries is the number of times a value is regenerated if its length does not satisfy the schema.
+const MaxRetries = 1000
+
+// Schema holds the fields of an OpenAPI (or JSON Schema) string schema that restrict its values.
+// It can be decoded from the JSON of a schema object.
+type Schema struct {
+	Type      string   `json:"type"`
+	Format    string   `json:"format"`
+	Pattern   string   `json:"pattern"`
+	Enum      []string `json:"enum"`
+	MinLength *int     `json:"minLength"`
+	MaxLength *int     `json:"maxLength"`
+}
+
+// formats holds the regular expressions used for the formats of the OpenAPI and JSON Schema specifications.
+var formats = map[string]string{
+	"date":      `20[0-9]{2}-(0[1-9]|1[0-2])-(0[1-9]|1[0-9]|2[0-8])`,
+	"date-time": `20[0-9]{2}-(0[1-9]|1[0-2])-(0[1-9]|1[0-9]|2[0-8])T([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]Z`,
+	"time":      `([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9]Z`,
+	"email":     `[a-z][a-z0-9]{2,11}@example\.(com|org|net)`,
+	"hostname":  `[a-z][a-z0-9]{2,11}\.example\.(com|org|net)`,
+	"uri":       `https://[a-z][a-z0-9]{2,11}\.example\.com/[a-z0-9]{1,12}`,
+	"uuid":      `[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}`,
+	"ipv4":      `(25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])(\.(25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])){3}`,
+	"ipv6":      `[0-9a-f]{1,4}(:[0-9a-f]{1,4}){7}`,
+	"byte":      `([A-Za-z0-9+/]{4}){1,8}`,
+	"password":  `[A-Za-z0-9]{12,16}`,
+}
+
+// Generator returns a Part that generates values valid for s.
+//
+// An enum selects one of its values, a pattern is compiled with pattern.Compile
+// and the formats date, date-time, time, email, hostname, uri, uuid, ipv4, ipv6, byte and password
+// generate values of that format; other formats are ignored.
+// Without any of them, the values consist of ASCII letters and digits.
+// Values with a length outside of [minLength, maxLength] are regenerated up to MaxRetries times.
+//
+// Generator returns an error if the schema is not a string schema,
+// the pattern can not be compiled or no value can satisfy the length constraints.
+func Generator(s Schema) (pattern.Part, error) {
+	if s.Type != "" && s.Type != "string" {
+		return nil, fmt.Errorf("openapi: unsupported type %q", s.Type)
+	}
+
+	if len(s.Enum) > 0 {
+		return pattern.OneOfString(s.Enum), nil
+	}
+
+	expr, ok := formats[s.Format]
+	if s.Pattern != "" {
+		expr, ok = s.Pattern, true
+	}
+	if !ok {
+		expr = lengthPattern(s.MinLength, s.MaxLength)
+	}
+
+	g, err := pattern.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+
+	min, max := 0, -1
+	if s.MinLength != nil {
+		min = *s.MinLength
+	}
+	if s.MaxLength != nil {
+		max = *s.MaxLength
+	}
+
+	// Only filter the values if the expression can generate values with invalid lengths.
+	bounds := g.JSONSchema()
+	if bounds.MinLength >= min && (max < 0 || bounds.MaxLength <= max) {
+		return g, nil
+	}
+
+	if bounds.MaxLength < min || (max >= 0 && (bounds.MinLength > max || max < min)) {
+		return nil, fmt.Errorf("openapi: %q generates values with %d to %d characters, which can not satisfy the length constraints",
+			expr, bounds.MinLength, bounds.MaxLength)
+	}
+
+	re, err := regexp.Compile(lengthRegexp(min, max))
+	if err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+	return pattern.Constrain(g, re, nil, MaxRetries), nil
+}
+
+// maxRepeat is the largest count of a repetition accepted by regexp.
+const maxRepeat = 1000
+
+// lengthRegexp returns an anchored expression that matches values with min to max characters, or at least min if max is < 0.
+// Counts above maxRepeat are split into consecutive repetitions.
+func lengthRegexp(min int, max int) string {
+	var b strings.Builder
+	b.WriteString(`^(?s:`)
+	if max < 0 {
+		for ; min > maxRepeat; min -= maxRepeat {
+			b.WriteString(".{" + strconv.Itoa(maxRepeat) + "}")
+		}
+		b.WriteString(".{" + strconv.Itoa(min) + ",}")
+	} else {
+		for ; max > maxRepeat; max -= maxRepeat {
+			lo := min
+			if lo > maxRepeat {
+				lo = maxRepeat
+			}
+			b.WriteString(".{" + strconv.Itoa(lo) + "," + strconv.Itoa(maxRepeat) + "}")
+			min -= lo
+		}
+		b.WriteString(".{" + strconv.Itoa(min) + "," + strconv.Itoa(max) + "}")
+	}
+	b.WriteString(`)$`)
+	return b.String()
+}
+
+// lengthPattern returns an expression of ASCII letters and digits for the length constraints.
+func lengthPattern(minLength *int, maxLength *int) string {
+	min := 1
+	if minLength != nil {
+		min = *minLength
+	}
+
+	max := min + 15
+	if maxLength != nil {
+		max = *maxLength
+		if minLength == nil && max < min {
+			min = max
+		}
+	}
+
+	return "[A-Za-z0-9]{" + strconv.Itoa(min) + "," + strconv.Itoa(max) + "}"
+}
@@ -0,0 +1,151 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func intp(v int) *int {
+	return &v
+}
+
+func generate(t *testing.T, s Schema) []string {
+	t.Helper()
+
+	p, err := Generator(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := make([]string, 100)
+	for i := range values {
+		values[i] = string(p.Append(nil))
+	}
+	return values
+}
+
+func TestGeneratorFormat(t *testing.T) {
+	tests := map[string]func(string) bool{
+		"date": func(s string) bool {
+			_, err := time.Parse("2006-01-02", s)
+			return err == nil
+		},
+		"date-time": func(s string) bool {
+			_, err := time.Parse(time.RFC3339, s)
+			return err == nil
+		},
+		"time": func(s string) bool {
+			_, err := time.Parse("15:04:05Z07:00", s)
+			return err == nil
+		},
+		"email": func(s string) bool {
+			a, err := mail.ParseAddress(s)
+			return err == nil && a.Address == s
+		},
+		"hostname": func(s string) bool {
+			return regexp.MustCompile(`^[a-z0-9]+(\.[a-z0-9]+)+$`).MatchString(s)
+		},
+		"uri": func(s string) bool {
+			u, err := url.Parse(s)
+			return err == nil && u.IsAbs()
+		},
+		"uuid": func(s string) bool {
+			return regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(s)
+		},
+		"ipv4": func(s string) bool {
+			ip := net.ParseIP(s)
+			return ip != nil && ip.To4() != nil
+		},
+		"ipv6": func(s string) bool {
+			ip := net.ParseIP(s)
+			return ip != nil && ip.To4() == nil
+		},
+		"byte": func(s string) bool {
+			_, err := base64.StdEncoding.DecodeString(s)
+			return err == nil
+		},
+	}
+
+	for format, valid := range tests {
+		for _, v := range generate(t, Schema{Type: "string", Format: format}) {
+			if !valid(v) {
+				t.Errorf("%s: invalid value %q", format, v)
+				break
+			}
+		}
+	}
+}
+
+func TestGeneratorLength(t *testing.T) {
+	tests := []struct {
+		schema   Schema
+		min, max int
+	}{
+		{Schema{}, 1, 16},
+		{Schema{MinLength: intp(3)}, 3, 18},
+		{Schema{MaxLength: intp(4)}, 1, 4},
+		{Schema{MaxLength: intp(0)}, 0, 0},
+		{Schema{MinLength: intp(2), MaxLength: intp(2)}, 2, 2},
+		{Schema{Pattern: `^ä[a-z]{0,5}$`, MinLength: intp(3), MaxLength: intp(4)}, 3, 4},
+		{Schema{Format: "password", MaxLength: intp(13)}, 12, 13},
+		{Schema{Pattern: `[a-z]{500}[a-z]{500,700}`, MinLength: intp(1100), MaxLength: intp(1150)}, 1100, 1150},
+		{Schema{Pattern: `[a-z]{500}[a-z]{500,700}`, MinLength: intp(1100)}, 1100, 1200},
+	}
+
+	for _, test := range tests {
+		for _, v := range generate(t, test.schema) {
+			if n := utf8.RuneCountInString(v); n < test.min || n > test.max {
+				t.Errorf("%+v: length of %q is not in [%d, %d]", test.schema, v, test.min, test.max)
+				break
+			}
+		}
+	}
+}
+
+func TestGeneratorPatternEnum(t *testing.T) {
+	re := regexp.MustCompile(`^ORD-[0-9]{6}$`)
+	for _, v := range generate(t, Schema{Pattern: `^ORD-\d{6}$`, Format: "uuid"}) {
+		if !re.MatchString(v) {
+			t.Errorf("%q does not match %s", v, re)
+			break
+		}
+	}
+
+	var s Schema
+	if err := json.Unmarshal([]byte(`{"type":"string","enum":["red","green"],"minLength":10}`), &s); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range generate(t, s) {
+		if v != "red" && v != "green" {
+			t.Errorf("got %q, want red or green", v)
+			break
+		}
+	}
+}
+
+func TestGeneratorError(t *testing.T) {
+	tests := []struct {
+		schema Schema
+		want   string
+	}{
+		{Schema{Type: "integer"}, `unsupported type "integer"`},
+		{Schema{Pattern: `(`}, "missing closing )"},
+		{Schema{Pattern: `[a-z]{3}`, MinLength: intp(4)}, "can not satisfy the length constraints"},
+		{Schema{Format: "uuid", MaxLength: intp(10)}, "can not satisfy the length constraints"},
+	}
+
+	for _, test := range tests {
+		_, err := Generator(test.schema)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%+v: got %v, want error containing %q", test.schema, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package pattern
+
+import (
+	"github.com/sollniss/pattern/internal"
+)
+
+// Option configures a generator created with NewWithOptions.
+type Option func(*gen)
+
+// NewWithOptions returns a new pattern generator like New, configured by opts, e.g.
+//
+//	NewWithOptions([]Option{WithSecure(), WithInitialCapacity(512)}, parts...)
+//
+// The options only apply if the generator is used directly, not as a Part of another pattern.
+func NewWithOptions(opts []Option, p ...Part) *gen {
+	g := New(p...)
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// WithInitialCapacity sets the initial capacity of the buffers of String and Bytes to n bytes.
+// By default, the capacity is derived from the SizeHint of the Parts, or 100 bytes if the maximum length is unknown.
+// A capacity of 0 restores the default.
+//
+// Panics if n is < 0.
+func WithInitialCapacity(n int) Option {
+	if n < 0 {
+		panic("n must be >= 0")
+	}
+
+	return func(g *gen) {
+		g.capacity = n
+	}
+}
+
+// WithRand makes the generator draw its random numbers from src, e.g. a *rand.Rand.
+// Unlike StringFrom, stateful Parts like Sequence still advance their state.
+// Custom Parts are not affected and use their own source of randomness.
+// If the generator is used as a Part of another generator, it keeps using src unless the other generator has a source too.
+// src must be safe for concurrent use if the generator is used concurrently.
+//
+// Panics if src is nil.
+func WithRand(src Source) Option {
+	if src == nil {
+		panic("src must not be nil")
+	}
+
+	return func(g *gen) {
+		g.src = src
+	}
+}
+
+// WithSecure makes the generator draw its random numbers from crypto/rand, e.g. for tokens and passwords.
+func WithSecure() Option {
+	return WithRand(internal.SecureSource{})
+}
+
+// WithSecret keys the outputs of Transform and StringFor with secret,
+// so that pseudonyms of guessed inputs can't be computed without knowing secret.
+// Generators with different secrets map the same input to unrelated outputs.
+//
+// Panics if secret is empty.
+func WithSecret(secret []byte) Option {
+	if len(secret) == 0 {
+		panic("secret must not be empty")
+	}
+
+	secret = append([]byte(nil), secret...)
+	return func(g *gen) {
+		g.secret = secret
+	}
+}
+
+// WithSeed makes the generator draw its random numbers from a deterministic source seeded with seed,
+// so the same sequence of calls always results in the same patterns, e.g. for reproducible test data.
+// The source is safe for concurrent use, but concurrent calls get their random numbers in an unpredictable order.
+// Each generator created with the option gets its own source.
+func WithSeed(seed uint64) Option {
+	return func(g *gen) {
+		g.src = internal.NewSeedSource(seed)
+	}
+}
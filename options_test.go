@@ -0,0 +1,83 @@
+package pattern
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	parts := []Part{Literal("id-"), Repeat(1, 20, OneOfByte([]byte("abcdef")))}
+
+	g1 := NewWithOptions([]Option{WithSeed(42)}, parts...)
+	g2 := NewWithOptions([]Option{WithSeed(42)}, parts...)
+	for i := 0; i < 10; i++ {
+		if v1, v2 := g1.String(), g2.String(); v1 != v2 {
+			t.Fatalf("WithSeed returned different values for the same seed: %q and %q", v1, v2)
+		}
+	}
+
+	// Generators created with the same options start with the same state.
+	opts := []Option{WithSeed(42)}
+	s1 := NewWithOptions(opts, parts...).String()
+	if s2 := NewWithOptions(opts, parts...).String(); s1 != s2 {
+		t.Errorf("WithSeed shared its source between generators: %q and %q", s1, s2)
+	}
+
+	r1 := NewWithOptions([]Option{WithRand(rand.New(rand.NewSource(1)))}, parts...)
+	r2 := NewWithOptions([]Option{WithRand(rand.New(rand.NewSource(1)))}, parts...)
+	if v1, v2 := string(r1.Append([]byte("x"))), "x"+r2.String(); v1 != v2 {
+		t.Errorf("WithRand returned different values for the same source: %q and %q", v1, v2)
+	}
+
+	if b := NewWithOptions([]Option{WithInitialCapacity(1000)}, parts...).Bytes(); cap(b) < 1000 {
+		t.Errorf("WithInitialCapacity returned a buffer with capacity %d", cap(b))
+	}
+
+	s := NewWithOptions([]Option{WithSecure(), WithMaxTotalLen(8)}, parts...)
+	for i := 0; i < 100; i++ {
+		if v := s.String(); len(v) < 4 || len(v) > 8 || !Match(New(parts...), v) {
+			t.Fatalf("WithSecure and WithMaxTotalLen returned invalid value %q", v)
+		}
+	}
+}
+
+func TestNestedSource(t *testing.T) {
+	inner := func(seed uint64) *gen {
+		return NewWithOptions([]Option{WithSeed(seed)}, NanoID(16, []byte("abcdef")))
+	}
+
+	// A nested generator keeps its own source.
+	if a, b := New(inner(1)).String(), inner(1).String(); a != b {
+		t.Errorf("nested generator did not use its source: want %s, got %s", b, a)
+	}
+
+	// The source of the outer generator takes precedence.
+	a := NewWithOptions([]Option{WithSeed(2)}, inner(1)).String()
+	b := NewWithOptions([]Option{WithSeed(2)}, inner(3)).String()
+	if a != b {
+		t.Errorf("nested generator did not use the outer source: got %s and %s", a, b)
+	}
+}
+
+func TestWithSecret(t *testing.T) {
+	parts := []Part{Literal("user-"), NanoID(16, nil), Literal("-"), Sequence(1, 999999, 6)}
+	plain := New(parts...)
+	s1 := NewWithOptions([]Option{WithSecret([]byte("s1"))}, parts...)
+	s1b := NewWithOptions([]Option{WithSecret([]byte("s1"))}, parts...)
+	s2 := NewWithOptions([]Option{WithSecret([]byte("s2"))}, parts...)
+
+	for _, in := range []string{"alice@example.com", "bob@example.com"} {
+		if a, b := s1.Transform(in), s1b.Transform(in); a != b {
+			t.Errorf("Transform returned different values for the same secret: %s != %s", a, b)
+		}
+		if a, b := s1.Transform(in), s2.Transform(in); a == b {
+			t.Errorf("Transform returned the same value for different secrets: %s", a)
+		}
+		if a, b := s1.Transform(in), plain.Transform(in); a == b {
+			t.Errorf("Transform returned the same value with and without a secret: %s", a)
+		}
+		if a, b := s1.StringFor([]byte(in)), plain.StringFor([]byte(in)); a[:21] == b[:21] {
+			t.Errorf("StringFor returned the same value with and without a secret: %s", a)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package pattern
+
+import (
+	"unicode/utf8"
+)
+
+// PadLeft returns a Part that pads the output of p with fill on the left to width characters,
+// e.g. to right-align variable-width values in fixed-column formats.
+// Outputs with at least width characters are not changed.
+//
+// Panics if width is < 0.
+func PadLeft(p Part, width int, fill byte) Part {
+	if width < 0 {
+		panic("width must be >= 0")
+	}
+
+	return pad{
+		part:  p,
+		width: width,
+		fill:  fill,
+		left:  true,
+	}
+}
+
+// PadRight returns a Part that pads the output of p with fill on the right to width characters.
+// Outputs with at least width characters are not changed.
+//
+// Panics if width is < 0.
+func PadRight(p Part, width int, fill byte) Part {
+	if width < 0 {
+		panic("width must be >= 0")
+	}
+
+	return pad{
+		part:  p,
+		width: width,
+		fill:  fill,
+	}
+}
+
+type pad struct {
+	part  Part
+	width int
+	fill  byte
+	left  bool
+}
+
+func (p pad) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p pad) appendRun(r *run, b []byte) []byte {
+	defer r.restoreLimit(r.suspendLimit())
+	start := len(b)
+	b = appendRun(r, p.part, b)
+	return p.pad(b, start)
+}
+
+// pad pads b[start:] in place.
+func (p pad) pad(b []byte, start int) []byte {
+	n := p.width - utf8.RuneCount(b[start:])
+	if n <= 0 {
+		return b
+	}
+
+	end := len(b)
+	for i := 0; i < n; i++ {
+		b = append(b, p.fill)
+	}
+	if p.left {
+		copy(b[start+n:], b[start:end])
+		for i := start; i < start+n; i++ {
+			b[i] = p.fill
+		}
+	}
+	return b
+}
+
+func (p pad) Children() []Part {
+	return []Part{p.part}
+}
@@ -0,0 +1,38 @@
+package pattern
+
+import (
+	"testing"
+)
+
+func TestPad(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Part
+		want string
+	}{
+		{"PadLeft", PadLeft(Literal("42"), 5, '0'), "00042"},
+		{"PadRight", PadRight(Literal("ab"), 4, ' '), "ab  "},
+		{"PadLeft unicode", PadLeft(Literal("äö"), 4, '.'), "..äö"},
+		{"PadRight empty", PadRight(Group(), 3, '-'), "---"},
+		{"too long", PadLeft(Literal("abcdef"), 3, ' '), "abcdef"},
+		{"exact", PadRight(Literal("abc"), 3, ' '), "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Surround the output to check that only the output of the Part is padded.
+			if got := New(Literal("|"), tt.p, Literal("|")).String(); got != "|"+tt.want+"|" {
+				t.Errorf("got %q, want %q", got, "|"+tt.want+"|")
+			}
+		})
+	}
+}
+
+func TestPadColumns(t *testing.T) {
+	g := New(PadRight(OneOfString([]string{"a", "bbb", "cc"}), 4, ' '), Literal("|"))
+	for i := 0; i < 100; i++ {
+		if s := g.String(); len(s) != 5 || s[4] != '|' {
+			t.Fatalf("got %q, want 4 characters and |", s)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package pattern
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// StringsParallel returns n generated patterns, sharding the generation across workers goroutines.
+// If workers is <= 0, GOMAXPROCS goroutines are used.
+// The result is ordered by index, but stateful Parts like Sequence are advanced concurrently,
+// so their values are not necessarily in order.
+// If ctx is cancelled before all patterns are generated, ctx.Err() is returned.
+// If n is < 0, an error wrapping ErrInvalidArgument is returned.
+func (g gen) StringsParallel(ctx context.Context, n int, workers int) ([]string, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("%w: n must be >= 0", ErrInvalidArgument)
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+
+	res := make([]string, n)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		// Each worker generates a contiguous shard of the result.
+		start, end := n*w/workers, n*(w+1)/workers
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			b := make([]byte, 0, g.bufferCap())
+			for i := start; i < end; i++ {
+				// Check for cancellation periodically.
+				if i%1024 == 0 && ctx.Err() != nil {
+					return
+				}
+
+				b = g.Append(b[:0])
+				res[i] = string(b)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
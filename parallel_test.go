@@ -0,0 +1,60 @@
+package pattern
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestStringsParallel(t *testing.T) {
+	gen := New(Literal("id-"), Shuffle(Literal("a"), Literal("b"), Literal("c")), Sequence(1, 1<<32, 0))
+	re := regexp.MustCompile(`^id-(abc|acb|bac|bca|cab|cba)\d+$`)
+
+	for _, tt := range []struct{ n, workers int }{{0, 4}, {1, 4}, {10000, 0}, {10000, 3}} {
+		values, err := gen.StringsParallel(context.Background(), tt.n, tt.workers)
+		if err != nil {
+			t.Fatalf("StringsParallel returned error: %v", err)
+		}
+
+		if len(values) != tt.n {
+			t.Errorf("StringsParallel returned invalid number of values: want %d, got %d", tt.n, len(values))
+		}
+
+		seen := make(map[string]bool, tt.n)
+		for _, v := range values {
+			if !re.MatchString(v) {
+				t.Errorf("StringsParallel returned invalid value %s", strconv.Quote(v))
+			}
+			if seen[v] {
+				t.Errorf("StringsParallel returned duplicate value %s", strconv.Quote(v))
+			}
+			seen[v] = true
+		}
+	}
+}
+
+func TestStringsParallelCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := New(Literal("o")).StringsParallel(ctx, 100000, 4)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("StringsParallel with cancelled context returned invalid error: want %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestStringsParallelNegative(t *testing.T) {
+	_, err := New(Literal("o")).StringsParallel(context.Background(), -1, 4)
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("StringsParallel with n < 0 returned invalid error: want %v, got %v", ErrInvalidArgument, err)
+	}
+}
+
+func BenchmarkStringsParallel(b *testing.B) {
+	gen := New(Repeat(20, 20, OneOfByte([]byte("abcdefghijklmnopqrstuvwxyz"))))
+	for i := 0; i < b.N; i++ {
+		_, _ = gen.StringsParallel(context.Background(), 10000, 0)
+	}
+}
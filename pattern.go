@@ -1,12 +1,16 @@
 package pattern
 
 import (
+	"errors"
 	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/sollniss/pattern/internal"
 )
 
 // Part is a part of a pattern.
+// Append may be called concurrently, so custom Parts must be safe for concurrent use.
 type Part interface {
 	// Append appends the Part to the output pattern.
 	Append([]byte) []byte
@@ -14,39 +18,177 @@ type Part interface {
 
 type gen struct {
 	parts []Part
+	// folded describes the Parts that New unwrapped or dropped.
+	folded []string
+	// mirror reports whether the Parts contain a Mirror, which needs to know where the output starts.
+	mirror bool
+	// consecutive reports whether the Parts contain a NoConsecutive, which needs a run to remember its previous output.
+	consecutive bool
+	// capacity is the initial capacity of the buffers of String and Bytes set by WithInitialCapacity or 0 to use hint.
+	capacity int
+	// hint is the initial capacity of the buffers derived from the SizeHint of the Parts.
+	hint int
+	// src is the source of randomness or nil for the default source.
+	src Source
+	// secret keys the sources of Transform and StringFor set by WithSecret or nil.
+	secret []byte
 }
 
 // New returns a new pattern generator.
 // The generator implements the Part interface, which means it can be used as a Part of another pattern.
+//
+// Generators and all Parts of this package are safe for concurrent use.
+// Stateful Parts like Sequence or Cycle synchronize their state, all other Parts keep their per-call state local to the call.
+// Functions passed to Parts, like PartFunc, UniqueBy or SequenceOnUpdate, may be called concurrently.
+// UnmarshalJSON must not be called while the generator is in use.
 func New(p ...Part) *gen {
 
 	parts := make([]Part, 0, len(p))
+	var folded []string
 
 	for i := 0; i < len(p); i++ {
 		switch v := p[i].(type) {
 		case nullpart:
 			// Skip nullparts.
+			if v.from != "" {
+				folded = append(folded, "dropped "+v.from)
+			}
 			continue
 		case group:
 			// Unwrap Group.
 			parts = append(parts, v...)
+			folded = append(folded, "unwrapped Group")
+		case constRepeat:
+			// Unwrap constant Repeat.
+			parts = append(parts, v.group...)
+			folded = append(folded, "unwrapped "+explainRepeat(v.n, v.n))
 		default:
 			parts = append(parts, v)
 		}
 
 	}
 
-	return &gen{
-		parts: parts,
+	g := &gen{
+		parts:  parts,
+		folded: folded,
+		hint:   capacityHint(parts),
 	}
+	Walk(g, func(p Part) bool {
+		switch p.(type) {
+		case mirror:
+			g.mirror = true
+		case noConsecutive:
+			g.consecutive = true
+		}
+		return !g.mirror || !g.consecutive
+	})
+	return g
 }
 
 // String returns a random pattern based on the Parts used to initialize the generator.
 func (g gen) String() string {
-	b := make([]byte, 0, 100)
-	for _, p := range g.parts {
-		b = p.Append(b)
+	b := make([]byte, 0, g.bufferCap())
+	b = g.appendRun(g.run(0), b)
+	return string(b)
+}
+
+// Bytes returns a random pattern based on the Parts used to initialize the generator as a new byte slice.
+// It avoids the conversion of String when the caller needs bytes.
+func (g gen) Bytes() []byte {
+	b := make([]byte, 0, g.bufferCap())
+	b = g.appendRun(g.run(0), b)
+	return b
+}
+
+// StringE returns a random pattern like String, but returns the errors String panics with instead of panicking,
+// like errors wrapping ErrOutputTooLong or ErrRetriesExhausted.
+func (g gen) StringE() (s string, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			e, ok := v.(error)
+			if !ok || !(errors.Is(e, ErrOutputTooLong) || errors.Is(e, ErrRetriesExhausted)) {
+				panic(v)
+			}
+			err = e
+		}
+	}()
+
+	return g.String(), nil
+}
+
+// bufferCap returns the initial capacity of the buffers of String and Bytes.
+func (g gen) bufferCap() int {
+	if g.capacity > 0 {
+		return g.capacity
 	}
+	return g.hint
+}
+
+// run returns the run of a call with output starting at start, or nil if the defaults suffice.
+func (g gen) run(start int) *run {
+	if g.src == nil && !g.consecutive && (!g.mirror || start == 0) {
+		return nil
+	}
+	return &run{src: g.src, start: start}
+}
+
+// Transform returns a pattern that is derived from input.
+// Unlike StringFor, stateful Parts like Sequence also derive their output from input instead of advancing their state,
+// so the same input always results in the same pattern.
+// This allows masking existing values with values that match the pattern, while preserving equality between them.
+// Without WithSecret, anyone who knows the pattern can compute the output of a guessed input.
+// Parts that depend on the current time or external state, like Timestamp and SequenceBackend, still behave as in String.
+func (g gen) Transform(input string) string {
+	r := &run{
+		src:           g.hashSource([]byte(input)),
+		deterministic: true,
+	}
+
+	b := make([]byte, 0, g.bufferCap())
+	b = g.appendRun(r, b)
+	return string(b)
+}
+
+// StringFor returns a pattern whose random choices are derived from key.
+// The same key always results in the same pattern, which allows generating stable pseudonyms, e.g. for user IDs.
+// Use WithSecret if the key must not be guessable from the pattern.
+// Parts that do not use randomness, like Sequence, behave as in String.
+// Custom Parts are not affected and use their own source of randomness.
+func (g gen) StringFor(key []byte) string {
+	r := &run{
+		src: g.hashSource(key),
+	}
+
+	b := make([]byte, 0, g.bufferCap())
+	b = g.appendRun(r, b)
+	return string(b)
+}
+
+// hashSource returns the source of Transform and StringFor that derives its values from key.
+func (g gen) hashSource(key []byte) Source {
+	if g.secret != nil {
+		return internal.NewSecretHashSource(g.secret, key)
+	}
+	return internal.NewHashSource(key)
+}
+
+// Source is a source of random numbers, e.g. *rand.Rand.
+type Source interface {
+	Uint64() uint64
+}
+
+// StringFrom returns a pattern generated by p with src as the source of randomness.
+// Stateful Parts like Sequence draw their output from src as well instead of advancing their state,
+// so the same sequence of random numbers always results in the same pattern.
+// Custom Parts are not affected and use their own source of randomness.
+func StringFrom(p Part, src Source) string {
+	r := &run{
+		src:           src,
+		deterministic: true,
+	}
+
+	b := make([]byte, 0, 100)
+	b = appendRun(r, p, b)
 	return string(b)
 }
 
@@ -54,13 +196,31 @@ func (g gen) String() string {
 //
 // Implements the Part interface.
 func (g gen) Append(b []byte) []byte {
+	return g.appendRun(g.run(len(b)), b)
+}
+
+func (g gen) appendRun(r *run, b []byte) []byte {
+	// A nested generator uses its own source, unless the outer generation has one.
+	if g.src != nil && (r == nil || r.src == nil) {
+		if r == nil {
+			r = &run{src: g.src}
+		} else {
+			inner := *r
+			inner.src = g.src
+			r = &inner
+		}
+	}
+
 	for _, p := range g.parts {
-		b = p.Append(b)
+		b = appendRun(r, p, b)
 	}
 	return b
 }
 
-type nullpart struct{}
+type nullpart struct {
+	// from is the constructor that was folded into the nullpart.
+	from string
+}
 
 func (p nullpart) Append(b []byte) []byte {
 	return b
@@ -79,14 +239,36 @@ func Group(p ...Part) Part {
 type group []Part
 
 func (p group) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p group) appendRun(r *run, b []byte) []byte {
 	for _, p := range p {
-		b = p.Append(b)
+		b = appendRun(r, p, b)
 	}
 	return b
 }
 
+// Join returns a Part that outputs p with sep between them, but not after the last one,
+// e.g. Join("-", a, b, c) for AAA-BBB-CCC style groups.
+func Join(sep string, p ...Part) Part {
+	if sep == "" || len(p) < 2 {
+		return Group(p...)
+	}
+
+	g := make(group, 0, 2*len(p)-1)
+	for i, part := range p {
+		if i > 0 {
+			g = append(g, literal(sep))
+		}
+		g = append(g, part)
+	}
+	return g
+}
+
 // Repeat returns a Part that repeats p between min and max times randomly.
 // If min == max, the Part will be repeated exactly max times in each iteration.
+// Several Parts alternate in each repetition, e.g. Repeat(3, 3, OneOfByte(AlphaUpper), OneOfByte(Digits)) outputs A1B2C3.
 func Repeat(min uint32, max uint32, p ...Part) Part {
 	if max == 0 {
 		panic("max must be > 0")
@@ -96,19 +278,24 @@ func Repeat(min uint32, max uint32, p ...Part) Part {
 		panic("max must be >= min")
 	}
 
-	// A constant repeat is a Group.
-	if min > 0 && min == max {
+	// A small constant repeat is a Group.
+	// Larger ones are repeated at generation time, so that the copies don't exhaust the memory.
+	if min > 0 && min == max && uint64(len(p))*uint64(max) <= maxFoldedParts {
 		g := make(group, 0, len(p)*int(max))
 		for i := uint32(0); i < max; i++ {
 			g = append(g, p...)
 		}
-		return g
+		return constRepeat{
+			group: g,
+			n:     max,
+		}
 	}
 
 	// A repeat with min == 0 and max == 1 is an Optional.
 	if min == 0 && max == 1 {
 		return potentially50{
 			part: Group(p...),
+			from: "Repeat(0, 1)",
 		}
 	}
 
@@ -119,6 +306,45 @@ func Repeat(min uint32, max uint32, p ...Part) Part {
 	}
 }
 
+// RepeatSep returns a Part that repeats p between min and max times randomly with sep between the repetitions,
+// but not after the last one.
+// Like Repeat, the number of repetitions is uniformly distributed.
+func RepeatSep(min uint32, max uint32, sep string, p ...Part) Part {
+	if max == 0 {
+		panic("max must be > 0")
+	}
+
+	if max < min {
+		panic("max must be >= min")
+	}
+
+	if sep == "" {
+		return Repeat(min, max, p...)
+	}
+
+	// Include the first repetition with the same probability as each other number of repetitions.
+	if min == 0 {
+		return Potentially(float64(max)/float64(max+1), RepeatSep(1, max, sep, p...))
+	}
+
+	if max == 1 {
+		return Group(p...)
+	}
+
+	// Repeat the separator with the Parts after the first repetition.
+	rest := append([]Part{literal(sep)}, p...)
+	return Group(Group(p...), Repeat(min-1, max-1, rest...))
+}
+
+// maxFoldedParts is the maximum number of Parts a constant Repeat is folded into.
+const maxFoldedParts = 1 << 12
+
+// constRepeat is a Repeat with min == max, which is folded into a Group of n copies of its Parts.
+type constRepeat struct {
+	group
+	n uint32
+}
+
 type repeat struct {
 	parts []Part
 	min   uint32
@@ -127,10 +353,14 @@ type repeat struct {
 }
 
 func (p repeat) Append(b []byte) []byte {
-	n := internal.RandN(p.maxr) + p.min
+	return p.appendRun(nil, b)
+}
+
+func (p repeat) appendRun(r *run, b []byte) []byte {
+	n := r.randN(p.maxr) + p.min
 	for i := uint32(0); i < n; i++ {
 		for _, p := range p.parts {
-			b = p.Append(b)
+			b = appendRun(r, p, b)
 		}
 	}
 
@@ -146,7 +376,7 @@ func Potentially(c float64, p Part) Part {
 	}
 
 	if c == 0 {
-		return nullpart{}
+		return nullpart{from: "Potentially(0)"}
 	}
 
 	// An Potentially with c >= 1 can never not be included.
@@ -169,11 +399,17 @@ func Potentially(c float64, p Part) Part {
 
 type potentially50 struct {
 	part Part
+	// from is the constructor that was folded into the potentially50.
+	from string
 }
 
 func (p potentially50) Append(b []byte) []byte {
-	if internal.Fastrand()&1 == 1 {
-		b = p.part.Append(b)
+	return p.appendRun(nil, b)
+}
+
+func (p potentially50) appendRun(r *run, b []byte) []byte {
+	if r.uint64()&1 == 1 {
+		b = appendRun(r, p.part, b)
 	}
 	return b
 }
@@ -184,8 +420,12 @@ type potentiallyP struct {
 }
 
 func (p potentiallyP) Append(b []byte) []byte {
-	if internal.RandFloat64() <= p.percent {
-		b = p.part.Append(b)
+	return p.appendRun(nil, b)
+}
+
+func (p potentiallyP) appendRun(r *run, b []byte) []byte {
+	if r.float64() <= p.percent {
+		b = appendRun(r, p.part, b)
 	}
 	return b
 }
@@ -220,8 +460,13 @@ type anyOf struct {
 }
 
 func (p anyOf) Append(b []byte) []byte {
-	n := internal.RandN(p.len)
-	return p.parts[n].Append(b)
+	return p.appendRun(nil, b)
+}
+
+func (p anyOf) appendRun(r *run, b []byte) []byte {
+	n := r.randN(p.len)
+	r.covered(&p.parts[0], n)
+	return appendRun(r, p.parts[n], b)
 }
 
 // OneOfString returns a Part that will output one of s randomly in each iteration.
@@ -238,7 +483,12 @@ type anyOfString struct {
 }
 
 func (p anyOfString) Append(b []byte) []byte {
-	n := internal.RandN(p.len)
+	return p.appendRun(nil, b)
+}
+
+func (p anyOfString) appendRun(r *run, b []byte) []byte {
+	n := r.randN(p.len)
+	r.covered(&p.alphabet[0], n)
 	return append(b, p.alphabet[n]...)
 }
 
@@ -256,7 +506,12 @@ type anyOfByte struct {
 }
 
 func (p anyOfByte) Append(b []byte) []byte {
-	n := internal.RandN(p.len)
+	return p.appendRun(nil, b)
+}
+
+func (p anyOfByte) appendRun(r *run, b []byte) []byte {
+	n := r.randN(p.len)
+	r.covered(&p.alphabet[0], n)
 	return append(b, p.alphabet[n])
 }
 
@@ -275,10 +530,119 @@ type anyOfRune struct {
 }
 
 func (p anyOfRune) Append(b []byte) []byte {
-	n := internal.RandN(p.len)
+	return p.appendRun(nil, b)
+}
+
+func (p anyOfRune) appendRun(r *run, b []byte) []byte {
+	n := r.randN(p.len)
+	r.covered(&p.alphabet[0], n)
 	return append(b, string(p.alphabet[n])...)
 }
 
+// OneOfByteRange returns a Part that outputs one byte of the inclusive range [lo, hi] randomly in each iteration,
+// without materializing the alphabet.
+//
+// Panics if hi < lo.
+func OneOfByteRange(lo byte, hi byte) Part {
+	if hi < lo {
+		panic("hi must be >= lo")
+	}
+
+	return byteRange{
+		lo: lo,
+		n:  uint32(hi-lo) + 1,
+	}
+}
+
+type byteRange struct {
+	lo byte
+	// n is the number of bytes in the range.
+	n uint32
+}
+
+func (p byteRange) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p byteRange) appendRun(r *run, b []byte) []byte {
+	return append(b, p.lo+byte(r.randN(p.n)))
+}
+
+// OneOfRuneRange returns a Part that outputs one rune of the inclusive range [lo, hi] randomly in each iteration,
+// without materializing the alphabet, e.g. OneOfRuneRange(0x4E00, 0x9FFF) for the CJK Unified Ideographs.
+// Surrogates are excluded from the range, since they are not valid runes.
+//
+// Panics if hi < lo, the range is not within [0, utf8.MaxRune] or contains only surrogates.
+func OneOfRuneRange(lo rune, hi rune) Part {
+	if hi < lo {
+		panic("hi must be >= lo")
+	}
+
+	if lo < 0 || hi > utf8.MaxRune {
+		panic("range must be within [0, utf8.MaxRune]")
+	}
+
+	// Exclude the surrogates U+D800 to U+DFFF.
+	var pairs []rune
+	if lo < 0xD800 {
+		end := hi
+		if end > 0xD7FF {
+			end = 0xD7FF
+		}
+		pairs = append(pairs, lo, end)
+	}
+	if hi > 0xDFFF {
+		start := lo
+		if start < 0xE000 {
+			start = 0xE000
+		}
+		pairs = append(pairs, start, hi)
+	}
+
+	if len(pairs) == 0 {
+		panic("range must contain valid runes")
+	}
+	return newRuneRanges(pairs)
+}
+
+// OneOfUnicode returns a Part that outputs one rune of tab uniformly in each iteration,
+// e.g. OneOfUnicode(unicode.Greek) for a script or OneOfUnicode(unicode.Lu) for a category.
+//
+// Panics if tab contains no valid runes.
+func OneOfUnicode(tab *unicode.RangeTable) Part {
+	// Collect the runes as sorted inclusive ranges, merging adjacent ones.
+	var pairs []rune
+	add := func(lo rune, hi rune) {
+		if n := len(pairs); n > 0 && pairs[n-1]+1 == lo {
+			pairs[n-1] = hi
+			return
+		}
+		pairs = append(pairs, lo, hi)
+	}
+	addRange := func(lo rune, hi rune, stride rune) {
+		if stride == 1 {
+			add(lo, hi)
+			return
+		}
+		for r := lo; r <= hi; r += stride {
+			add(r, r)
+		}
+	}
+
+	for _, r := range tab.R16 {
+		addRange(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	for _, r := range tab.R32 {
+		addRange(rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+
+	p, err := charClass(pairs)
+	if err != nil {
+		panic("table must contain valid runes")
+	}
+	return p
+}
+
 // Shuffle returns a Part that randomly rearranges p in each iteration.
 // Uses the Fisher-Yates shuffle to generate permutations.
 //
@@ -287,24 +651,61 @@ func Shuffle(p ...Part) Part {
 	return shuffle{
 		parts: p,
 		len:   uint32(len(p)),
+		k:     uint32(len(p)),
+	}
+}
+
+// SampleK returns a Part that selects k distinct Parts of p in random order in each iteration,
+// e.g. to pick 3 of 10 segments. SampleK(len(p), p...) is the same as Shuffle(p...).
+//
+// Panics if k is < 0 or > len(p).
+func SampleK(k int, p ...Part) Part {
+	if k < 0 || k > len(p) {
+		panic("k must be in [0, len(p)]")
+	}
+
+	return shuffle{
+		parts: p,
+		len:   uint32(len(p)),
+		k:     uint32(k),
 	}
 }
 
 type shuffle struct {
 	parts []Part
 	len   uint32
+	// k is the number of Parts that are output.
+	k uint32
 }
 
 func (p shuffle) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
 
-	// Fisher-Yates shuffle: https://en.wikipedia.org/wiki/Fisher%E2%80%93Yates_shuffle
-	for i := p.len - 1; i > 0; i-- {
-		j := internal.RandN(i + 1)
-		p.parts[i], p.parts[j] = p.parts[j], p.parts[i]
+func (p shuffle) appendRun(r *run, b []byte) []byte {
+	if p.k == 0 {
+		return b
 	}
 
+	// Shuffle indices instead of p.parts, so the permutation only depends on the current iteration.
+	var buf [16]uint32
+	idx := buf[:0]
+	if p.len > uint32(len(buf)) {
+		idx = make([]uint32, 0, p.len)
+	}
 	for i := uint32(0); i < p.len; i++ {
-		b = p.parts[i].Append(b)
+		idx = append(idx, i)
+	}
+
+	// Fisher-Yates shuffle: https://en.wikipedia.org/wiki/Fisher%E2%80%93Yates_shuffle
+	// Only the last k positions are needed, which hold a random sample of size k in random order.
+	for i := p.len - 1; i > 0 && i >= p.len-p.k; i-- {
+		j := r.randN(i + 1)
+		idx[i], idx[j] = idx[j], idx[i]
+	}
+
+	for _, i := range idx[p.len-p.k:] {
+		b = appendRun(r, p.parts[i], b)
 	}
 
 	return b
@@ -313,17 +714,118 @@ func (p shuffle) Append(b []byte) []byte {
 // Sequence returns a Part that will on each iteration increment a number from start to max.
 // The number will be zero-padded to width.
 // The output number will reset to start when max is reached.
-func Sequence(start uint64, max uint64, width int) Part {
+// The counting behaviour can be changed with SequenceOptions.
+func Sequence(start uint64, max uint64, width int, opts ...SequenceOption) Part {
 	if max < start {
 		panic("max must be >= min")
 	}
 
-	var curr uint64 = start - 1
-	return sequence{
+	p := sequence{
 		start: start,
 		max:   max,
 		width: width,
-		curr:  &curr,
+		step:  1,
+	}
+
+	for _, opt := range opts {
+		opt(&p)
+	}
+
+	// Initialize the counter one step before the first number,
+	// unless it was resumed from a previous value.
+	if p.curr == nil {
+		var curr uint64 = start - p.step
+		if p.desc {
+			curr = max + p.step
+		}
+		p.curr = &curr
+	}
+
+	return p
+}
+
+// SequenceBase returns a Part that behaves like Sequence, but encodes the number with the digits of alphabet instead of decimal digits.
+// The base of the number is len(alphabet) and the number will be padded with alphabet[0] to width.
+// For example, the alphabet "0123456789ABCDEFGHJKMNPQRSTVWXYZ" outputs Crockford's base32.
+//
+// Panics if alphabet has less than 2 or more than 256 digits.
+func SequenceBase(start uint64, max uint64, width int, alphabet []byte, opts ...SequenceOption) Part {
+	if len(alphabet) < 2 || len(alphabet) > 256 {
+		panic("alphabet must have between 2 and 256 digits")
+	}
+
+	p := Sequence(start, max, width, opts...).(sequence)
+	p.alphabet = string(alphabet)
+	return p
+}
+
+// SequenceOption changes the counting behaviour of a Sequence.
+type SequenceOption func(*sequence)
+
+// SequenceStep makes a Sequence increment (or decrement) the number by step instead of 1.
+// The number resets as soon as the next step would leave [start, max].
+//
+// Panics if step is 0.
+func SequenceStep(step uint64) SequenceOption {
+	if step == 0 {
+		panic("step must be > 0")
+	}
+
+	return func(p *sequence) {
+		p.step = step
+	}
+}
+
+// SequenceDescending makes a Sequence count down from max to start.
+// The output number will reset to max when start is reached.
+func SequenceDescending() SequenceOption {
+	return func(p *sequence) {
+		p.desc = true
+	}
+}
+
+// SequenceSkip makes a Sequence skip the reserved values.
+func SequenceSkip(values ...uint64) SequenceOption {
+	reserved := make(map[uint64]struct{}, len(values))
+	for _, v := range values {
+		reserved[v] = struct{}{}
+	}
+
+	skip := SequenceSkipFunc(func(u uint64) bool {
+		_, ok := reserved[u]
+		return ok
+	})
+
+	return func(p *sequence) {
+		skip(p)
+		p.reserved = append([]uint64{}, values...)
+	}
+}
+
+// SequenceSkipFunc makes a Sequence skip all values for which skip returns true.
+// The Sequence panics during generation if every value in [start, max] is skipped.
+func SequenceSkipFunc(skip func(uint64) bool) SequenceOption {
+	return func(p *sequence) {
+		p.skip = skip
+		p.reserved = nil
+	}
+}
+
+// SequenceResume makes a Sequence continue after last, which is usually the last number generated before a restart.
+// Use it together with SequenceOnUpdate to persist the state of a Sequence.
+func SequenceResume(last uint64) SequenceOption {
+	return func(p *sequence) {
+		// Copy last, so that Sequences created with the same option do not share their counter.
+		curr := last
+		p.curr = &curr
+	}
+}
+
+// SequenceOnUpdate makes a Sequence call f with every number it generates.
+// When the Sequence is used concurrently, f may be called concurrently and observe the numbers out of order.
+func SequenceOnUpdate(f func(uint64)) SequenceOption {
+	return func(p *sequence) {
+		p.onUpdate = f
 	}
 }
 
@@ -332,22 +834,100 @@ type sequence struct {
 	max   uint64
 	width int
 	curr  *uint64
+	// alphabet holds the digits of non-decimal sequences.
+	alphabet string
+	step     uint64
+	desc     bool
+	skip     func(uint64) bool
+	// reserved holds the values of SequenceSkip, which are used to marshal the Sequence.
+	reserved []uint64
+	onUpdate func(uint64)
 }
 
 func (p sequence) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p sequence) appendRun(r *run, b []byte) []byte {
+	if r.pure() {
+		return p.format(b, p.draw(r))
+	}
+
 	for {
 		last := atomic.LoadUint64(p.curr)
-		curr := last + 1
-		if curr > p.max || curr < p.start {
-			curr = p.start
-		}
+		curr := p.next(last)
 
 		if atomic.CompareAndSwapUint64(p.curr, last, curr) {
-			return appendInt(b, curr, p.width)
+			if p.onUpdate != nil {
+				p.onUpdate(curr)
+			}
+			return p.format(b, curr)
+		}
+	}
+}
+
+func (p sequence) format(b []byte, u uint64) []byte {
+	if p.alphabet != "" {
+		return appendUintBase(b, u, p.width, p.alphabet)
+	}
+	return appendInt(b, u, p.width)
+}
+
+// draw returns a random number of the sequence without advancing it.
+func (p sequence) draw(r *run) uint64 {
+	// Number of steps in [start, max], 0 if it covers all uint64.
+	n := (p.max-p.start)/p.step + 1
+	for i := 0; ; i++ {
+		var u uint64
+		if p.desc {
+			u = p.max - r.uint64N(n)*p.step
+		} else {
+			u = p.start + r.uint64N(n)*p.step
+		}
+
+		if p.skip == nil || !p.skip(u) {
+			return u
+		}
+
+		// Most values are skipped, so scan for the next value that is not.
+		if i > 1000 {
+			return p.next(u)
 		}
 	}
 }
 
+// next returns the number following last.
+func (p sequence) next(last uint64) uint64 {
+	resets := 0
+	for {
+		var curr uint64
+		if p.desc {
+			curr = last - p.step
+			// Also reset on underflow.
+			if curr < p.start || curr > p.max || curr > last {
+				curr = p.max
+				resets++
+			}
+		} else {
+			curr = last + p.step
+			// Also reset on overflow.
+			if curr > p.max || curr < p.start || curr < last {
+				curr = p.start
+				resets++
+			}
+		}
+
+		if p.skip == nil || !p.skip(curr) {
+			return curr
+		}
+
+		if resets > 1 {
+			panic("sequence skips all values")
+		}
+		last = curr
+	}
+}
+
 func appendInt(b []byte, u uint64, width int) []byte {
 	// Compute the number of decimal digits.
 	var n int
@@ -385,3 +965,34 @@ func appendInt(b []byte, u uint64, width int) []byte {
 func itob(u uint64) byte {
 	return '0' + byte(u)
 }
+
+// appendUintBase appends u encoded with the digits of alphabet, where the base is len(alphabet).
+// The number will be padded with alphabet[0] to width.
+func appendUintBase(b []byte, u uint64, width int, alphabet string) []byte {
+	base := uint64(len(alphabet))
+
+	// Compute the number of digits.
+	n := 1
+	for u2 := u / base; u2 > 0; u2 /= base {
+		n++
+	}
+
+	// Add padding.
+	for pad := width - n; pad > 0; pad-- {
+		b = append(b, alphabet[0])
+	}
+
+	// Ensure capacity.
+	if len(b)+n <= cap(b) {
+		b = b[:len(b)+n]
+	} else {
+		b = append(b, make([]byte, n)...)
+	}
+
+	// Assemble digits in reverse order.
+	for i := len(b) - 1; n > 0; i, n = i-1, n-1 {
+		b[i] = alphabet[u%base]
+		u /= base
+	}
+	return b
+}
@@ -3,8 +3,17 @@ package pattern
 import (
 	"fmt"
 	"math"
+	"math/rand"
+	"reflect"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sollniss/pattern/alphabets"
 )
 
 var id string
@@ -14,7 +23,7 @@ func BenchmarkString(b *testing.B) {
 		Repeat(2, 5,
 			Repeat(5, 5, OneOfByte([]byte("1234567890"))),
 			Literal("-"),
-			Repeat(3, 6, OneOfRune([]rune("あいうえおかきくけこさしすせそたちつてとなにぬねのはひふへほまみむめもやゆよらりるれろわをん"))),
+			Repeat(3, 6, OneOfRune(alphabets.Hiragana)),
 			Literal("-"),
 		),
 		OneOfString([]string{"asd", "fgh", "jkl"}),
@@ -36,6 +45,7 @@ func BenchmarkRepeat(b *testing.B) {
 		{"Repeat(50,100)", New(Repeat(50, 100, Literal("-")))},
 		{"Optional()", New(Potentially(0.5, Literal("-")))},
 		{"Repeat(0, 1)", New(Repeat(0, 1, Literal("-")))},
+		{"RepeatSep(50,100)", New(RepeatSep(50, 100, "-", Literal("x")))},
 	}
 
 	for _, bb := range benchs {
@@ -112,6 +122,31 @@ func TestGroup(t *testing.T) {
 	}
 }
 
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Part
+		want string
+	}{
+		{"empty", Join("-"), ""},
+		{"single", Join("-", Literal("a")), "a"},
+		{"groups", Join("-", Literal("AAA"), Literal("BBB"), Literal("CCC")), "AAA-BBB-CCC"},
+		{"no separator", Join("", Literal("a"), Literal("b")), "ab"},
+	}
+
+	for _, tt := range tests {
+		if got := New(tt.p).String(); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// The Join stays analyzable.
+	g := New(Join(", ", OneOfByte([]byte("ab")), OneOfByte([]byte("xy"))))
+	if n, ok := g.count(); !ok || n != 4 {
+		t.Errorf("Join generates %d values, want 4", n)
+	}
+}
+
 func TestRepeat(t *testing.T) {
 	tests := []struct {
 		name string
@@ -156,6 +191,71 @@ func TestRepeat(t *testing.T) {
 	}
 }
 
+func TestRepeatSep(t *testing.T) {
+	tests := []struct {
+		name string
+		min  uint32
+		max  uint32
+	}{
+		{"optional", 0, 1},
+		{"single", 1, 1},
+		{"const length", 3, 3},
+		{"from zero", 0, 3},
+		{"from one", 1, 2},
+		{"normal", 2, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New(RepeatSep(tt.min, tt.max, "-", Literal("ab")))
+
+			counts := map[uint32]int{}
+			for i := 0; i < 10000; i++ {
+				s := g.String()
+				n := uint32(0)
+				if s != "" {
+					n = uint32(strings.Count(s, "ab"))
+					if s != strings.Repeat("ab-", int(n)-1)+"ab" {
+						t.Fatalf("RepeatSep returned invalid value %q", s)
+					}
+				}
+				if n < tt.min || n > tt.max {
+					t.Fatalf("RepeatSep has invalid repetitions: want [%d,%d], got %d", tt.min, tt.max, n)
+				}
+				counts[n]++
+			}
+
+			// The number of repetitions is uniform.
+			want := 10000 / int(tt.max-tt.min+1)
+			for n := tt.min; n <= tt.max; n++ {
+				if c := counts[n]; c < want*8/10 || c > want*12/10 {
+					t.Errorf("RepeatSep returned %d repetitions %d times, want about %d", n, c, want)
+				}
+			}
+
+			if c, ok := g.count(); !ok || c != uint64(tt.max-tt.min+1) {
+				t.Errorf("RepeatSep generates %d values, want %d", c, tt.max-tt.min+1)
+			}
+		})
+	}
+}
+
+func TestRepeatAlternate(t *testing.T) {
+	g := New(Repeat(3, 3, OneOfByte(AlphaUpper), OneOfByte(Digits)))
+
+	for i := 0; i < 100; i++ {
+		s := g.String()
+		if len(s) != 6 {
+			t.Fatalf("Repeat returned %q, want 6 characters", s)
+		}
+		for j := 0; j < len(s); j += 2 {
+			if !unicode.IsUpper(rune(s[j])) || !unicode.IsDigit(rune(s[j+1])) {
+				t.Fatalf("Repeat returned %q, want alternating letters and digits", s)
+			}
+		}
+	}
+}
+
 func TestRepeatEmpty(t *testing.T) {
 	gen := New(Repeat(10, 100))
 	p := gen.String()
@@ -435,6 +535,7 @@ func TestSequenceUint64Overflow(t *testing.T) {
 		max:   uint64(math.MaxUint64),
 		width: 0,
 		curr:  &val,
+		step:  1,
 	})
 
 	v := gen.String()
@@ -470,6 +571,94 @@ func TestShuffle(t *testing.T) {
 	}
 }
 
+func TestShuffleEmpty(t *testing.T) {
+	if v := New(Shuffle()).String(); v != "" {
+		t.Errorf("Shuffle without Parts returned invalid value: want \"\", got %s", strconv.Quote(v))
+	}
+}
+
+func TestSampleK(t *testing.T) {
+	gen := New(SampleK(2, Literal("a"), Literal("b"), Literal("c")))
+
+	hitmap := map[string]bool{
+		"ab": false,
+		"ac": false,
+		"ba": false,
+		"bc": false,
+		"ca": false,
+		"cb": false,
+	}
+
+	for i := 0; i < 200; i++ {
+		v := gen.String()
+		if _, ok := hitmap[v]; !ok {
+			t.Errorf("SampleK returned invalid sample of Literals \"a\", \"b\", \"c\": got %s", strconv.Quote(v))
+		}
+		hitmap[v] = true
+	}
+
+	for v, found := range hitmap {
+		if !found {
+			t.Errorf("SampleK with Literals \"a\", \"b\", \"c\" never returned %s", strconv.Quote(v))
+		}
+	}
+
+	if v := New(SampleK(0, Literal("a"))).String(); v != "" {
+		t.Errorf("SampleK(0) returned invalid value: want \"\", got %s", strconv.Quote(v))
+	}
+}
+
+func TestSampleKPanic(t *testing.T) {
+	for _, k := range []int{-1, 3} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("SampleK did not panic on k = %d", k)
+				}
+			}()
+			SampleK(k, Literal("a"), Literal("b"))
+		}()
+	}
+}
+
+// TestConcurrentUse is meant to be run with -race.
+func TestConcurrentUse(t *testing.T) {
+	gen := New(
+		Shuffle(Literal("a"), Literal("b"), Literal("c"), Literal("d")),
+		Literal("-"),
+		SampleK(2, Literal("e"), Literal("f"), Literal("g")),
+		Literal("-"),
+		Sequence(0, 999, 3),
+		Cycle(Literal("x"), Literal("y")),
+		RepeatDistinct(2, 2, OneOfByte(Digits)),
+		ShuffleRunes(Literal("äöü")),
+	)
+
+	const workers, n = 8, 200
+	var wg sync.WaitGroup
+	errs := make(chan string, workers*n)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				v := gen.String()
+				perm := []byte(strings.SplitN(v, "-", 2)[0])
+				sort.Slice(perm, func(i, j int) bool { return perm[i] < perm[j] })
+				if string(perm) != "abcd" {
+					errs <- v
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for v := range errs {
+		t.Errorf("Shuffle returned invalid permutation when used concurrently: %s", strconv.Quote(v))
+	}
+}
+
 // Not a real test, just a way to preview generated strings.
 func TestPreviewID(t *testing.T) {
 	t.Skip()
@@ -490,3 +679,235 @@ func TestPreviewID(t *testing.T) {
 		t.Error(gen.String())
 	}
 }
+
+func TestStringFor(t *testing.T) {
+	gen := New(
+		Repeat(2, 5, OneOfByte([]byte("1234567890"))),
+		Potentially(0.5, Literal("-")),
+		Potentially(0.3, OneOfString([]string{"aaaa", "bbbb", "cccc", "dddd"})),
+		OneOf(Literal("x"), Literal("y"), Literal("z")),
+		OneOfRune([]rune("あいうえお")),
+		Shuffle(Literal("a"), Literal("b"), Literal("c")),
+		NanoID(5, nil),
+	)
+
+	seen := make(map[string]bool, 100)
+	for i := 0; i < 100; i++ {
+		key := []byte(strconv.Itoa(i))
+		v1 := gen.StringFor(key)
+		v2 := gen.StringFor(key)
+		if v1 != v2 {
+			t.Errorf("StringFor returned different values for the same key: %s != %s", strconv.Quote(v1), strconv.Quote(v2))
+		}
+		seen[v1] = true
+	}
+
+	if len(seen) < 90 {
+		t.Errorf("StringFor returned too many equal values for different keys: want at least 90 distinct values, got %d", len(seen))
+	}
+}
+
+// zeroSource always returns 0.
+type zeroSource struct{}
+
+func (zeroSource) Uint64() uint64 { return 0 }
+
+func TestStringFrom(t *testing.T) {
+	gen := New(
+		Repeat(2, 5, OneOfByte([]byte("1234567890"))),
+		Potentially(0.5, Literal("-")),
+		OneOf(Literal("x"), Literal("y"), Literal("z")),
+		Sequence(10, 99, 0),
+	)
+
+	// A source of zeros selects the first choice everywhere.
+	if v := StringFrom(gen, zeroSource{}); v != "11x10" {
+		t.Errorf("StringFrom returned invalid value: want \"11x10\", got %s", strconv.Quote(v))
+	}
+
+	a := StringFrom(gen, rand.New(rand.NewSource(1)))
+	b := StringFrom(gen, rand.New(rand.NewSource(1)))
+	if a != b {
+		t.Errorf("StringFrom returned different values for the same source: %s != %s", strconv.Quote(a), strconv.Quote(b))
+	}
+}
+
+func TestStringForSequence(t *testing.T) {
+	gen := New(Sequence(1, 100, 0))
+	for _, want := range []string{"1", "2"} {
+		p := gen.StringFor([]byte("key"))
+		if p != want {
+			t.Errorf("StringFor returned invalid value: want %s, got %s", strconv.Quote(want), strconv.Quote(p))
+		}
+	}
+}
+
+func TestTransform(t *testing.T) {
+	gen := New(
+		Literal("user-"),
+		Repeat(3, 8, OneOfByte([]byte("abcdefghijklmnopqrstuvwxyz"))),
+		Literal("-"),
+		Sequence(1, 9999, 4),
+		Literal("-"),
+		SequenceBase(0, 1000, 0, []byte("01"), SequenceStep(7), SequenceDescending()),
+		Literal("-"),
+		PermutedSequence(0, 99, 2, 1),
+	)
+
+	inputs := []string{"alice@example.com", "bob@example.com", "carol@example.com", ""}
+	results := make(map[string]bool, len(inputs))
+	for _, in := range inputs {
+		v1 := gen.Transform(in)
+		v2 := gen.Transform(in)
+		if v1 != v2 {
+			t.Errorf("Transform returned different values for the same input: %s != %s", strconv.Quote(v1), strconv.Quote(v2))
+		}
+		results[v1] = true
+	}
+
+	if len(results) != len(inputs) {
+		t.Errorf("Transform returned equal values for different inputs: want %d distinct values, got %d", len(inputs), len(results))
+	}
+
+	// Transform does not advance the Sequence.
+	gen = New(Sequence(1, 9999, 4))
+	for _, in := range inputs {
+		id = gen.Transform(in)
+	}
+	p := gen.String()
+	if p != "0001" {
+		t.Errorf("Sequence returned invalid value after Transform: want \"0001\", got %s", strconv.Quote(p))
+	}
+}
+
+func TestBytes(t *testing.T) {
+	gen := New(Literal("o"), Repeat(2, 2, Literal("x")))
+
+	b1 := gen.Bytes()
+	b2 := gen.Bytes()
+	if string(b1) != "oxx" {
+		t.Errorf("Bytes returned invalid value: want \"oxx\", got %s", strconv.Quote(string(b1)))
+	}
+
+	// The slices must not share memory.
+	b1[0] = 'a'
+	if string(b2) != "oxx" {
+		t.Errorf("Bytes returned shared slice")
+	}
+}
+
+func BenchmarkBytes(b *testing.B) {
+	gen := New(Repeat(20, 20, OneOfByte([]byte("abcdefghijklmnopqrstuvwxyz"))))
+	for i := 0; i < b.N; i++ {
+		_ = gen.Bytes()
+	}
+}
+
+func TestOneOfByteRange(t *testing.T) {
+	p := OneOfByteRange('a', 'e')
+	seen := map[byte]bool{}
+	for i := 0; i < 1000; i++ {
+		v := p.Append(nil)
+		if len(v) != 1 || v[0] < 'a' || v[0] > 'e' {
+			t.Fatalf("OneOfByteRange returned invalid value %q", v)
+		}
+		seen[v[0]] = true
+	}
+	if len(seen) != 5 {
+		t.Errorf("got %d distinct bytes, want 5", len(seen))
+	}
+
+	if v := OneOfByteRange(0, 255).(byteRange).n; v != 256 {
+		t.Errorf("got %d bytes in full range, want 256", v)
+	}
+
+	gen := New(OneOfByteRange('0', '9'), OneOfByteRange(0xf0, 0xff))
+	if v := gen.String(); !Match(gen, v) {
+		t.Errorf("%q does not match", v)
+	}
+	if c, ok := gen.count(); c != 160 || !ok {
+		t.Errorf("got %d outputs, want 160", c)
+	}
+}
+
+func TestOneOfRuneRange(t *testing.T) {
+	tests := []struct {
+		lo, hi rune
+		pairs  []rune
+	}{
+		{'a', 'z', []rune{'a', 'z'}},
+		{0x4E00, 0x9FFF, []rune{0x4E00, 0x9FFF}},
+		{0xD000, 0xE100, []rune{0xD000, 0xD7FF, 0xE000, 0xE100}},
+		{0xDC00, 0xE000, []rune{0xE000, 0xE000}},
+		{0, utf8.MaxRune, []rune{0, 0xD7FF, 0xE000, utf8.MaxRune}},
+	}
+
+	for _, test := range tests {
+		p := OneOfRuneRange(test.lo, test.hi).(runeRanges)
+		if !reflect.DeepEqual(p.pairs, test.pairs) {
+			t.Errorf("OneOfRuneRange(%#x, %#x) has ranges %#x, want %#x", test.lo, test.hi, p.pairs, test.pairs)
+		}
+		for i := 0; i < 100; i++ {
+			r, _ := utf8.DecodeRune(p.Append(nil))
+			if r < test.lo || r > test.hi || !utf8.ValidRune(r) {
+				t.Fatalf("OneOfRuneRange(%#x, %#x) returned invalid rune %#x", test.lo, test.hi, r)
+			}
+		}
+	}
+
+	for _, r := range [][2]rune{{2, 1}, {-1, 5}, {0, utf8.MaxRune + 1}, {0xD800, 0xDFFF}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("OneOfRuneRange(%#x, %#x) did not panic", r[0], r[1])
+				}
+			}()
+			OneOfRuneRange(r[0], r[1])
+		}()
+	}
+}
+
+func TestOneOfUnicode(t *testing.T) {
+	tests := []struct {
+		name  string
+		tab   *unicode.RangeTable
+		count uint64
+	}{
+		{"Greek", unicode.Greek, 0},
+		{"Han", unicode.Han, 0},
+		{"Lu", unicode.Lu, 0},
+		{"Nd", unicode.Nd, 0},
+		{"ASCII_Hex_Digit", unicode.ASCII_Hex_Digit, 22},
+	}
+
+	for _, test := range tests {
+		p := OneOfUnicode(test.tab)
+
+		// Every rune of the table can be generated exactly once.
+		want := test.count
+		if want == 0 {
+			for r := rune(0); r <= unicode.MaxRune; r++ {
+				if unicode.Is(test.tab, r) {
+					want++
+				}
+			}
+		}
+		if c, _ := p.(countable).count(); c != want {
+			t.Errorf("%s: got %d runes, want %d", test.name, c, want)
+		}
+
+		for i := 0; i < 100; i++ {
+			r, _ := utf8.DecodeRune(p.Append(nil))
+			if !unicode.Is(test.tab, r) {
+				t.Fatalf("%s: got rune %q outside of the table", test.name, r)
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("OneOfUnicode did not panic on an empty table")
+		}
+	}()
+	OneOfUnicode(&unicode.RangeTable{})
+}
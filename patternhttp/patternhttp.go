@@ -0,0 +1,90 @@
+// Package patternhttp serves generated patterns over HTTP.
+package patternhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sollniss/pattern"
+)
+
+// MaxBatch is the maximum number of values that can be requested at once.
+const MaxBatch = 10000
+
+// Handler returns a handler that responds to GET and HEAD requests with values generated by p.
+// HEAD requests only return the headers without generating values.
+//
+// The number of values is set with the query parameter n (default 1, at most MaxBatch).
+// The values are returned as plain text, one per line, or as a JSON array of strings
+// if the query parameter format is "json" or the Accept header contains "application/json".
+//
+// The handler is safe for concurrent use if p is.
+func Handler(p pattern.Part) http.Handler {
+	return handler{
+		part: p,
+	}
+}
+
+type handler struct {
+	part pattern.Part
+}
+
+func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+
+	n := 1
+	if s := q.Get("n"); s != "" {
+		var err error
+		n, err = strconv.Atoi(s)
+		if err != nil || n < 1 || n > MaxBatch {
+			http.Error(w, "n must be a number in [1, "+strconv.Itoa(MaxBatch)+"]", http.StatusBadRequest)
+			return
+		}
+	}
+
+	asJSON := q.Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json")
+
+	w.Header().Set("Cache-Control", "no-store")
+	if asJSON {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+
+	// HEAD requests do not generate values, so stateful Parts like Sequence are not advanced.
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	var b []byte
+	if asJSON {
+		values := make([]string, n)
+		for i := range values {
+			b = h.part.Append(b[:0])
+			values[i] = string(b)
+		}
+
+		var err error
+		b, err = json.Marshal(values)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		for i := 0; i < n; i++ {
+			b = h.part.Append(b)
+			b = append(b, '\n')
+		}
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+	_, _ = w.Write(b)
+}
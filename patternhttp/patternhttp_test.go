@@ -0,0 +1,142 @@
+package patternhttp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sollniss/pattern"
+)
+
+func newTestServer() *httptest.Server {
+	return httptest.NewServer(Handler(pattern.New(
+		pattern.Literal("ID-"),
+		pattern.Shuffle(pattern.Literal("a"), pattern.Literal("b"), pattern.Literal("c")),
+		pattern.Sequence(1, 1<<32, 0),
+	)))
+}
+
+func get(t *testing.T, url string, accept string) (*http.Response, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res, string(body)
+}
+
+func TestHandler(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	res, body := get(t, srv.URL, "")
+	if res.StatusCode != http.StatusOK || !strings.HasPrefix(body, "ID-") || strings.Count(body, "\n") != 1 {
+		t.Errorf("Handler returned invalid single value: %d %q", res.StatusCode, body)
+	}
+
+	res, body = get(t, srv.URL+"?n=5", "")
+	if res.StatusCode != http.StatusOK || strings.Count(body, "\n") != 5 {
+		t.Errorf("Handler returned invalid batch: %d %q", res.StatusCode, body)
+	}
+
+	for _, tt := range []struct{ query, accept string }{{"?n=3&format=json", ""}, {"?n=3", "application/json"}} {
+		res, body = get(t, srv.URL+tt.query, tt.accept)
+		var values []string
+		if err := json.Unmarshal([]byte(body), &values); err != nil || len(values) != 3 {
+			t.Errorf("Handler returned invalid JSON batch: %q", body)
+		}
+		if ct := res.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Handler returned invalid content type: want \"application/json\", got %q", ct)
+		}
+	}
+}
+
+func TestHandlerError(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	for _, query := range []string{"?n=0", "?n=abc", "?n=10001"} {
+		res, _ := get(t, srv.URL+query, "")
+		if res.StatusCode != http.StatusBadRequest {
+			t.Errorf("Handler with query %s returned invalid status: want %d, got %d", query, http.StatusBadRequest, res.StatusCode)
+		}
+	}
+
+	res, err := http.Post(srv.URL, "text/plain", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Handler with POST returned invalid status: want %d, got %d", http.StatusMethodNotAllowed, res.StatusCode)
+	}
+}
+
+func TestHandlerConcurrent(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, body := get(t, srv.URL+"?n=100", "")
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, v := range strings.Fields(body) {
+				if seen[v] {
+					t.Errorf("Handler returned duplicate value %q", v)
+				}
+				seen[v] = true
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(seen) != 800 {
+		t.Errorf("Handler returned invalid number of values: want 800, got %d", len(seen))
+	}
+}
+
+func TestHandlerHead(t *testing.T) {
+	srv := httptest.NewServer(Handler(pattern.New(pattern.Sequence(1, 100, 0))))
+	defer srv.Close()
+
+	res, err := http.Head(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "text/plain; charset=utf-8" {
+		t.Errorf("Handler returned invalid response to HEAD: %d %q", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+
+	// The HEAD request did not advance the Sequence.
+	if _, body := get(t, srv.URL, ""); body != "1\n" {
+		t.Errorf("Handler advanced the Sequence on HEAD: got %q, want %q", body, "1\n")
+	}
+}
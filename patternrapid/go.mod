@@ -0,0 +1,10 @@
+module github.com/sollniss/pattern/patternrapid
+
+go 1.23
+
+require (
+	github.com/sollniss/pattern v0.0.0
+	pgregory.net/rapid v1.3.0
+)
+
+replace github.com/sollniss/pattern => ../
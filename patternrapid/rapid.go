@@ -0,0 +1,29 @@
+// Package patternrapid provides generators for property-based tests with pgregory.net/rapid.
+package patternrapid
+
+import (
+	"math/bits"
+
+	"github.com/sollniss/pattern"
+	"pgregory.net/rapid"
+)
+
+// Generator returns a rapid generator of patterns generated by p.
+// All random choices of p are drawn from rapid, so rapid shrinks failing patterns along with the rest of the test case,
+// e.g. towards fewer repetitions and earlier alphabet members.
+func Generator(p pattern.Part) *rapid.Generator[string] {
+	return rapid.Custom(func(t *rapid.T) string {
+		return pattern.StringFrom(p, source{t})
+	})
+}
+
+// source draws random numbers from rapid.
+type source struct {
+	t *rapid.T
+}
+
+func (s source) Uint64() uint64 {
+	// rapid prefers small numbers and shrinks towards 0, but the Parts mostly use the high bits.
+	// Reversing the bits keeps both properties for the Parts.
+	return bits.Reverse64(rapid.Uint64().Draw(s.t, "pattern"))
+}
@@ -0,0 +1,73 @@
+package patternrapid
+
+import (
+	"flag"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/sollniss/pattern"
+	"pgregory.net/rapid"
+)
+
+func TestGenerator(t *testing.T) {
+	gen := pattern.MustCompile(`user-[a-z]{3,8}(-[0-9]{2})?`)
+	re := regexp.MustCompile(`^user-[a-z]{3,8}(-[0-9]{2})?$`)
+
+	seen := make(map[string]bool)
+	rapid.Check(t, func(t *rapid.T) {
+		s := Generator(gen).Draw(t, "id")
+		if !re.MatchString(s) {
+			t.Fatalf("Generator returned invalid value: %q", s)
+		}
+		seen[s] = true
+	})
+
+	if len(seen) < 50 {
+		t.Errorf("Generator returned too few distinct values: %d", len(seen))
+	}
+}
+
+func TestGeneratorShrink(t *testing.T) {
+	gen := pattern.New(
+		pattern.Repeat(1, 20, pattern.OneOfByte([]byte("abcxyz"))),
+		pattern.Sequence(1, 999, 3),
+	)
+
+	// The property fails for every value containing a z, which rapid should shrink to the simplest one.
+	var failed string
+	prop := func(t *rapid.T) {
+		s := Generator(gen).Draw(t, "id")
+		if strings.Contains(s, "z") {
+			failed = s
+			t.Fatalf("contains z: %q", s)
+		}
+	}
+
+	// Don't leave fail files of the expected failure behind.
+	flag.Set("rapid.nofailfile", "true")
+	t.Cleanup(func() { flag.Set("rapid.nofailfile", "false") })
+
+	ok := t.Run("prop", func(t *testing.T) {
+		rapid.Check(&failingT{T: t}, prop)
+	})
+	if !ok {
+		t.Fatal("property test failed unexpectedly")
+	}
+
+	if failed != "z001" {
+		t.Errorf("Generator did not shrink: want \"z001\", got %q", failed)
+	}
+}
+
+// failingT swallows the expected failure of a property.
+type failingT struct {
+	*testing.T
+}
+
+func (t *failingT) Error(args ...any)                 {}
+func (t *failingT) Errorf(format string, args ...any) {}
+func (t *failingT) Fatal(args ...any)                 { t.SkipNow() }
+func (t *failingT) Fatalf(format string, args ...any) { t.SkipNow() }
+func (t *failingT) Fail()                             {}
+func (t *failingT) FailNow()                          { t.SkipNow() }
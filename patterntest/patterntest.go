@@ -0,0 +1,57 @@
+// Package patterntest provides assertions for tests of patterns.
+package patterntest
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/sollniss/pattern"
+)
+
+// Samples is the number of patterns generated by AssertLenBounds.
+const Samples = 1000
+
+// AssertMatches reports an error if s is not a possible output of p.
+// It returns whether the assertion succeeded.
+func AssertMatches(t testing.TB, p pattern.Part, s string) bool {
+	t.Helper()
+
+	if !pattern.Match(p, s) {
+		t.Errorf("%s does not match the pattern", strconv.Quote(s))
+		return false
+	}
+	return true
+}
+
+// AssertLenBounds generates Samples patterns with p and reports an error if the length of any of them is not in [min, max].
+// It returns whether the assertion succeeded.
+func AssertLenBounds(t testing.TB, p pattern.Part, min int, max int) bool {
+	t.Helper()
+
+	b := make([]byte, 0, 100)
+	for i := 0; i < Samples; i++ {
+		b = p.Append(b[:0])
+		if len(b) < min || len(b) > max {
+			t.Errorf("length of %s is not in [%d, %d]: got %d", strconv.Quote(string(b)), min, max, len(b))
+			return false
+		}
+	}
+	return true
+}
+
+// AssertCoverage generates n patterns with p and reports an error if any choice of a OneOf, OneOfString, OneOfByte or OneOfRune Part in p was never selected.
+// It returns whether the assertion succeeded.
+func AssertCoverage(t testing.TB, p pattern.Part, n int) bool {
+	t.Helper()
+
+	ok := true
+	for i, counts := range pattern.Coverage(p, n) {
+		for j, c := range counts {
+			if c == 0 {
+				t.Errorf("choice %d of OneOf Part %d was not selected in %d patterns", j, i, n)
+				ok = false
+			}
+		}
+	}
+	return ok
+}
@@ -0,0 +1,66 @@
+package patterntest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sollniss/pattern"
+)
+
+// recorder records the errors of an assertion.
+type recorder struct {
+	testing.TB
+	errors int
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors++
+}
+
+func TestAssertMatches(t *testing.T) {
+	gen := pattern.New(pattern.Literal("id-"), pattern.Sequence(1, 99, 2))
+
+	r := &recorder{TB: t}
+	if !AssertMatches(r, gen, "id-42") || r.errors != 0 {
+		t.Errorf("AssertMatches failed on matching value")
+	}
+
+	if AssertMatches(r, gen, "id-4") || r.errors != 1 {
+		t.Errorf("AssertMatches did not fail on invalid value")
+	}
+
+	// Ambiguous patterns must not take exponential time.
+	amb := pattern.Repeat(1, 60, pattern.OneOfString([]string{"a", "aa"}))
+	if AssertMatches(r, amb, strings.Repeat("a", 32)+"b") || r.errors != 2 {
+		t.Errorf("AssertMatches did not fail on invalid value")
+	}
+}
+
+func TestAssertLenBounds(t *testing.T) {
+	gen := pattern.New(pattern.Repeat(2, 5, pattern.Literal("x")))
+
+	r := &recorder{TB: t}
+	if !AssertLenBounds(r, gen, 2, 5) || r.errors != 0 {
+		t.Errorf("AssertLenBounds failed on valid bounds")
+	}
+
+	if AssertLenBounds(r, gen, 3, 5) || r.errors != 1 {
+		t.Errorf("AssertLenBounds did not fail on invalid bounds")
+	}
+}
+
+func TestAssertCoverage(t *testing.T) {
+	r := &recorder{TB: t}
+
+	gen := pattern.New(pattern.OneOfString([]string{"a", "b", "c"}))
+	if !AssertCoverage(r, gen, 1000) || r.errors != 0 {
+		t.Errorf("AssertCoverage failed on reachable choices")
+	}
+
+	// A single pattern selects only one of the three choices.
+	if AssertCoverage(r, gen, 1) || r.errors != 2 {
+		t.Errorf("AssertCoverage did not fail on unselected choices: got %d errors", r.errors)
+	}
+}
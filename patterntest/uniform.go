@@ -0,0 +1,188 @@
+package patterntest
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/sollniss/pattern"
+)
+
+// Alpha is the significance level of the uniformity assertions.
+// An assertion fails if the probability of the observed counts under a uniform distribution is below Alpha.
+const Alpha = 0.001
+
+// ChiSquare returns Pearson's chi-square statistic of counts against a uniform distribution and its p-value.
+// A small p-value indicates that the counts are not uniformly distributed.
+func ChiSquare(counts []int) (stat float64, p float64) {
+	if len(counts) < 2 {
+		return 0, 1
+	}
+
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	if total == 0 {
+		return 0, 1
+	}
+
+	expected := float64(total) / float64(len(counts))
+	for _, c := range counts {
+		d := float64(c) - expected
+		stat += d * d / expected
+	}
+
+	return stat, gammaQ(float64(len(counts)-1)/2, stat/2)
+}
+
+// KolmogorovSmirnov returns the Kolmogorov-Smirnov statistic of samples in [0, 1) against a uniform distribution and its p-value.
+// A small p-value indicates that the samples are not uniformly distributed.
+// KolmogorovSmirnov sorts samples.
+func KolmogorovSmirnov(samples []float64) (d float64, p float64) {
+	n := len(samples)
+	if n == 0 {
+		return 0, 1
+	}
+
+	sort.Float64s(samples)
+	for i, x := range samples {
+		d = math.Max(d, math.Max(float64(i+1)/float64(n)-x, x-float64(i)/float64(n)))
+	}
+
+	sn := math.Sqrt(float64(n))
+	return d, kolmogorovQ((sn + 0.12 + 0.11/sn) * d)
+}
+
+// AssertUniformChars generates n patterns with p and reports an error if the bytes of alphabet are not uniformly distributed at any position.
+// Positions at which no byte of alphabet occurs are ignored.
+// It returns whether the assertion succeeded.
+func AssertUniformChars(t testing.TB, p pattern.Part, alphabet string, n int) bool {
+	t.Helper()
+
+	var index [256]int
+	for i := range index {
+		index[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		index[alphabet[i]] = i
+	}
+
+	var counts [][]int
+	b := make([]byte, 0, 100)
+	for i := 0; i < n; i++ {
+		b = p.Append(b[:0])
+		for pos, c := range b {
+			for len(counts) <= pos {
+				counts = append(counts, make([]int, len(alphabet)))
+			}
+			if j := index[c]; j >= 0 {
+				counts[pos][j]++
+			}
+		}
+	}
+
+	ok := true
+	for pos, c := range counts {
+		if stat, pv := ChiSquare(c); pv < Alpha {
+			t.Errorf("bytes at position %d are not uniformly distributed: chi-square %.2f, p-value %.2g, counts %v", pos, stat, pv, c)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AssertUniformLen generates n patterns with p and reports an error if their lengths are not uniformly distributed in [min, max],
+// e.g. to verify the distribution of a Repeat of single bytes.
+// It returns whether the assertion succeeded.
+func AssertUniformLen(t testing.TB, p pattern.Part, min int, max int, n int) bool {
+	t.Helper()
+
+	counts := make([]int, max-min+1)
+	b := make([]byte, 0, 100)
+	for i := 0; i < n; i++ {
+		b = p.Append(b[:0])
+		if len(b) < min || len(b) > max {
+			t.Errorf("length of %s is not in [%d, %d]: got %d", strconv.Quote(string(b)), min, max, len(b))
+			return false
+		}
+		counts[len(b)-min]++
+	}
+
+	if stat, pv := ChiSquare(counts); pv < Alpha {
+		t.Errorf("lengths are not uniformly distributed: chi-square %.2f, p-value %.2g, counts %v", stat, pv, counts)
+		return false
+	}
+	return true
+}
+
+// gammaQ returns the regularized upper incomplete gamma function Q(a, x).
+//
+// https://en.wikipedia.org/wiki/Incomplete_gamma_function
+func gammaQ(a float64, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+
+	lg, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lg)
+
+	if x < a+1 {
+		// Series expansion of P(a, x).
+		sum, term := 1/a, 1/a
+		for n := 1; n < 1000; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-15 {
+				break
+			}
+		}
+		return 1 - sum*prefix
+	}
+
+	// Continued fraction of Q(a, x) with the modified Lentz algorithm.
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < 1000; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return h * prefix
+}
+
+// kolmogorovQ returns the complementary cumulative distribution function of the Kolmogorov distribution.
+func kolmogorovQ(lambda float64) float64 {
+	if lambda < 0.2 {
+		return 1
+	}
+
+	var sum float64
+	sign := 1.0
+	for j := 1; j <= 100; j++ {
+		term := sign * math.Exp(-2*float64(j*j)*lambda*lambda)
+		sum += term
+		if math.Abs(term) < 1e-12 {
+			break
+		}
+		sign = -sign
+	}
+	return math.Min(1, math.Max(0, 2*sum))
+}
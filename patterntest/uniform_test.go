@@ -0,0 +1,84 @@
+package patterntest
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/sollniss/pattern"
+)
+
+func TestChiSquare(t *testing.T) {
+	tests := []struct {
+		counts []int
+		stat   float64
+		p      float64
+	}{
+		{[]int{10, 10, 10, 10}, 0, 1},
+		{[]int{20, 10}, 10.0 / 3, 0.0679},
+		{[]int{30, 14, 34, 45, 57, 20}, 37.78, 4.1773e-7},
+		{[]int{5}, 0, 1},
+	}
+
+	for _, test := range tests {
+		stat, p := ChiSquare(test.counts)
+		if math.Abs(stat-test.stat) > 1e-3 || math.Abs(p-test.p) > math.Max(1e-3*test.p, 1e-7) {
+			t.Errorf("ChiSquare(%v) returned invalid value: want %.4f, %.4g, got %.4f, %.4g", test.counts, test.stat, test.p, stat, p)
+		}
+	}
+}
+
+func TestKolmogorovSmirnov(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	uniform := make([]float64, 10000)
+	for i := range uniform {
+		uniform[i] = r.Float64()
+	}
+	if _, p := KolmogorovSmirnov(uniform); p < Alpha {
+		t.Errorf("KolmogorovSmirnov rejected uniform samples: p-value %.2g", p)
+	}
+
+	skewed := make([]float64, 10000)
+	for i := range skewed {
+		skewed[i] = r.Float64() * r.Float64()
+	}
+	if _, p := KolmogorovSmirnov(skewed); p >= Alpha {
+		t.Errorf("KolmogorovSmirnov accepted skewed samples: p-value %.2g", p)
+	}
+}
+
+// seeded generates p with a deterministic source of randomness.
+type seeded struct {
+	p pattern.Part
+	r *rand.Rand
+}
+
+func (s seeded) Append(b []byte) []byte {
+	return append(b, pattern.StringFrom(s.p, s.r)...)
+}
+
+func TestAssertUniformChars(t *testing.T) {
+	// Short alphabets whose sizes are not powers of two.
+	for _, alphabet := range []string{"abc", "abcde", "0123456789", "abcdefghijklmnopqrstuvwxyz0123456789"} {
+		gen := seeded{pattern.New(pattern.Repeat(4, 4, pattern.OneOfByte([]byte(alphabet)))), rand.New(rand.NewSource(1))}
+		AssertUniformChars(t, gen, alphabet, 20000)
+	}
+
+	r := &recorder{TB: t}
+	biased := seeded{pattern.New(pattern.OneOfByte([]byte("aab"))), rand.New(rand.NewSource(1))}
+	if AssertUniformChars(r, biased, "ab", 20000) || r.errors != 1 {
+		t.Errorf("AssertUniformChars did not fail on biased pattern")
+	}
+}
+
+func TestAssertUniformLen(t *testing.T) {
+	gen := seeded{pattern.New(pattern.Repeat(2, 7, pattern.Literal("x"))), rand.New(rand.NewSource(1))}
+	AssertUniformLen(t, gen, 2, 7, 20000)
+
+	r := &recorder{TB: t}
+	biased := seeded{pattern.New(pattern.Literal("x"), pattern.Potentially(0.8, pattern.Literal("x"))), rand.New(rand.NewSource(1))}
+	if AssertUniformLen(r, biased, 1, 2, 20000) || r.errors != 1 {
+		t.Errorf("AssertUniformLen did not fail on biased pattern")
+	}
+}
@@ -0,0 +1,126 @@
+package pattern
+
+import (
+	"math/bits"
+	"sync/atomic"
+)
+
+const feistelRounds = 6
+
+// PermutedSequence returns a Part that will on each iteration output the next number of a pseudo-random permutation of [start, max].
+// The number will be zero-padded to width.
+// Every number in [start, max] is generated exactly once before the permutation repeats, so the output looks random but is unique without storing previous values.
+// The permutation is determined by key; the same key always results in the same order.
+//
+// The permutation is a Feistel network over the counter combined with cycle-walking to restrict it to the range.
+// It is not cryptographically secure.
+//
+// https://en.wikipedia.org/wiki/Format-preserving_encryption
+func PermutedSequence(start uint64, max uint64, width int, key uint64) Part {
+	if max < start {
+		panic("max must be >= min")
+	}
+
+	// n is 0 if the range covers all uint64.
+	n := max - start + 1
+
+	// The Feistel network needs an even number of bits.
+	b := 64
+	if n != 0 {
+		b = bits.Len64(n - 1)
+		b += b & 1
+		if b < 2 {
+			b = 2
+		}
+	}
+
+	p := permutation{
+		start: start,
+		n:     n,
+		half:  uint(b / 2),
+		mask:  1<<uint(b/2) - 1,
+		key:   key,
+		width: width,
+		curr:  new(uint64),
+	}
+
+	// Derive the round keys with splitmix64.
+	for i := range p.keys {
+		key += 0x9e3779b97f4a7c15
+		p.keys[i] = mix64(key)
+	}
+
+	return p
+}
+
+type permutation struct {
+	start uint64
+	// n is the size of the range or 0 if it covers all uint64.
+	n uint64
+	// half is the number of bits in each half of the Feistel network.
+	half uint
+	mask uint64
+	keys [feistelRounds]uint64
+	// key is the key the round keys were derived from.
+	key uint64
+
+	width int
+	// curr is the number of generated values.
+	curr *uint64
+}
+
+func (p permutation) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p permutation) appendRun(r *run, b []byte) []byte {
+	var i uint64
+	if r.pure() {
+		i = r.uint64N(p.n)
+	} else {
+		i = atomic.AddUint64(p.curr, 1) - 1
+		if p.n != 0 {
+			i %= p.n
+		}
+	}
+	return appendInt(b, p.start+p.permute(i), p.width)
+}
+
+// permute maps i in [0, n) to a unique number in [0, n).
+func (p permutation) permute(i uint64) uint64 {
+	// Cycle-walk until the result is inside the range.
+	// Since the domain of the network is less than 4n, this takes 4 iterations on average.
+	for {
+		i = p.feistel(i)
+		if p.n == 0 || i < p.n {
+			return i
+		}
+	}
+}
+
+func (p permutation) feistel(v uint64) uint64 {
+	l, r := v>>p.half, v&p.mask
+	for _, k := range p.keys {
+		l, r = r, l^(mix64(r^k)&p.mask)
+	}
+	return l<<p.half | r
+}
+
+// mix64 is the finalizer of splitmix64.
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (p permutation) saveState() partState {
+	return partState{
+		Kind: "permutation",
+		Curr: atomic.LoadUint64(p.curr),
+	}
+}
+
+func (p permutation) loadState(s partState) error {
+	atomic.StoreUint64(p.curr, s.Curr)
+	return nil
+}
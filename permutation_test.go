@@ -0,0 +1,94 @@
+package pattern
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestPermutedSequence(t *testing.T) {
+	tests := []struct {
+		name  string
+		start uint64
+		max   uint64
+	}{
+		{"single", 5, 5},
+		{"small", 0, 9},
+		{"odd bits", 1, 1000},
+		{"power of two", 0, 1023},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := New(PermutedSequence(tt.start, tt.max, 0, 42))
+
+			n := int(tt.max - tt.start + 1)
+			seen := make(map[uint64]bool, n)
+			ordered := true
+			for i := 0; i < n; i++ {
+				v, err := strconv.ParseUint(gen.String(), 10, 64)
+				if err != nil {
+					t.Fatalf("PermutedSequence returned invalid number: %v", err)
+				}
+				if v < tt.start || v > tt.max {
+					t.Errorf("PermutedSequence returned number out of range: want [%d,%d], got %d", tt.start, tt.max, v)
+				}
+				if seen[v] {
+					t.Errorf("PermutedSequence returned duplicate number %d", v)
+				}
+				if v != tt.start+uint64(i) {
+					ordered = false
+				}
+				seen[v] = true
+			}
+
+			if n > 2 && ordered {
+				t.Errorf("PermutedSequence returned the numbers in order")
+			}
+		})
+	}
+}
+
+func TestPermutedSequenceKey(t *testing.T) {
+	g1 := New(PermutedSequence(0, 999, 3, 1))
+	g2 := New(PermutedSequence(0, 999, 3, 1))
+	g3 := New(PermutedSequence(0, 999, 3, 2))
+
+	var same, differ bool = true, false
+	for i := 0; i < 100; i++ {
+		v1, v2, v3 := g1.String(), g2.String(), g3.String()
+		if len(v1) != 3 {
+			t.Errorf("PermutedSequence has invalid width: want 3, got %d", len(v1))
+		}
+		same = same && v1 == v2
+		differ = differ || v1 != v3
+	}
+
+	if !same {
+		t.Errorf("PermutedSequence with the same key returned different numbers")
+	}
+
+	if !differ {
+		t.Errorf("PermutedSequence with different keys returned the same numbers")
+	}
+}
+
+func TestPermutedSequenceFullRange(t *testing.T) {
+	gen := New(PermutedSequence(0, math.MaxUint64, 0, 42))
+	v1, v2 := gen.String(), gen.String()
+	if v1 == v2 {
+		t.Errorf("PermutedSequence returned duplicate number %s", v1)
+	}
+}
+
+func TestPermutedSequencePanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("PermutedSequence with max < start did not panic")
+			}
+		}()
+
+		New(PermutedSequence(2, 1, 0, 0))
+	}()
+}
@@ -0,0 +1,42 @@
+package pattern
+
+// Placeholder returns a Part that outputs the value of the argument name passed to StringArgs,
+// e.g. a tenant code or region that changes per call.
+// The Part panics if it is generated without a value for name.
+//
+// Panics if name is empty.
+func Placeholder(name string) Part {
+	if name == "" {
+		panic("name must not be empty")
+	}
+
+	return placeholder(name)
+}
+
+type placeholder string
+
+func (p placeholder) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p placeholder) appendRun(r *run, b []byte) []byte {
+	if r != nil {
+		if v, ok := r.args[string(p)]; ok {
+			return append(b, v...)
+		}
+	}
+	panic("missing argument for Placeholder " + string(p))
+}
+
+// StringArgs returns a random pattern like String, where each Placeholder outputs its value of args.
+// StringArgs panics if args has no value for a Placeholder of the pattern.
+func (g gen) StringArgs(args map[string]string) string {
+	r := &run{
+		src:  g.src,
+		args: args,
+	}
+
+	b := make([]byte, 0, g.bufferCap())
+	b = g.appendRun(r, b)
+	return string(b)
+}
@@ -0,0 +1,64 @@
+package pattern
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPlaceholder(t *testing.T) {
+	gen := New(
+		Placeholder("tenant"),
+		Literal("-"),
+		Repeat(2, 2, Placeholder("region")),
+		Literal("-"),
+		Sequence(1, 100, 3),
+	)
+
+	tests := []struct {
+		args map[string]string
+		want string
+	}{
+		{map[string]string{"tenant": "acme", "region": "eu"}, "acme-eueu-001"},
+		{map[string]string{"tenant": "", "region": "us", "unused": "x"}, "-usus-002"},
+	}
+	for _, test := range tests {
+		if v := gen.StringArgs(test.args); v != test.want {
+			t.Errorf("got %q, want %q", v, test.want)
+		}
+	}
+
+	data, err := json.Marshal(gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := New()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if v := decoded.StringArgs(map[string]string{"tenant": "a", "region": "b"}); v != "a-bb-001" {
+		t.Errorf("got %q, want a-bb-001", v)
+	}
+}
+
+func TestPlaceholderPanic(t *testing.T) {
+	gen := New(Literal("a"), Placeholder("tenant"))
+
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"String", func() { _ = gen.String() }},
+		{"MissingArg", func() { _ = gen.StringArgs(map[string]string{"region": "eu"}) }},
+		{"EmptyName", func() { Placeholder("") }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("did not panic")
+				}
+			}()
+			test.f()
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package presets
+
+import (
+	"github.com/sollniss/pattern"
+)
+
+// FriendlyName returns a Part that outputs Docker-style names like "brave_otter" from pattern.Adjectives and pattern.Nouns,
+// e.g. for default resource names.
+func FriendlyName(opts ...FriendlyNameOption) pattern.Part {
+	n := friendlyName{sep: "_"}
+	for _, opt := range opts {
+		opt(&n)
+	}
+
+	parts := []pattern.Part{pattern.Word(pattern.Adjectives), pattern.Word(pattern.Nouns)}
+	if n.digits > 0 {
+		parts = append(parts, pattern.Repeat(uint32(n.digits), uint32(n.digits), pattern.OneOfByte(pattern.Digits)))
+	}
+	return pattern.Join(n.sep, parts...)
+}
+
+// FriendlyNameOption changes the output of FriendlyName.
+type FriendlyNameOption func(*friendlyName)
+
+// FriendlyNameDigits makes FriendlyName append n random digits to expand the number of names, e.g. "brave_otter_4821".
+//
+// Panics if n is < 0.
+func FriendlyNameDigits(n int) FriendlyNameOption {
+	if n < 0 {
+		panic("n must be >= 0")
+	}
+
+	return func(f *friendlyName) {
+		f.digits = n
+	}
+}
+
+// FriendlyNameSeparator makes FriendlyName separate the words and digits with sep instead of "_", e.g. "-" for Heroku-style names.
+func FriendlyNameSeparator(sep string) FriendlyNameOption {
+	return func(f *friendlyName) {
+		f.sep = sep
+	}
+}
+
+type friendlyName struct {
+	sep    string
+	digits int
+}
@@ -0,0 +1,42 @@
+package presets
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/sollniss/pattern"
+)
+
+func TestFriendlyName(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []FriendlyNameOption
+		re   string
+	}{
+		{"default", nil, `^[a-z]+_[a-z]+$`},
+		{"digits", []FriendlyNameOption{FriendlyNameDigits(4)}, `^[a-z]+_[a-z]+_[0-9]{4}$`},
+		{"separator", []FriendlyNameOption{FriendlyNameSeparator("-"), FriendlyNameDigits(2)}, `^[a-z]+-[a-z]+-[0-9]{2}$`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re := regexp.MustCompile(tt.re)
+			gen := pattern.New(FriendlyName(tt.opts...))
+			for i := 0; i < 100; i++ {
+				if v := gen.String(); !re.MatchString(v) {
+					t.Fatalf("FriendlyName returned invalid value: %s", strconv.Quote(v))
+				}
+			}
+		})
+	}
+}
+
+func TestFriendlyNamePanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("FriendlyNameDigits did not panic on n < 0")
+		}
+	}()
+	FriendlyNameDigits(-1)
+}
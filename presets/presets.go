@@ -0,0 +1,102 @@
+// Package presets provides ready-made patterns for common formats.
+//
+// All functions of this package return Parts, so the presets can be generated with pattern.New
+// and combined with the Parts of package pattern.
+package presets
+
+import (
+	"github.com/sollniss/pattern"
+)
+
+// Policy describes the characters of a password.
+type Policy struct {
+	// Lower, Upper and Digits include the lowercase ASCII letters, the uppercase ASCII letters and the decimal digits.
+	Lower, Upper, Digits bool
+	// Symbols holds the allowed special characters, e.g. "!#$%&*+-=?@". If empty, no symbols are used.
+	Symbols string
+	// Require makes every password contain at least one character of each included class.
+	Require bool
+	// ExcludeAmbiguous removes the easily confused characters 0, O, 1, l, I and |.
+	ExcludeAmbiguous bool
+}
+
+// DefaultPolicy includes letters, digits and common symbols and requires one of each.
+var DefaultPolicy = Policy{
+	Lower:   true,
+	Upper:   true,
+	Digits:  true,
+	Symbols: "!#$%&*+-=?@",
+	Require: true,
+}
+
+// ambiguous holds the characters removed by Policy.ExcludeAmbiguous.
+const ambiguous = "0O1lI|"
+
+// Password returns a Part that outputs a password of minLen to maxLen characters following policy, e.g.
+//
+//	pattern.New(presets.Password(16, 20, presets.DefaultPolicy)).String()
+//
+// The characters are drawn uniformly from all included characters; required characters are placed at random positions.
+// The random numbers are read from crypto/rand, unless the generator using the Part has its own source, e.g. WithSeed for test data.
+//
+// Panics if policy includes no characters, a class has no characters left after excluding ambiguous ones,
+// maxLen is 0 or < minLen or, if policy requires all classes, minLen is less than the number of classes.
+func Password(minLen int, maxLen int, policy Policy) pattern.Part {
+	if minLen < 0 {
+		panic("minLen must be >= 0")
+	}
+
+	if maxLen == 0 {
+		panic("maxLen must be > 0")
+	}
+
+	if maxLen < minLen {
+		panic("maxLen must be >= minLen")
+	}
+
+	var classes []pattern.Part
+	var all []byte
+	add := func(alphabet []byte) {
+		c := pattern.OneOfByte(alphabet)
+		if policy.ExcludeAmbiguous {
+			c = pattern.Except(c, ambiguous)
+		}
+		classes = append(classes, c)
+		all = append(all, alphabet...)
+	}
+
+	if policy.Lower {
+		add(pattern.AlphaLower)
+	}
+	if policy.Upper {
+		add(pattern.AlphaUpper)
+	}
+	if policy.Digits {
+		add(pattern.Digits)
+	}
+	if policy.Symbols != "" {
+		add([]byte(policy.Symbols))
+	}
+
+	if len(classes) == 0 {
+		panic("policy includes no characters")
+	}
+
+	if policy.Require {
+		if minLen < len(classes) {
+			panic("minLen must be >= the number of required classes")
+		}
+		return secure(pattern.AtLeastOneOf(uint32(minLen), uint32(maxLen), classes...))
+	}
+
+	c := pattern.OneOfByte(all)
+	if policy.ExcludeAmbiguous {
+		c = pattern.Except(c, ambiguous)
+	}
+	return secure(pattern.Repeat(uint32(minLen), uint32(maxLen), c))
+}
+
+// secure returns a generator of p that draws its random numbers from crypto/rand.
+func secure(p pattern.Part) pattern.Part {
+	return pattern.NewWithOptions([]pattern.Option{pattern.WithSecure()}, p)
+}
@@ -0,0 +1,75 @@
+package presets
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sollniss/pattern"
+)
+
+func TestPassword(t *testing.T) {
+	gen := pattern.New(Password(12, 16, DefaultPolicy))
+
+	for i := 0; i < 500; i++ {
+		v := gen.String()
+		if len(v) < 12 || len(v) > 16 {
+			t.Fatalf("Password returned invalid length: %s", strconv.Quote(v))
+		}
+
+		for _, class := range []string{string(pattern.AlphaLower), string(pattern.AlphaUpper), string(pattern.Digits), DefaultPolicy.Symbols} {
+			if !strings.ContainsAny(v, class) {
+				t.Errorf("Password is missing a character of %s: %s", strconv.Quote(class), strconv.Quote(v))
+			}
+		}
+	}
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  Policy
+		allowed string
+	}{
+		{"digits", Policy{Digits: true}, "0123456789"},
+		{"unambiguous", Policy{Digits: true, Symbols: "|-", ExcludeAmbiguous: true}, "23456789-"},
+		{"required unambiguous", Policy{Upper: true, Digits: true, Require: true, ExcludeAmbiguous: true}, "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := pattern.New(Password(8, 8, tt.policy))
+			for i := 0; i < 200; i++ {
+				v := gen.String()
+				if len(v) != 8 || strings.Trim(v, tt.allowed) != "" {
+					t.Fatalf("Password returned invalid value: %s", strconv.Quote(v))
+				}
+			}
+		})
+	}
+}
+
+func TestPasswordPanic(t *testing.T) {
+	tests := []struct {
+		name           string
+		minLen, maxLen int
+		policy         Policy
+	}{
+		{"no characters", 8, 8, Policy{Require: true}},
+		{"maxLen < minLen", 8, 4, DefaultPolicy},
+		{"maxLen 0", 0, 0, DefaultPolicy},
+		{"too short", 3, 8, DefaultPolicy},
+		{"only ambiguous", 8, 8, Policy{Symbols: "|", ExcludeAmbiguous: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Password did not panic")
+				}
+			}()
+			Password(tt.minLen, tt.maxLen, tt.policy)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package pattern
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// pronounceableRetries is the number of times Pronounceable regenerates outputs that contain a blocked word.
+const pronounceableRetries = 100
+
+const (
+	// pronounceableConsonants omits c, q, w, x and y, which are hard to spell out or pronounce in a consonant-vowel syllable.
+	pronounceableConsonants = "bdfghjklmnprstvz"
+	pronounceableVowels     = "aeiou"
+)
+
+// pronounceableBlocked holds offensive words that can be formed by consonant-vowel syllables.
+var pronounceableBlocked = [][]byte{
+	[]byte("dik"), []byte("fag"), []byte("fuk"), []byte("homo"), []byte("kike"), []byte("nazi"), []byte("nig"),
+	[]byte("pedo"), []byte("penis"), []byte("puta"), []byte("rape"), []byte("semen"), []byte("tit"), []byte("vagina"),
+}
+
+// Pronounceable returns a Part that outputs syllables lowercase consonant-vowel syllables,
+// e.g. "tovamuki", so support staff can read generated codes over the phone.
+// The consonants c, q, w, x and y are not used, and syllables that form an offensive word are regenerated.
+// Real words can still be generated, especially with few syllables.
+//
+// The Part panics with an error wrapping ErrRetriesExhausted if a syllable still forms an offensive word after 100 retries.
+// Panics if syllables is < 0.
+func Pronounceable(syllables int, opts ...PronounceableOption) Part {
+	if syllables < 0 {
+		panic("syllables must be >= 0")
+	}
+
+	p := pronounceable{
+		syllables: syllables,
+	}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return p
+}
+
+// PronounceableOption changes the output of Pronounceable.
+type PronounceableOption func(*pronounceable)
+
+// PronounceableDigits makes Pronounceable append n random digits to the syllables, e.g. "tovamuki42".
+//
+// Panics if n is < 0.
+func PronounceableDigits(n int) PronounceableOption {
+	if n < 0 {
+		panic("n must be >= 0")
+	}
+
+	return func(p *pronounceable) {
+		p.digits = n
+	}
+}
+
+type pronounceable struct {
+	syllables int
+	digits    int
+}
+
+func (p pronounceable) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p pronounceable) appendRun(r *run, b []byte) []byte {
+	start := len(b)
+	for j := 0; j < p.syllables; j++ {
+		// The syllables before are clean, so only the words overlapping the new syllable are checked.
+		end := len(b)
+		from := end - pronounceableMaxBlocked
+		if from < start {
+			from = start
+		}
+
+		for i := 0; ; i++ {
+			b = append(b[:end],
+				pronounceableConsonants[r.randN(uint32(len(pronounceableConsonants)))],
+				pronounceableVowels[r.randN(uint32(len(pronounceableVowels)))],
+			)
+			if !pronounceableOffensive(b[from:]) {
+				break
+			}
+
+			if i == pronounceableRetries {
+				panic(fmt.Errorf("%w: syllable still forms an offensive word after %d retries", ErrRetriesExhausted, pronounceableRetries))
+			}
+		}
+	}
+
+	for j := 0; j < p.digits; j++ {
+		b = append(b, '0'+byte(r.randN(10)))
+	}
+	return b
+}
+
+// pronounceableMaxBlocked is the length of the longest blocked word.
+var pronounceableMaxBlocked = func() int {
+	n := 0
+	for _, w := range pronounceableBlocked {
+		if len(w) > n {
+			n = len(w)
+		}
+	}
+	return n
+}()
+
+// pronounceableOffensive reports whether b contains a blocked word.
+func pronounceableOffensive(b []byte) bool {
+	for _, w := range pronounceableBlocked {
+		if bytes.Contains(b, w) {
+			return true
+		}
+	}
+	return false
+}
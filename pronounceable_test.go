@@ -0,0 +1,64 @@
+package pattern
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestPronounceable(t *testing.T) {
+	re := regexp.MustCompile(`^(?:[bdfghjklmnprstvz][aeiou]){4}[0-9]{2}$`)
+	gen := New(Pronounceable(4, PronounceableDigits(2)))
+
+	for i := 0; i < 1000; i++ {
+		v := gen.String()
+		if !re.MatchString(v) {
+			t.Fatalf("Pronounceable returned invalid value: %s", strconv.Quote(v))
+		}
+		if pronounceableOffensive([]byte(v)) {
+			t.Errorf("Pronounceable returned offensive value: %s", strconv.Quote(v))
+		}
+	}
+
+	if got := gen.Pattern(); got != `(?:[bdf-hj-npr-tvz][aeiou]){4}[0-9]{2}` {
+		t.Errorf("Pattern returned invalid regular expression: %s", got)
+	}
+}
+
+func TestPronounceableLong(t *testing.T) {
+	// Long words almost always contain a blocked word somewhere, so only the offending syllables may be regenerated.
+	p := Pronounceable(10000)
+	for i := 0; i < 10; i++ {
+		if v := p.Append(nil); pronounceableOffensive(v) {
+			t.Errorf("Pronounceable returned offensive value")
+		}
+	}
+}
+
+func TestPronounceablePanic(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"syllables", func() { Pronounceable(-1) }},
+		{"digits", func() { Pronounceable(1, PronounceableDigits(-1)) }},
+		{"retries", func() {
+			// Every output contains the empty word.
+			old := pronounceableBlocked
+			defer func() { pronounceableBlocked = old }()
+			pronounceableBlocked = [][]byte{{}}
+			_ = New(Pronounceable(1)).String()
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Pronounceable did not panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package pattern
+
+import (
+	"math/rand"
+	"reflect"
+	"testing/quick"
+)
+
+// QuickString returns a pattern generated by p with r as the source of randomness.
+// Stateful Parts like Sequence draw their output from r as well, so the same seed always results in the same pattern.
+// This allows implementing quick.Generator for types with fields that must match a pattern.
+func QuickString(p Part, r *rand.Rand) string {
+	return StringFrom(p, r)
+}
+
+// QuickConfig returns a quick.Config that generates the arguments of the tested function with parts, one Part per argument.
+// All arguments of the tested function must be of type string.
+// The Config panics if the number of arguments does not match the number of parts.
+func QuickConfig(parts ...Part) *quick.Config {
+	return &quick.Config{
+		Values: func(args []reflect.Value, r *rand.Rand) {
+			if len(args) != len(parts) {
+				panic("number of parts does not match number of arguments")
+			}
+
+			for i, p := range parts {
+				args[i] = reflect.ValueOf(QuickString(p, r))
+			}
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package pattern
+
+import (
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+	"testing/quick"
+)
+
+func TestQuickString(t *testing.T) {
+	gen := New(Literal("id-"), Sequence(1, 9999, 4), NanoID(8, []byte("abcdef")))
+
+	a := QuickString(gen, rand.New(rand.NewSource(42)))
+	b := QuickString(gen, rand.New(rand.NewSource(42)))
+	if a != b {
+		t.Errorf("QuickString returned different values for the same seed: %s and %s", strconv.Quote(a), strconv.Quote(b))
+	}
+
+	if ok, _ := regexp.MatchString(`^id-\d{4}[a-f]{8}$`, a); !ok {
+		t.Errorf("QuickString returned invalid value: %s", strconv.Quote(a))
+	}
+
+	// The Sequence was not advanced.
+	if v := gen.String(); v[:7] != "id-0001" {
+		t.Errorf("QuickString advanced the sequence: got %s", strconv.Quote(v))
+	}
+}
+
+func TestQuickConfig(t *testing.T) {
+	user := MustCompile(`user-[0-9]{6}`)
+	color := New(OneOfString([]string{"red", "green", "blue"}))
+
+	re := regexp.MustCompile(`^user-\d{6}$`)
+	f := func(u string, c string) bool {
+		return re.MatchString(u) && (c == "red" || c == "green" || c == "blue")
+	}
+
+	if err := quick.Check(f, QuickConfig(user, color)); err != nil {
+		t.Error(err)
+	}
+}
+
+type quickUser struct {
+	ID string
+}
+
+func (quickUser) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(quickUser{ID: QuickString(MustCompile(`u[0-9]{3}`), r)})
+}
+
+func TestQuickGenerator(t *testing.T) {
+	f := func(u quickUser) bool {
+		return len(u.ID) == 4 && u.ID[0] == 'u'
+	}
+
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQuickConfigPanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("QuickConfig did not panic on mismatched arguments")
+			}
+		}()
+		quick.Check(func(a, b string) bool { return true }, QuickConfig(Literal("a")))
+	}()
+}
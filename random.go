@@ -0,0 +1,146 @@
+package pattern
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+)
+
+const (
+	hexDigits = "0123456789abcdef"
+	// base58Digits is the Bitcoin alphabet, which omits 0, O, I and l.
+	base58Digits = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+// RandomHex returns a Part that outputs nBytes random bytes encoded as lowercase hex, e.g. for session tokens.
+// The bytes are drawn and encoded in one pass, so it is faster than repeating OneOfByte and uses all drawn bits.
+//
+// Panics if nBytes is < 0.
+func RandomHex(nBytes int) Part {
+	if nBytes < 0 {
+		panic("nBytes must be >= 0")
+	}
+
+	return randomHex{
+		n: nBytes,
+	}
+}
+
+type randomHex struct {
+	n int
+}
+
+func (p randomHex) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p randomHex) appendRun(r *run, b []byte) []byte {
+	start := len(b)
+	b = append(b, make([]byte, 2*p.n)...)
+
+	// Draw the bytes into the first half and encode them backwards,
+	// so no byte is overwritten before it is encoded.
+	src := b[start : start+p.n]
+	r.read(src)
+	for i := p.n - 1; i >= 0; i-- {
+		v := src[i]
+		b[start+2*i] = hexDigits[v>>4]
+		b[start+2*i+1] = hexDigits[v&0x0f]
+	}
+	return b
+}
+
+// fixedString returns the strings generated by p.
+func (p randomHex) fixedString() fixedString {
+	return fixedString{[]byte(hexDigits), 2 * p.n}
+}
+
+// RandomBase64URL returns a Part that outputs nBytes random bytes encoded with the URL-safe base64 alphabet without padding.
+//
+// Panics if nBytes is < 0.
+func RandomBase64URL(nBytes int) Part {
+	if nBytes < 0 {
+		panic("nBytes must be >= 0")
+	}
+
+	return randomEncoded{
+		n:    nBytes,
+		enc:  base64.RawURLEncoding,
+		name: "RandomBase64URL",
+	}
+}
+
+// RandomBase32 returns a Part that outputs nBytes random bytes encoded with encoding.
+// If encoding is nil, the standard base32 alphabet without padding is used.
+//
+// Panics if nBytes is < 0.
+func RandomBase32(nBytes int, encoding *base32.Encoding) Part {
+	if nBytes < 0 {
+		panic("nBytes must be >= 0")
+	}
+
+	p := randomEncoded{
+		n:    nBytes,
+		enc:  encoding,
+		name: "RandomBase32",
+	}
+	if encoding == nil {
+		p.enc = base32.StdEncoding.WithPadding(base32.NoPadding)
+		p.std = true
+	}
+	return p
+}
+
+// encoder is implemented by *base64.Encoding and *base32.Encoding.
+type encoder interface {
+	Encode(dst []byte, src []byte)
+	EncodedLen(n int) int
+}
+
+// encodeChunk is the number of random bytes encoded at once.
+// It is a multiple of 3 and 5, so only the last chunk of base64 and base32 is padded,
+// and of 8, so no random bits are discarded between chunks.
+const encodeChunk = 120
+
+type randomEncoded struct {
+	n    int
+	enc  encoder
+	name string
+	// std reports whether the default encoding of the constructor is used.
+	std bool
+}
+
+func (p randomEncoded) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p randomEncoded) appendRun(r *run, b []byte) []byte {
+	var buf [encodeChunk]byte
+	for n := p.n; n > 0; n -= encodeChunk {
+		src := buf[:]
+		if n < encodeChunk {
+			src = buf[:n]
+		}
+		r.read(src)
+
+		start := len(b)
+		b = append(b, make([]byte, p.enc.EncodedLen(len(src)))...)
+		p.enc.Encode(b[start:], src)
+	}
+	return b
+}
+
+// Base58 returns a Part that outputs n characters of the Bitcoin base58 alphabet, which omits the easily confused 0, O, I and l.
+// Like NanoID, characters are selected without modulo bias.
+//
+// Panics if n is < 0.
+func Base58(n int) Part {
+	if n < 0 {
+		panic("n must be >= 0")
+	}
+
+	return nanoID{
+		alphabet: []byte(base58Digits),
+		len:      uint32(len(base58Digits)),
+		size:     n,
+	}
+}
@@ -0,0 +1,133 @@
+package pattern
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+)
+
+func TestRandomHex(t *testing.T) {
+	for _, n := range []int{0, 1, 7, 16, 33} {
+		p := RandomHex(n)
+		v := string(p.Append([]byte("x")))
+		if len(v) != 1+2*n || v[0] != 'x' {
+			t.Fatalf("RandomHex(%d) returned invalid value %q", n, v)
+		}
+		if _, err := hex.DecodeString(v[1:]); err != nil {
+			t.Errorf("RandomHex(%d) returned invalid hex %q: %v", n, v, err)
+		}
+	}
+
+	// StringFrom encodes the bytes of the source in little-endian order.
+	if v := StringFrom(RandomHex(9), &countingSource{v: 0x0123456789abcdef}); v != "efcdab8967452301f0" {
+		t.Errorf("got %q, want efcdab8967452301f0", v)
+	}
+
+	gen := New(Literal("s-"), RandomHex(4))
+	if v := gen.Pattern(); v != "s-[0-9a-f]{8}" {
+		t.Errorf("got %q, want s-[0-9a-f]{8}", v)
+	}
+	if v := gen.String(); !Match(gen, v) {
+		t.Errorf("%q does not match", v)
+	}
+}
+
+func TestRandomEncoded(t *testing.T) {
+	tests := []struct {
+		part   Part
+		decode func(string) ([]byte, error)
+	}{
+		{RandomBase64URL(0), base64.RawURLEncoding.DecodeString},
+		{RandomBase64URL(16), base64.RawURLEncoding.DecodeString},
+		{RandomBase64URL(61), base64.RawURLEncoding.DecodeString},
+		{RandomBase32(10, nil), base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString},
+		{RandomBase32(7, nil), base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString},
+		{RandomBase32(123, base32.HexEncoding), base32.HexEncoding.DecodeString},
+	}
+
+	for _, test := range tests {
+		n := test.part.(randomEncoded).n
+		v := string(test.part.Append(nil))
+		b, err := test.decode(v)
+		if err != nil || len(b) != n {
+			t.Errorf("%s returned invalid value %q: %v", explainPart(test.part), v, err)
+		}
+	}
+
+	// The chunks are encoded from consecutive random numbers.
+	want := base64.RawURLEncoding.EncodeToString(decodeHex(t, StringFrom(RandomHex(130), &countingSource{v: 1})))
+	if v := StringFrom(RandomBase64URL(130), &countingSource{v: 1}); v != want {
+		t.Errorf("got %q, want %q", v, want)
+	}
+
+	if _, err := json.Marshal(RandomBase32(5, base32.HexEncoding)); err == nil {
+		t.Error("RandomBase32 with a custom encoding was marshalled")
+	}
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestBase58(t *testing.T) {
+	p := Base58(10)
+	seen := map[rune]int{}
+	for i := 0; i < 1000; i++ {
+		v := string(p.Append(nil))
+		if len(v) != 10 {
+			t.Fatalf("Base58 returned invalid value %q", v)
+		}
+		for _, c := range v {
+			seen[c]++
+		}
+	}
+
+	if len(seen) != 58 {
+		t.Errorf("got %d distinct characters, want 58", len(seen))
+	}
+	for _, c := range "0OIl" {
+		if seen[c] > 0 {
+			t.Errorf("Base58 returned %q", c)
+		}
+	}
+
+	if v := New(Base58(0)).String(); v != "" {
+		t.Errorf("got %q, want empty string", v)
+	}
+}
+
+// countingSource returns v, v+1, ...
+type countingSource struct {
+	v uint64
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.v++
+	return s.v - 1
+}
+
+func BenchmarkRandomHex(b *testing.B) {
+	p := RandomHex(16)
+	buf := make([]byte, 0, 32)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = p.Append(buf[:0])
+	}
+}
+
+func BenchmarkRandomHexRepeat(b *testing.B) {
+	p := Repeat(32, 32, OneOfByte([]byte(hexDigits)))
+	buf := make([]byte, 0, 32)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = p.Append(buf[:0])
+	}
+}
@@ -0,0 +1,153 @@
+package pattern
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// Record generates multiple named fields, which can reference each other with Ref.
+type Record struct {
+	fields []recordField
+	index  map[string]int
+}
+
+type recordField struct {
+	name string
+	part Part
+}
+
+// NewRecord returns an empty Record.
+func NewRecord() *Record {
+	return &Record{
+		index: make(map[string]int),
+	}
+}
+
+// Field adds a field to the Record that is generated by p.
+// Fields are generated in the order they are added, so p can reference all previously added fields with Ref.
+//
+// Panics if a field with the same name already exists or p references a field that was not added before.
+func (rec *Record) Field(name string, p Part) *Record {
+	if _, ok := rec.index[name]; ok {
+		panic("duplicate field " + name)
+	}
+
+	walk(p, func(p Part) {
+		if v, ok := p.(ref); ok {
+			if _, ok := rec.index[string(v)]; !ok {
+				panic("field " + name + " references unknown field " + string(v))
+			}
+		}
+	})
+
+	rec.index[name] = len(rec.fields)
+	rec.fields = append(rec.fields, recordField{
+		name: name,
+		part: p,
+	})
+	return rec
+}
+
+// generate returns the values of all fields in the order they were added.
+func (rec *Record) generate() []string {
+	r := &run{
+		record: make(map[string]string, len(rec.fields)),
+	}
+
+	values := make([]string, len(rec.fields))
+	var b []byte
+	for i, f := range rec.fields {
+		b = appendRun(r, f.part, b[:0])
+		values[i] = string(b)
+		r.record[f.name] = values[i]
+	}
+	return values
+}
+
+// Map returns a new set of generated fields.
+func (rec *Record) Map() map[string]string {
+	values := rec.generate()
+	m := make(map[string]string, len(values))
+	for i, f := range rec.fields {
+		m[f.name] = values[i]
+	}
+	return m
+}
+
+// JSON returns a new set of generated fields as a JSON object.
+// The fields are in the order they were added.
+func (rec *Record) JSON() ([]byte, error) {
+	values := rec.generate()
+
+	b := []byte{'{'}
+	for i, f := range rec.fields {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		name, err := json.Marshal(f.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		b = append(b, name...)
+		b = append(b, ':')
+		b = append(b, value...)
+	}
+	return append(b, '}'), nil
+}
+
+// Fill sets the string fields of the struct pointed to by v to a new set of generated fields.
+// A struct field is set to the Record field with the same name, or the name given by a `record:"name"` tag.
+// Struct fields without a matching Record field are left unchanged.
+func (rec *Record) Fill(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("pattern: Fill requires a non-nil pointer to a struct")
+	}
+	rv = rv.Elem()
+
+	values := rec.generate()
+
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Type.Kind() != reflect.String {
+			continue
+		}
+
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("record"); ok {
+			name = tag
+		}
+
+		if j, ok := rec.index[name]; ok {
+			rv.Field(i).SetString(values[j])
+		}
+	}
+	return nil
+}
+
+// Ref returns a Part that outputs the value of the field name of the Record that is currently generated.
+// Ref can only be used inside a Record.
+func Ref(name string) Part {
+	return ref(name)
+}
+
+type ref string
+
+func (p ref) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p ref) appendRun(r *run, b []byte) []byte {
+	if r == nil || r.record == nil {
+		panic("Ref used outside of a Record")
+	}
+	return append(b, r.record[string(p)]...)
+}
@@ -0,0 +1,104 @@
+package pattern
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func newTestRecord() *Record {
+	return NewRecord().
+		Field("username", Group(Literal("user"), Repeat(4, 4, OneOfByte([]byte("0123456789"))))).
+		Field("email", Group(Ref("username"), Literal("@example.com"))).
+		Field("display", Group(Literal("@"), Ref("username")))
+}
+
+func TestRecordMap(t *testing.T) {
+	rec := newTestRecord()
+
+	m := rec.Map()
+	if len(m) != 3 {
+		t.Fatalf("Record returned invalid number of fields: want 3, got %d", len(m))
+	}
+
+	if !strings.HasPrefix(m["username"], "user") || len(m["username"]) != 8 {
+		t.Errorf("Record returned invalid username %s", strconv.Quote(m["username"]))
+	}
+
+	if m["email"] != m["username"]+"@example.com" {
+		t.Errorf("Record returned invalid email: want %s, got %s", strconv.Quote(m["username"]+"@example.com"), strconv.Quote(m["email"]))
+	}
+
+	if m["display"] != "@"+m["username"] {
+		t.Errorf("Record returned invalid display: want %s, got %s", strconv.Quote("@"+m["username"]), strconv.Quote(m["display"]))
+	}
+}
+
+func TestRecordJSON(t *testing.T) {
+	b, err := newTestRecord().JSON()
+	if err != nil {
+		t.Fatalf("JSON returned error: %v", err)
+	}
+
+	if !strings.HasPrefix(string(b), `{"username":`) {
+		t.Errorf("JSON did not keep the field order: got %s", b)
+	}
+
+	var m map[string]string
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("JSON returned invalid JSON: %v", err)
+	}
+
+	if m["email"] != m["username"]+"@example.com" {
+		t.Errorf("JSON returned invalid email: want %s, got %s", strconv.Quote(m["username"]+"@example.com"), strconv.Quote(m["email"]))
+	}
+}
+
+func TestRecordFill(t *testing.T) {
+	var u struct {
+		Username string `record:"username"`
+		Mail     string `record:"email"`
+		Other    string
+	}
+	u.Other = "keep"
+
+	if err := newTestRecord().Fill(&u); err != nil {
+		t.Fatalf("Fill returned error: %v", err)
+	}
+
+	if u.Mail != u.Username+"@example.com" {
+		t.Errorf("Fill set invalid email: want %s, got %s", strconv.Quote(u.Username+"@example.com"), strconv.Quote(u.Mail))
+	}
+
+	if u.Other != "keep" {
+		t.Errorf("Fill changed unmatched field")
+	}
+
+	if err := newTestRecord().Fill(u); err == nil {
+		t.Errorf("Fill with non-pointer did not return an error")
+	}
+}
+
+func TestRecordPanic(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"duplicate field", func() { NewRecord().Field("a", Literal("a")).Field("a", Literal("a")) }},
+		{"unknown reference", func() { NewRecord().Field("a", Ref("b")).Field("b", Literal("b")) }},
+		{"Ref outside of Record", func() { id = New(Ref("a")).String() }},
+	}
+
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Record with %s did not panic", tt.name)
+				}
+			}()
+
+			tt.f()
+		}()
+	}
+}
@@ -0,0 +1,311 @@
+package pattern
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxUnbounded is the number of additional repetitions generated for unbounded repetitions like *, + and {n,}.
+const maxUnbounded = 10
+
+// Compile parses a regular expression and returns a generator for strings matching it.
+// The syntax is the one accepted by the regexp package.
+//
+// Unbounded repetitions (*, + and {n,}) repeat at most 10 times more than their minimum.
+// The any character (.) is limited to printable ASCII characters.
+// Anchors and word boundaries are ignored.
+func Compile(expr string) (*gen, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("pattern: %w", err)
+	}
+
+	p, err := compileRegexp(re)
+	if err != nil {
+		return nil, fmt.Errorf("pattern: %s: %w", expr, err)
+	}
+
+	return New(p), nil
+}
+
+// MustCompile is like Compile but panics if the expression can not be parsed.
+func MustCompile(expr string) *gen {
+	g, err := Compile(expr)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// Class returns a Part that selects one rune of a character class in the syntax of the regexp package,
+// e.g. Class("[a-zA-Z0-9_-]") or Class(`[\p{Greek}\d]`).
+// Like the any character of Compile, a negated class like "[^aeiou]" selects from the printable ASCII characters not in the class.
+//
+// Panics if class is not a valid character class or matches no rune.
+func Class(class string) Part {
+	if len(class) < 2 || class[0] != '[' || class[len(class)-1] != ']' {
+		panic("class must be enclosed in []")
+	}
+
+	negated := len(class) > 2 && class[1] == '^'
+	if negated {
+		class = "[" + class[2:]
+		if class[1] == '^' {
+			// A leading ^ after the negation is a literal.
+			class = `[\` + class[1:]
+		}
+	}
+
+	re, err := syntax.Parse(class, syntax.Perl)
+	if err != nil {
+		panic(fmt.Errorf("pattern: %w", err))
+	}
+
+	var ranges []rune
+	switch {
+	case re.Op == syntax.OpCharClass:
+		ranges = re.Rune
+	case re.Op == syntax.OpLiteral && len(re.Rune) == 1:
+		// Classes of a single rune are simplified to literals.
+		ranges = []rune{re.Rune[0], re.Rune[0]}
+	default:
+		panic(fmt.Sprintf("pattern: %s is not a character class", class))
+	}
+
+	if negated {
+		ranges = excludeRanges(' ', '~', ranges)
+	}
+
+	p, err := charClass(ranges)
+	if err != nil {
+		panic(fmt.Errorf("pattern: %s: %w", class, err))
+	}
+	return p
+}
+
+// excludeRanges returns the inclusive ranges of [lo, hi] not covered by the sorted pairs of ranges.
+func excludeRanges(lo rune, hi rune, ranges []rune) []rune {
+	var pairs []rune
+	for i := 0; i < len(ranges) && lo <= hi; i += 2 {
+		if ranges[i+1] < lo {
+			continue
+		}
+		if ranges[i] > hi {
+			break
+		}
+		if ranges[i] > lo {
+			pairs = append(pairs, lo, ranges[i]-1)
+		}
+		lo = ranges[i+1] + 1
+	}
+	if lo <= hi {
+		pairs = append(pairs, lo, hi)
+	}
+	return pairs
+}
+
+var errNoMatch = errors.New("expression can not match any string")
+
+func compileRegexp(re *syntax.Regexp) (Part, error) {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return nil, errNoMatch
+
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine,
+		syntax.OpBeginText, syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return nullpart{}, nil
+
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase == 0 {
+			return Literal(string(re.Rune)), nil
+		}
+
+		parts := make([]Part, 0, len(re.Rune))
+		for _, r := range re.Rune {
+			parts = append(parts, foldCase(r))
+		}
+		return Group(parts...), nil
+
+	case syntax.OpCharClass:
+		return charClass(re.Rune)
+
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		return charClass([]rune{' ', '~'})
+
+	case syntax.OpCapture:
+		return compileRegexp(re.Sub[0])
+
+	case syntax.OpStar:
+		return compileRepeat(re.Sub[0], 0, -1)
+
+	case syntax.OpPlus:
+		return compileRepeat(re.Sub[0], 1, -1)
+
+	case syntax.OpQuest:
+		return compileRepeat(re.Sub[0], 0, 1)
+
+	case syntax.OpRepeat:
+		return compileRepeat(re.Sub[0], re.Min, re.Max)
+
+	case syntax.OpConcat:
+		parts := make([]Part, 0, len(re.Sub))
+		for _, sub := range re.Sub {
+			p, err := compileRegexp(sub)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, p)
+		}
+		return Group(parts...), nil
+
+	case syntax.OpAlternate:
+		parts := make([]Part, 0, len(re.Sub))
+		for _, sub := range re.Sub {
+			p, err := compileRegexp(sub)
+			if errors.Is(err, errNoMatch) {
+				// Skip alternatives that can not match.
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, p)
+		}
+		if len(parts) == 0 {
+			return nil, errNoMatch
+		}
+		return OneOf(parts...), nil
+	}
+
+	return nil, fmt.Errorf("unsupported operation %v", re.Op)
+}
+
+func compileRepeat(sub *syntax.Regexp, min int, max int) (Part, error) {
+	p, err := compileRegexp(sub)
+	if errors.Is(err, errNoMatch) && min == 0 {
+		return nullpart{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if max < 0 {
+		max = min + maxUnbounded
+	}
+
+	if max == 0 {
+		return nullpart{}, nil
+	}
+
+	return Repeat(uint32(min), uint32(max), p), nil
+}
+
+// foldCase returns a Part that selects one of the case variants of r.
+func foldCase(r rune) Part {
+	variants := []rune{r}
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		variants = append(variants, f)
+	}
+	if len(variants) == 1 {
+		return Literal(string(r))
+	}
+	return OneOfRune(variants)
+}
+
+// maxEnumerated is the maximum size of character classes which are enumerated into an alphabet.
+const maxEnumerated = 256
+
+// charClass returns a Part that selects one rune of the character class defined by the pairs of inclusive rune ranges.
+func charClass(ranges []rune) (Part, error) {
+	// Exclude surrogates, since they are not valid runes.
+	pairs := make([]rune, 0, len(ranges)+2)
+	for i := 0; i < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if lo <= 0xDFFF && hi >= 0xD800 {
+			if lo < 0xD800 {
+				pairs = append(pairs, lo, 0xD7FF)
+			}
+			if hi > 0xDFFF {
+				pairs = append(pairs, 0xE000, hi)
+			}
+			continue
+		}
+		pairs = append(pairs, lo, hi)
+	}
+
+	if len(pairs) == 0 {
+		return nil, errNoMatch
+	}
+
+	var total uint64
+	ascii := true
+	for i := 0; i < len(pairs); i += 2 {
+		total += uint64(pairs[i+1]-pairs[i]) + 1
+		ascii = ascii && pairs[i+1] < utf8.RuneSelf
+	}
+
+	if total <= maxEnumerated {
+		if ascii {
+			alphabet := make([]byte, 0, total)
+			for i := 0; i < len(pairs); i += 2 {
+				for r := pairs[i]; r <= pairs[i+1]; r++ {
+					alphabet = append(alphabet, byte(r))
+				}
+			}
+			if len(alphabet) == 1 {
+				return Literal(string(alphabet)), nil
+			}
+			return OneOfByte(alphabet), nil
+		}
+
+		alphabet := make([]rune, 0, total)
+		for i := 0; i < len(pairs); i += 2 {
+			for r := pairs[i]; r <= pairs[i+1]; r++ {
+				alphabet = append(alphabet, r)
+			}
+		}
+		return OneOfRune(alphabet), nil
+	}
+
+	return newRuneRanges(pairs), nil
+}
+
+// runeRanges selects one rune of multiple inclusive rune ranges uniformly.
+type runeRanges struct {
+	// pairs holds the inclusive ranges as [lo, hi] pairs.
+	pairs []rune
+	// cum holds the cumulative number of runes before each range.
+	cum   []uint32
+	total uint32
+}
+
+func newRuneRanges(pairs []rune) runeRanges {
+	p := runeRanges{
+		pairs: pairs,
+		cum:   make([]uint32, 0, len(pairs)/2),
+	}
+	for i := 0; i < len(pairs); i += 2 {
+		p.cum = append(p.cum, p.total)
+		p.total += uint32(pairs[i+1]-pairs[i]) + 1
+	}
+	return p
+}
+
+func (p runeRanges) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p runeRanges) appendRun(r *run, b []byte) []byte {
+	n := r.randN(p.total)
+
+	// Find the last range starting at or before n.
+	i := sort.Search(len(p.cum), func(i int) bool {
+		return p.cum[i] > n
+	}) - 1
+
+	return append(b, string(p.pairs[2*i]+rune(n-p.cum[i]))...)
+}
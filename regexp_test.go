@@ -0,0 +1,124 @@
+package pattern
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	exprs := []string{
+		`abc`,
+		`[a-z]{3}-[0-9]{4}`,
+		`ORD-\d{8}-[A-Z0-9]{4}`,
+		`(foo|bar|baz)+`,
+		`x*y+z?`,
+		`[^a-z]{5}`,
+		`(?i)hello`,
+		`[\p{Greek}\p{Han}]{3,5}`,
+		`^\w+@\w+\.(com|org)$`,
+		`.{10}`,
+		`a{0}b`,
+		`[[:alpha:]][[:digit:]]\s`,
+		`😀+`,
+	}
+
+	for _, expr := range exprs {
+		t.Run(expr, func(t *testing.T) {
+			gen, err := Compile(expr)
+			if err != nil {
+				t.Fatalf("Compile returned error: %v", err)
+			}
+
+			re := regexp.MustCompile(`^(?:` + expr + `)$`)
+			for i := 0; i < 100; i++ {
+				v := gen.String()
+				if !re.MatchString(v) {
+					t.Errorf("Compile(%s) generated non-matching value %s", strconv.Quote(expr), strconv.Quote(v))
+				}
+			}
+		})
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	exprs := []string{
+		`(`,
+		`[^\x00-\x{10FFFF}]`,
+	}
+
+	for _, expr := range exprs {
+		if _, err := Compile(expr); err == nil {
+			t.Errorf("Compile(%s) did not return an error", strconv.Quote(expr))
+		}
+	}
+}
+
+func TestMustCompilePanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("MustCompile with invalid expression did not panic")
+			}
+		}()
+
+		MustCompile(`(`)
+	}()
+}
+
+func TestClass(t *testing.T) {
+	tests := []struct {
+		class string
+		match string
+		count uint64
+	}{
+		{"[a-zA-Z0-9_-]", `^[a-zA-Z0-9_-]$`, 64},
+		{`[\d\s]`, `^[\d\s]$`, 15},
+		{"[x]", `^x$`, 1},
+		{"[]a]", `^[\]a]$`, 2},
+		{"[^a-z]", `^[ -\x60{-~]$`, 95 - 26},
+		{"[^^]", `^[ -\]_-~]$`, 94},
+		{`[\p{Greek}]`, `^\p{Greek}$`, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.class, func(t *testing.T) {
+			g := New(Class(tt.class))
+			re := regexp.MustCompile(tt.match)
+			for i := 0; i < 1000; i++ {
+				if s := g.String(); !re.MatchString(s) {
+					t.Fatalf("Class(%q) generated %q", tt.class, s)
+				}
+			}
+
+			if tt.count > 0 {
+				if n, ok := g.count(); !ok || n != tt.count {
+					t.Errorf("Class(%q) generates %d values, want %d", tt.class, n, tt.count)
+				}
+			}
+		})
+	}
+}
+
+func TestClassPanic(t *testing.T) {
+	classes := []string{
+		"",
+		"a-z",
+		"[a-z",
+		"[z-a]",
+		"[a-z][0-9]",
+		"[^ -~]",
+	}
+
+	for _, class := range classes {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Class(%q) did not panic", class)
+				}
+			}()
+
+			Class(class)
+		}()
+	}
+}
@@ -0,0 +1,154 @@
+package pattern
+
+import (
+	"context"
+	"math/bits"
+
+	"github.com/sollniss/pattern/internal"
+)
+
+// run holds the state of a single generation.
+// A nil *run generates with the default source of randomness.
+type run struct {
+	// src is the source of randomness or nil for the default source.
+	src internal.Source
+	// deterministic makes stateful Parts draw their output from src instead of advancing their state.
+	deterministic bool
+	// record holds the fields of the Record that is currently generated.
+	record map[string]string
+	// args holds the values of the Placeholders.
+	args map[string]string
+	// start is the length of the buffer before the current call, which is not mirrored by Mirror.
+	start int
+	// limit is the length of the buffer at which the output is too long, or 0 for no limit.
+	limit int
+	// ceiling is the length of the buffer at which the output is too long even while the limit is suspended, or 0 for no ceiling.
+	ceiling int
+	// rules holds the innermost Define of each rule name.
+	rules map[string]*rule
+	// ctx is the context of the generation or nil, which Parts that block observe to return early.
+	ctx context.Context
+	// cover counts the selected choices of OneOf Parts, keyed by their first choice.
+	cover map[any][]int
+	// last holds the position of the previous output of each NoConsecutive, keyed by the key of the Part.
+	last map[*byte]span
+}
+
+// runPart is implemented by Parts that use the state of a run.
+type runPart interface {
+	appendRun(r *run, b []byte) []byte
+}
+
+// appendRun appends p to b using the state of r.
+// If the output exceeds the limit of r, appendRun aborts the generation.
+func appendRun(r *run, p Part, b []byte) []byte {
+	if v, ok := p.(runPart); ok {
+		b = v.appendRun(r, b)
+	} else {
+		b = p.Append(b)
+	}
+
+	if r != nil && r.limit > 0 && len(b) >= r.limit {
+		panic(outputLimit{b: b})
+	}
+	if r != nil && r.ceiling > 0 && len(b) >= r.ceiling {
+		panic(outputLimit{b: b, ceiling: true})
+	}
+	return b
+}
+
+// suspendLimit removes the output limit of r and returns it for restoreLimit.
+// Parts that rewrite or regenerate the output of their children suspend the limit while their children run,
+// so that the limit is only checked on their final output and the output is always a prefix of the final output.
+// The ceiling is not suspended, so the children can't grow without bounds.
+func (r *run) suspendLimit() int {
+	if r == nil {
+		return 0
+	}
+	l := r.limit
+	r.limit = 0
+	return l
+}
+
+// restoreLimit restores the output limit of r removed by suspendLimit.
+func (r *run) restoreLimit(l int) {
+	if r != nil {
+		r.limit = l
+	}
+}
+
+// done returns a channel that is closed when the context of r is cancelled, or nil if r has no context.
+func (r *run) done() <-chan struct{} {
+	if r == nil || r.ctx == nil {
+		return nil
+	}
+	return r.ctx.Done()
+}
+
+// covered records that choice i of the OneOf Part identified by key was selected.
+func (r *run) covered(key any, i uint32) {
+	if r == nil || r.cover == nil {
+		return
+	}
+	if c := r.cover[key]; c != nil {
+		c[i]++
+	}
+}
+
+// uint64 returns a random uint64.
+func (r *run) uint64() uint64 {
+	if r == nil || r.src == nil {
+		return internal.Fastrand()
+	}
+	return r.src.Uint64()
+}
+
+// read fills b with random bytes.
+func (r *run) read(b []byte) {
+	for i := 0; i < len(b); i += 8 {
+		v := r.uint64()
+		for j := i; j < len(b) && j < i+8; j++ {
+			b[j] = byte(v)
+			v >>= 8
+		}
+	}
+}
+
+// pure reports whether stateful Parts have to draw their output from the source of randomness.
+func (r *run) pure() bool {
+	return r != nil && r.deterministic
+}
+
+// uint64N returns a random uint64 in [0, n).
+// If n is 0, the result covers all uint64.
+func (r *run) uint64N(n uint64) uint64 {
+	if n == 0 {
+		return r.uint64()
+	}
+	res, _ := bits.Mul64(n, r.uint64())
+	return res
+}
+
+// randN returns a random uint32 in [0, n).
+func (r *run) randN(n uint32) uint32 {
+	if r == nil || r.src == nil {
+		return internal.RandN(n)
+	}
+	return internal.Reduce(n, r.src.Uint64())
+}
+
+// uniformN returns a random uint32 in [0, n) without modulo bias.
+func (r *run) uniformN(n uint32) uint32 {
+	if r == nil || r.src == nil {
+		return internal.UniformN(n)
+	}
+	return internal.UniformNSource(r.src, n)
+}
+
+// float64 returns a random float64 in [0.0, 1.0).
+func (r *run) float64() float64 {
+	if r == nil || r.src == nil {
+		return internal.RandFloat64()
+	}
+	return internal.Float64(r.src.Uint64())
+}
@@ -0,0 +1,101 @@
+package pattern
+
+import (
+	"regexp/syntax"
+	"strconv"
+	"strings"
+)
+
+// Schema is a JSON Schema fragment describing the strings generated by a pattern.
+//
+// https://json-schema.org/understanding-json-schema/reference/string
+type Schema struct {
+	Type      string `json:"type"`
+	Pattern   string `json:"pattern"`
+	MinLength int    `json:"minLength"`
+	MaxLength int    `json:"maxLength"`
+}
+
+// JSONSchema returns a JSON Schema fragment for the outputs of the pattern,
+// so API schemas stay in sync with the generator used to mint the values.
+// The pattern is the regular expression of Pattern in ECMA-262 syntax with Unicode escapes, anchored as a whole as in ^(?:expr)$,
+// the lengths are counted in characters like JSON Schema does.
+// JSONSchema panics if the pattern contains Parts that can not be expressed as a regular expression.
+func (g gen) JSONSchema() Schema {
+	re := toRegexp(g)
+	min, max := regexpLen(re)
+	return Schema{
+		Type:      "string",
+		Pattern:   "^(?:" + ecmaRegexp(re.String()) + ")$",
+		MinLength: min,
+		MaxLength: max,
+	}
+}
+
+// regexpLen returns the minimum and maximum number of runes matched by re, which must be bounded.
+func regexpLen(re *syntax.Regexp) (int, int) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return len(re.Rune), len(re.Rune)
+	case syntax.OpCharClass, syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return 1, 1
+	case syntax.OpCapture:
+		return regexpLen(re.Sub[0])
+	case syntax.OpQuest:
+		_, max := regexpLen(re.Sub[0])
+		return 0, max
+	case syntax.OpRepeat:
+		min, max := regexpLen(re.Sub[0])
+		return re.Min * min, re.Max * max
+	case syntax.OpConcat:
+		var min, max int
+		for _, sub := range re.Sub {
+			lo, hi := regexpLen(sub)
+			min += lo
+			max += hi
+		}
+		return min, max
+	case syntax.OpAlternate:
+		min, max := regexpLen(re.Sub[0])
+		for _, sub := range re.Sub[1:] {
+			lo, hi := regexpLen(sub)
+			if lo < min {
+				min = lo
+			}
+			if hi > max {
+				max = hi
+			}
+		}
+		return min, max
+	}
+	return 0, 0
+}
+
+// ecmaRegexp rewrites the \x{...} escapes of Go regular expressions to the \u escapes of ECMA-262.
+func ecmaRegexp(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+
+		end := strings.IndexByte(s[i:], '}')
+		if s[i+1] != 'x' || i+2 == len(s) || s[i+2] != '{' || end < 0 {
+			// Copy other escapes including the escaped character.
+			sb.WriteString(s[i : i+2])
+			i++
+			continue
+		}
+
+		hex := s[i+3 : i+end]
+		r, _ := strconv.ParseUint(hex, 16, 32)
+		if r <= 0xFFFF {
+			sb.WriteString(`\u` + strings.Repeat("0", 4-len(hex)) + hex)
+		} else {
+			sb.WriteString(`\u{` + hex + `}`)
+		}
+		i += end
+	}
+	return sb.String()
+}
@@ -0,0 +1,56 @@
+package pattern
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	tests := []struct {
+		gen  *gen
+		want Schema
+	}{
+		{
+			New(
+				Literal("id-"),
+				Repeat(2, 4, Literal("ab"), OneOfByte([]byte("xyz0123"))),
+				Potentially(0.3, OneOf(Literal("a.b"), OneOfString([]string{"c", "dd"}))),
+				NanoID(5, []byte("abc")),
+			),
+			Schema{"string", `^(?:id-(?:ab[0-3x-z]){2,4}(?:a\.b|c|dd)?[a-c]{5})$`, 14, 23},
+		},
+		{MustCompile(`ä\x01[\x{100}-\x{3ff}\x{10000}-\x{10ffff}]?`), Schema{"string", `^(?:ä\x01[Ā-Ͽ𐀀-\u{10ffff}]?)$`, 2, 3}},
+		{New(MustCompile(`[^a]`), Literal(`\x{41}`)), Schema{"string", `^(?:[^a\ud800-\udfff]\\x\{41\})$`, 7, 7}},
+		{New(), Schema{"string", `^(?:(?:))$`, 0, 0}},
+		{New(OneOfString([]string{"foo", "bar"})), Schema{"string", `^(?:foo|bar)$`, 3, 3}},
+	}
+
+	for _, test := range tests {
+		if got := test.gen.JSONSchema(); got != test.want {
+			t.Errorf("JSONSchema returned invalid value: want %+v, got %+v", test.want, got)
+		}
+	}
+}
+
+func TestJSONSchemaAlternation(t *testing.T) {
+	re := regexp.MustCompile(New(OneOfString([]string{"foo", "bar"})).JSONSchema().Pattern)
+	for _, v := range []string{"fooXXXX", "XXbar"} {
+		if re.MatchString(v) {
+			t.Errorf("JSONSchema pattern %s matches %s", strconv.Quote(re.String()), strconv.Quote(v))
+		}
+	}
+}
+
+func TestJSONSchemaMarshal(t *testing.T) {
+	data, err := json.Marshal(New(Literal("a"), Repeat(1, 3, OneOfByte([]byte("0123456789")))).JSONSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"type":"string","pattern":"^(?:a[0-9]{1,3})$","minLength":2,"maxLength":4}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
@@ -0,0 +1,102 @@
+package pattern
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"regexp"
+)
+
+// ScrubWriter returns a writer that replaces every match of re in the data written to it with the result of replace and writes the result to w.
+// Matching is done line by line, so matches can not span multiple lines.
+// Incomplete lines are buffered until the next newline or until Close is called.
+// Close does not close w.
+//
+// Use the Transform method of a generator as replace to replace equal matches with equal values,
+// or a function returning the String of a generator to replace every match with a fresh value.
+func ScrubWriter(w io.Writer, re *regexp.Regexp, replace func(match string) string) io.WriteCloser {
+	return &scrubWriter{
+		w:       w,
+		re:      re,
+		replace: replace,
+	}
+}
+
+type scrubWriter struct {
+	w       io.Writer
+	re      *regexp.Regexp
+	replace func(string) string
+	buf     []byte
+}
+
+func (s *scrubWriter) Write(p []byte) (int, error) {
+	s.buf = append(s.buf, p...)
+
+	i := bytes.LastIndexByte(s.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+
+	if _, err := s.w.Write(scrub(s.re, s.replace, s.buf[:i+1])); err != nil {
+		return 0, err
+	}
+
+	n := copy(s.buf, s.buf[i+1:])
+	s.buf = s.buf[:n]
+	return len(p), nil
+}
+
+func (s *scrubWriter) Close() error {
+	if len(s.buf) == 0 {
+		return nil
+	}
+
+	_, err := s.w.Write(scrub(s.re, s.replace, s.buf))
+	s.buf = s.buf[:0]
+	return err
+}
+
+// ScrubReader returns a reader that reads from r and replaces every match of re with the result of replace.
+// Matching is done line by line, so matches can not span multiple lines.
+//
+// See ScrubWriter for suitable replace functions.
+func ScrubReader(r io.Reader, re *regexp.Regexp, replace func(match string) string) io.Reader {
+	return &scrubReader{
+		r:       bufio.NewReader(r),
+		re:      re,
+		replace: replace,
+	}
+}
+
+type scrubReader struct {
+	r       *bufio.Reader
+	re      *regexp.Regexp
+	replace func(string) string
+	// out holds scrubbed data that was not read yet.
+	out []byte
+	err error
+}
+
+func (s *scrubReader) Read(p []byte) (int, error) {
+	for len(s.out) == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+
+		var line []byte
+		line, s.err = s.r.ReadBytes('\n')
+		if len(line) > 0 {
+			s.out = scrub(s.re, s.replace, line)
+		}
+	}
+
+	n := copy(p, s.out)
+	s.out = s.out[n:]
+	return n, nil
+}
+
+func scrub(re *regexp.Regexp, replace func(string) string, b []byte) []byte {
+	return re.ReplaceAllFunc(b, func(match []byte) []byte {
+		return []byte(replace(string(match)))
+	})
+}
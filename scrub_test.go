@@ -0,0 +1,67 @@
+package pattern
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var (
+	scrubInput = "login user=alice@example.com\nlogin user=bob@example.com\nlogout user=alice@example.com"
+	scrubRe    = regexp.MustCompile(`[a-z]+@example\.com`)
+	scrubGen   = New(Literal("user"), Repeat(6, 6, OneOfByte([]byte("0123456789"))), Literal("@example.org"))
+	scrubWant  = regexp.MustCompile(`^login user=(user\d{6}@example\.org)\nlogin user=(user\d{6}@example\.org)\nlogout user=(user\d{6}@example\.org)$`)
+)
+
+func checkScrubbed(t *testing.T, out string) {
+	t.Helper()
+
+	m := scrubWant.FindStringSubmatch(out)
+	if m == nil {
+		t.Fatalf("scrubbed output has invalid format: got %s", strconv.Quote(out))
+	}
+
+	if m[1] != m[3] {
+		t.Errorf("equal matches were replaced with different values: %s != %s", strconv.Quote(m[1]), strconv.Quote(m[3]))
+	}
+
+	if m[1] == m[2] {
+		t.Errorf("different matches were replaced with equal values: %s", strconv.Quote(m[1]))
+	}
+}
+
+func TestScrubWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := ScrubWriter(&buf, scrubRe, scrubGen.Transform)
+
+	// Write in small chunks to split matches.
+	for i := 0; i < len(scrubInput); i += 7 {
+		end := i + 7
+		if end > len(scrubInput) {
+			end = len(scrubInput)
+		}
+		if _, err := w.Write([]byte(scrubInput[i:end])); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	checkScrubbed(t, buf.String())
+}
+
+func TestScrubReader(t *testing.T) {
+	r := ScrubReader(strings.NewReader(scrubInput), scrubRe, scrubGen.Transform)
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll returned error: %v", err)
+	}
+
+	checkScrubbed(t, string(out))
+}
@@ -0,0 +1,111 @@
+//go:build go1.23
+
+package pattern
+
+import (
+	"iter"
+)
+
+// Seq returns an endless sequence of generated patterns.
+func (g gen) Seq() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for {
+			if !yield(g.String()) {
+				return
+			}
+		}
+	}
+}
+
+// SeqN returns a sequence of n generated patterns.
+func (g gen) SeqN(n int) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for i := 0; i < n; i++ {
+			if !yield(g.String()) {
+				return
+			}
+		}
+	}
+}
+
+// All returns a sequence of every possible output of the pattern in a deterministic order.
+// Outputs that can be generated in several ways (e.g. OneOfString("a", "a")) are yielded once for each way.
+// Stateful Parts like Sequence are enumerated over their whole range without being advanced.
+// All panics if the pattern contains Parts whose outputs can not be enumerated, like custom Parts or Timestamp.
+func (g gen) All() iter.Seq[string] {
+	if !canEnumerate(g) {
+		panic("pattern contains parts that can not be enumerated")
+	}
+
+	return func(yield func(string) bool) {
+		b := make([]byte, 0, g.bufferCap())
+		g.enumerate(b, func(b []byte) bool {
+			return yield(string(b))
+		})
+	}
+}
+
+// Sample returns a sequence of n outputs drawn at random without replacement from all possible outputs of the pattern.
+// The outputs are selected by a pseudo-random permutation of their position in All, so the output space is never materialized.
+// Each iteration over the sequence draws a new sample.
+// If the pattern has fewer than n outputs, all of them are yielded, and none are yielded if n is <= 0.
+// The permutation is drawn from the source of randomness of the generator, e.g. WithSeed.
+// As with All, outputs that can be generated in several ways may be yielded more than once.
+// Sample panics if the pattern contains Parts whose outputs can not be counted, like custom Parts or Sequence with skipped values,
+// or if the number of outputs exceeds the range of uint64.
+func (g gen) Sample(n int) iter.Seq[string] {
+	if !canCount(g) {
+		panic("pattern contains parts that can not be counted")
+	}
+
+	c, ok := g.count()
+	if !ok {
+		panic("pattern has too many outputs to sample")
+	}
+
+	if n < 0 {
+		n = 0
+	}
+
+	return func(yield func(string) bool) {
+		perm := PermutedSequence(0, c-1, 0, g.run(0).uint64()).(permutation)
+
+		b := make([]byte, 0, g.bufferCap())
+		for i := uint64(0); i < uint64(n) && i < c; i++ {
+			if !yield(string(g.unrank(b[:0], perm.permute(i)))) {
+				return
+			}
+		}
+	}
+}
+
+// Shrink returns a sequence of outputs of the pattern that are simpler than s, e.g. with fewer repetitions or earlier alphabet members.
+// The most aggressive simplifications come first.
+// A property test typically checks the candidates in order and calls Shrink again with the first candidate that still fails, until none fails.
+// The sequence is empty if s is not an output of the pattern.
+// Shrink panics if the pattern contains Parts whose choices can not be recovered from an output, like custom Parts or Timestamp.
+func (g gen) Shrink(s string) iter.Seq[string] {
+	if !canDerive(g) {
+		panic("pattern contains parts that can not be shrunk")
+	}
+
+	return func(yield func(string) bool) {
+		d := deriveAll(g, []byte(s))
+		if d == nil {
+			return
+		}
+
+		seen := map[string]bool{s: true}
+		b := make([]byte, 0, g.bufferCap())
+		g.shrink(d, func(d *derivation) bool {
+			b = g.build(b[:0], d)
+			if seen[string(b)] {
+				return true
+			}
+
+			v := string(b)
+			seen[v] = true
+			return yield(v)
+		})
+	}
+}
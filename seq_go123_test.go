@@ -0,0 +1,378 @@
+//go:build go1.23
+
+package pattern
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSeq(t *testing.T) {
+	gen := New(Sequence(1, 100, 0))
+
+	i := 0
+	for v := range gen.Seq() {
+		i++
+		if v != strconv.Itoa(i) {
+			t.Errorf("Seq returned invalid value: want %d, got %s", i, strconv.Quote(v))
+		}
+		if i == 10 {
+			break
+		}
+	}
+
+	if i != 10 {
+		t.Errorf("Seq returned invalid number of values: want 10, got %d", i)
+	}
+}
+
+func TestSeqN(t *testing.T) {
+	gen := New(Literal("o"))
+
+	for _, n := range []int{0, 1, 1000} {
+		i := 0
+		for v := range gen.SeqN(n) {
+			if v != "o" {
+				t.Errorf("SeqN returned invalid value: want \"o\", got %s", strconv.Quote(v))
+			}
+			i++
+		}
+
+		if i != n {
+			t.Errorf("SeqN returned invalid number of values: want %d, got %d", n, i)
+		}
+	}
+
+	// Stop early.
+	i := 0
+	for range gen.SeqN(100) {
+		i++
+		if i == 5 {
+			break
+		}
+	}
+
+	if i != 5 {
+		t.Errorf("SeqN did not stop: want 5 values, got %d", i)
+	}
+}
+
+func TestAll(t *testing.T) {
+	gen := New(
+		Literal("x-"),
+		OneOfByte([]byte("ab")),
+		Potentially(0.1, Literal("!")),
+		Repeat(1, 2, OneOfString([]string{"0", "1"})),
+	)
+
+	want := []string{
+		"x-a0", "x-a1", "x-a00", "x-a01", "x-a10", "x-a11",
+		"x-a!0", "x-a!1", "x-a!00", "x-a!01", "x-a!10", "x-a!11",
+		"x-b0", "x-b1", "x-b00", "x-b01", "x-b10", "x-b11",
+		"x-b!0", "x-b!1", "x-b!00", "x-b!01", "x-b!10", "x-b!11",
+	}
+
+	var got []string
+	for v := range gen.All() {
+		got = append(got, v)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("All returned invalid number of values: want %d, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("All returned invalid value: want %s, got %s", strconv.Quote(want[i]), strconv.Quote(got[i]))
+		}
+	}
+}
+
+func TestAllParts(t *testing.T) {
+	tests := []struct {
+		gen  *gen
+		want []string
+	}{
+		{New(Shuffle(Literal("a"), Literal("b"), Literal("c"))), []string{"abc", "acb", "bac", "bca", "cab", "cba"}},
+		{New(Sequence(1, 9, 2, SequenceStep(3))), []string{"01", "04", "07"}},
+		{New(Sequence(1, 4, 0, SequenceDescending(), SequenceSkip(3))), []string{"4", "2", "1"}},
+		{New(NanoID(2, []byte("xy"))), []string{"xx", "xy", "yx", "yy"}},
+		{New(OneOf(Literal("a"), OneOfRune([]rune("äö")))), []string{"a", "ä", "ö"}},
+		{MustCompile(`[a-c]|z`), []string{"a", "b", "c", "z"}},
+	}
+
+	for _, test := range tests {
+		var got []string
+		for v := range test.gen.All() {
+			got = append(got, v)
+		}
+
+		if strings.Join(got, ",") != strings.Join(test.want, ",") {
+			t.Errorf("All returned invalid values: want %q, got %q", test.want, got)
+		}
+	}
+}
+
+func TestAllPermutedSequence(t *testing.T) {
+	gen := New(PermutedSequence(10, 99, 0, 42))
+
+	seen := make(map[string]bool)
+	for v := range gen.All() {
+		seen[v] = true
+	}
+
+	if len(seen) != 90 {
+		t.Errorf("All returned invalid number of distinct values: want 90, got %d", len(seen))
+	}
+}
+
+func TestAllStop(t *testing.T) {
+	gen := New(Sequence(0, 1<<62, 0))
+
+	i := 0
+	for range gen.All() {
+		i++
+		if i == 5 {
+			break
+		}
+	}
+
+	if i != 5 {
+		t.Errorf("All did not stop: want 5 values, got %d", i)
+	}
+
+	// Enumerating does not advance the sequence.
+	if v := gen.String(); v != "0" {
+		t.Errorf("All advanced the sequence: want \"0\", got %s", strconv.Quote(v))
+	}
+}
+
+func TestAllPanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("All did not panic on a Part that can not be enumerated")
+			}
+		}()
+		New(Literal("a"), Timestamp(time.RFC3339)).All()
+	}()
+}
+
+func TestUnrank(t *testing.T) {
+	gen := New(
+		Shuffle(Literal("a"), OneOfString([]string{"b", "c"}), Literal("d")),
+		Potentially(0.5, Literal("-")),
+		Repeat(0, 2, OneOfByte([]byte("xyz"))),
+		NanoID(2, []byte("01")),
+		Sequence(5, 20, 3, SequenceStep(5), SequenceDescending()),
+		MustCompile(`[α-γ]`),
+	)
+
+	c, ok := gen.count()
+	if !ok {
+		t.Fatalf("count overflowed")
+	}
+
+	i := uint64(0)
+	for v := range gen.All() {
+		if got := string(gen.unrank(nil, i)); got != v {
+			t.Fatalf("unrank(%d) returned invalid value: want %s, got %s", i, strconv.Quote(v), strconv.Quote(got))
+		}
+		i++
+	}
+
+	if i != c {
+		t.Errorf("count returned invalid value: want %d, got %d", i, c)
+	}
+}
+
+func TestUnrankSampleK(t *testing.T) {
+	gen := New(SampleK(2, Literal("a"), OneOfString([]string{"b", "c"}), OneOfByte([]byte("xyz"))))
+
+	c, ok := gen.count()
+	if !ok {
+		t.Fatalf("count overflowed")
+	}
+
+	// 2! * (1*2 + 1*3 + 2*3)
+	if c != 22 {
+		t.Errorf("count returned invalid value: want 22, got %d", c)
+	}
+
+	i := uint64(0)
+	seen := make(map[string]bool)
+	for v := range gen.All() {
+		if got := string(gen.unrank(nil, i)); got != v {
+			t.Fatalf("unrank(%d) returned invalid value: want %s, got %s", i, strconv.Quote(v), strconv.Quote(got))
+		}
+		seen[v] = true
+		i++
+	}
+
+	if i != c || len(seen) != int(c) {
+		t.Errorf("All returned invalid number of values: want %d, got %d (%d distinct)", c, i, len(seen))
+	}
+}
+
+func TestSample(t *testing.T) {
+	gen := New(OneOfByte([]byte("abcd")), Repeat(1, 2, OneOfByte([]byte("01"))))
+
+	// Sampling the whole space yields every output exactly once.
+	seen := make(map[string]bool)
+	for v := range gen.Sample(100) {
+		if seen[v] {
+			t.Errorf("Sample returned duplicate value: %s", strconv.Quote(v))
+		}
+		seen[v] = true
+	}
+
+	for v := range gen.All() {
+		if !seen[v] {
+			t.Errorf("Sample did not return value: %s", strconv.Quote(v))
+		}
+	}
+	if len(seen) != 24 {
+		t.Errorf("Sample returned invalid number of values: want 24, got %d", len(seen))
+	}
+}
+
+func TestSampleSeedNegative(t *testing.T) {
+	sample := func() []string {
+		var out []string
+		for v := range NewWithOptions([]Option{WithSeed(3)}, NanoID(8, []byte("abcdef"))).Sample(10) {
+			out = append(out, v)
+		}
+		return out
+	}
+
+	// The permutation is drawn from the seeded source.
+	a, b := sample(), sample()
+	if strings.Join(a, ",") != strings.Join(b, ",") {
+		t.Errorf("Sample with the same seed returned different values: %q and %q", a, b)
+	}
+
+	for v := range New(OneOfByte([]byte("ab"))).Sample(-1) {
+		t.Errorf("Sample with n < 0 returned value: %s", strconv.Quote(v))
+	}
+}
+
+func TestSampleLarge(t *testing.T) {
+	gen := New(Literal("id-"), NanoID(12, []byte("0123456789abcdefghijklmnopqrstuvwxyz")))
+
+	seen := make(map[string]bool)
+	for v := range gen.Sample(10000) {
+		if len(v) != 15 || v[:3] != "id-" {
+			t.Errorf("Sample returned invalid value: %s", strconv.Quote(v))
+		}
+		seen[v] = true
+	}
+
+	if len(seen) != 10000 {
+		t.Errorf("Sample returned invalid number of distinct values: want 10000, got %d", len(seen))
+	}
+}
+
+func TestSamplePanic(t *testing.T) {
+	tests := []*gen{
+		New(Sequence(1, 10, 0, SequenceSkip(5))),
+		New(NanoID(20, []byte("0123456789abcdefghijklmnopqrstuvwxyz"))),
+	}
+
+	for _, gen := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Sample did not panic")
+				}
+			}()
+			gen.Sample(1)
+		}()
+	}
+}
+
+func TestShrink(t *testing.T) {
+	gen := New(
+		Literal("id-"),
+		Repeat(1, 5, OneOfByte([]byte("abc"))),
+		Potentially(0.5, Literal("!")),
+		Sequence(10, 99, 3),
+	)
+
+	want := []string{
+		"id-b!042", "id-bac!042", "id-bcc!042", "id-acc!042",
+		"id-aacc!042", "id-baac!042", "id-babc!042", "id-baca!042", "id-bacb!042",
+		"id-bacc042",
+		"id-bacc!010", "id-bacc!026", "id-bacc!041",
+	}
+
+	var got []string
+	for v := range gen.Shrink("id-bacc!042") {
+		got = append(got, v)
+	}
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Shrink returned invalid values: want %q, got %q", want, got)
+	}
+
+	// Not an output of the pattern.
+	for v := range gen.Shrink("id-xyz") {
+		t.Errorf("Shrink returned value for invalid input: %s", strconv.Quote(v))
+	}
+}
+
+func TestShrinkAmbiguous(t *testing.T) {
+	gen := New(Repeat(1, 60, OneOfString([]string{"a", "aa"})))
+
+	// The number of ways to split the a's grows exponentially, none of them matches the b.
+	for v := range gen.Shrink(strings.Repeat("a", 32) + "b") {
+		t.Errorf("Shrink returned value for invalid input: %s", strconv.Quote(v))
+	}
+
+	var got []string
+	for v := range gen.Shrink(strings.Repeat("a", 32)) {
+		got = append(got, v)
+	}
+	if len(got) == 0 || got[0] != "a" {
+		t.Errorf("Shrink returned invalid values: want \"a\" first, got %q", got)
+	}
+}
+
+func TestShrinkMinimize(t *testing.T) {
+	gen := New(
+		Repeat(0, 10, OneOf(Literal("x"), OneOfByte([]byte("0123456789")))),
+		Shuffle(Literal("a"), Literal("b"), Literal("c")),
+	)
+
+	// The property fails for every value containing a 7 and a 5.
+	fails := func(s string) bool {
+		return strings.Contains(s, "7") && strings.Contains(s, "5")
+	}
+
+	s := "x7x95x3cba"
+	for shrunk := true; shrunk; {
+		shrunk = false
+		for v := range gen.Shrink(s) {
+			if fails(v) {
+				s = v
+				shrunk = true
+				break
+			}
+		}
+	}
+
+	if s != "75abc" {
+		t.Errorf("Shrink did not minimize: want \"75abc\", got %s", strconv.Quote(s))
+	}
+}
+
+func TestShrinkPanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Shrink did not panic on a Part that can not be shrunk")
+			}
+		}()
+		New(Timestamp(time.RFC3339)).Shrink("")
+	}()
+}
@@ -0,0 +1,136 @@
+package pattern
+
+import (
+	"sync"
+	"time"
+)
+
+// Period maps a point in time to a bucket.
+// Two points in time belong to the same bucket if Period returns the same value for both.
+type Period func(t time.Time) int64
+
+// Daily is a Period that changes at midnight of t's location.
+func Daily(t time.Time) int64 {
+	y, m, d := t.Date()
+	return int64(y)*10000 + int64(m)*100 + int64(d)
+}
+
+// Monthly is a Period that changes at the first day of each month in t's location.
+func Monthly(t time.Time) int64 {
+	y, m, _ := t.Date()
+	return int64(y)*100 + int64(m)
+}
+
+// Every returns a Period that changes every d, starting at the Unix epoch.
+//
+// Panics if d is <= 0.
+func Every(d time.Duration) Period {
+	if d <= 0 {
+		panic("duration must be > 0")
+	}
+
+	return func(t time.Time) int64 {
+		return t.UnixNano() / int64(d)
+	}
+}
+
+// SequencePer returns a Part that behaves like Sequence, but also resets to start whenever the current time moves into a new period.
+// The number will be zero-padded to width.
+// For example, SequencePer(Daily, 1, 99999, 5) generates numbers starting at 00001 each day.
+// Periods must increase over time; a time in an earlier bucket than the current one, e.g. read by a goroutine that was preempted,
+// continues the current bucket instead of resetting it again.
+func SequencePer(period Period, start uint64, max uint64, width int) Part {
+	if max < start {
+		panic("max must be >= min")
+	}
+
+	return sequencePer{
+		period: period,
+		start:  start,
+		max:    max,
+		width:  width,
+		state:  &sequencePerState{},
+	}
+}
+
+type sequencePer struct {
+	period Period
+	start  uint64
+	max    uint64
+	width  int
+	state  *sequencePerState
+}
+
+type sequencePerState struct {
+	// The bucket and the counter have to change together,
+	// so a mutex is used instead of atomics.
+	mu     sync.Mutex
+	valid  bool
+	bucket int64
+	curr   uint64
+}
+
+func (p sequencePer) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p sequencePer) appendRun(r *run, b []byte) []byte {
+	if r.pure() {
+		return appendInt(b, p.start+r.uint64N(p.max-p.start+1), p.width)
+	}
+
+	bucket := p.period(now())
+
+	p.state.mu.Lock()
+	// The clock is read before locking, so only reset when the bucket advances.
+	if !p.state.valid || bucket > p.state.bucket {
+		p.state.valid = true
+		p.state.bucket = bucket
+		p.state.curr = p.start
+	} else {
+		p.state.curr++
+		if p.state.curr > p.max || p.state.curr < p.start {
+			p.state.curr = p.start
+		}
+	}
+	curr := p.state.curr
+	p.state.mu.Unlock()
+
+	return appendInt(b, curr, p.width)
+}
+
+// Counter is a source of monotonically increasing numbers, e.g. a Redis INCR or a database sequence.
+// Next must be safe for concurrent use.
+type Counter interface {
+	// Next returns the next number.
+	Next() (uint64, error)
+}
+
+// SequenceBackend returns a Part that will output the next number of c in each iteration.
+// The number will be zero-padded to width.
+// Unlike Sequence, the state lives in c, which allows multiple processes to share a sequence.
+//
+// The Part panics if c returns an error.
+func SequenceBackend(c Counter, width int) Part {
+	if c == nil {
+		panic("counter must not be nil")
+	}
+
+	return sequenceBackend{
+		counter: c,
+		width:   width,
+	}
+}
+
+type sequenceBackend struct {
+	counter Counter
+	width   int
+}
+
+func (p sequenceBackend) Append(b []byte) []byte {
+	u, err := p.counter.Next()
+	if err != nil {
+		panic("counter failed: " + err.Error())
+	}
+	return appendInt(b, u, p.width)
+}
@@ -0,0 +1,343 @@
+package pattern
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSequencePer(t *testing.T) {
+	ts := time.Date(2024, 1, 31, 23, 59, 0, 0, time.UTC)
+	setNow(t, func() time.Time {
+		return ts
+	})
+
+	gen := New(Literal("INV-"), Timestamp("20060102"), Literal("-"), SequencePer(Daily, 1, 99999, 5))
+
+	for _, want := range []string{"INV-20240131-00001", "INV-20240131-00002", "INV-20240131-00003"} {
+		p := gen.String()
+		if p != want {
+			t.Errorf("SequencePer returned invalid value: want %s, got %s", strconv.Quote(want), strconv.Quote(p))
+		}
+	}
+
+	ts = ts.Add(time.Minute)
+
+	for _, want := range []string{"INV-20240201-00001", "INV-20240201-00002"} {
+		p := gen.String()
+		if p != want {
+			t.Errorf("SequencePer returned invalid value: want %s, got %s", strconv.Quote(want), strconv.Quote(p))
+		}
+	}
+}
+
+func TestSequencePerOverflow(t *testing.T) {
+	setNow(t, func() time.Time {
+		return time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	})
+
+	gen := New(SequencePer(Monthly, 1, 2, 0))
+	for _, want := range []string{"1", "2", "1"} {
+		p := gen.String()
+		if p != want {
+			t.Errorf("SequencePer returned invalid value: want %s, got %s", strconv.Quote(want), strconv.Quote(p))
+		}
+	}
+}
+
+func TestSequencePerInterleaved(t *testing.T) {
+	// The third call read the clock before the second one, but locks after it.
+	day1 := time.Date(2024, 1, 31, 23, 59, 0, 0, time.UTC)
+	day2 := day1.Add(time.Minute)
+	times := []time.Time{day1, day2, day1, day2}
+	setNow(t, func() time.Time {
+		ts := times[0]
+		times = times[1:]
+		return ts
+	})
+
+	gen := New(SequencePer(Daily, 1, 99999, 0))
+	for _, want := range []string{"1", "1", "2", "3"} {
+		p := gen.String()
+		if p != want {
+			t.Errorf("SequencePer returned invalid value: want %s, got %s", strconv.Quote(want), strconv.Quote(p))
+		}
+	}
+}
+
+func TestSequencePerConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		n          = 1000
+	)
+
+	var mu sync.Mutex
+	ts := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	setNow(t, func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return ts
+	})
+
+	gen := New(SequencePer(Every(time.Hour), 1, goroutines*n, 0))
+
+	var wg sync.WaitGroup
+	results := make([][]string, goroutines)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < n; j++ {
+				results[i] = append(results[i], gen.String())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool, goroutines*n)
+	for _, r := range results {
+		for _, v := range r {
+			if seen[v] {
+				t.Errorf("SequencePer returned duplicate value %s", strconv.Quote(v))
+			}
+			seen[v] = true
+		}
+	}
+
+	mu.Lock()
+	ts = ts.Add(time.Hour)
+	mu.Unlock()
+
+	p := gen.String()
+	if p != "1" {
+		t.Errorf("SequencePer did not reset: want \"1\", got %s", strconv.Quote(p))
+	}
+}
+
+func TestSequencePerPanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("SequencePer with max < start did not panic")
+			}
+		}()
+
+		New(SequencePer(Daily, 2, 1, 0))
+	}()
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Every with d == 0 did not panic")
+			}
+		}()
+
+		Every(0)
+	}()
+}
+
+func TestSequenceBase(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    uint64
+		width    int
+		alphabet string
+		want     []string
+	}{
+		{"binary", 0, 3, "01", []string{"000", "001", "010", "011", "100"}},
+		{"base36", 34, 2, digits36, []string{"0y", "0z", "10", "11"}},
+		{"base62", 60, 0, digits62, []string{"y", "z", "10"}},
+		{"crockford", 31, 0, "0123456789ABCDEFGHJKMNPQRSTVWXYZ", []string{"Z", "10"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := New(SequenceBase(tt.start, 1000, tt.width, []byte(tt.alphabet)))
+			for _, want := range tt.want {
+				p := gen.String()
+				if p != want {
+					t.Errorf("SequenceBase returned invalid value: want %s, got %s", strconv.Quote(want), strconv.Quote(p))
+				}
+			}
+		})
+	}
+}
+
+func TestSequenceBasePanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("SequenceBase with a single digit alphabet did not panic")
+			}
+		}()
+
+		New(SequenceBase(0, 1, 0, []byte("0")))
+	}()
+}
+
+func TestSequenceOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		start uint64
+		max   uint64
+		opts  []SequenceOption
+		want  []string
+	}{
+		{"step", 0, 25, []SequenceOption{SequenceStep(10)}, []string{"0", "10", "20", "0"}},
+		{"descending", 1, 3, []SequenceOption{SequenceDescending()}, []string{"3", "2", "1", "3"}},
+		{"descending step", 0, 25, []SequenceOption{SequenceDescending(), SequenceStep(10)}, []string{"25", "15", "5", "25"}},
+		{"skip", 1, 5, []SequenceOption{SequenceSkip(1, 3)}, []string{"2", "4", "5", "2"}},
+		{"skip func", 0, 10, []SequenceOption{SequenceSkipFunc(func(u uint64) bool { return u%2 == 1 })}, []string{"0", "2", "4", "6", "8", "10", "0"}},
+		{"max uint64", math.MaxUint64 - 1, math.MaxUint64, []SequenceOption{SequenceStep(2)}, []string{"18446744073709551614", "18446744073709551614"}},
+		{"zero descending", 0, 1, []SequenceOption{SequenceDescending(), SequenceStep(2)}, []string{"1", "1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := New(Sequence(tt.start, tt.max, 0, tt.opts...))
+			for _, want := range tt.want {
+				p := gen.String()
+				if p != want {
+					t.Errorf("Sequence returned invalid value: want %s, got %s", strconv.Quote(want), strconv.Quote(p))
+				}
+			}
+		})
+	}
+}
+
+func TestSequenceOptionsPanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("SequenceStep with step == 0 did not panic")
+			}
+		}()
+
+		SequenceStep(0)
+	}()
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Sequence skipping all values did not panic")
+			}
+		}()
+
+		id = New(Sequence(1, 2, 0, SequenceSkip(1, 2))).String()
+	}()
+}
+
+func TestSequenceDrawSparse(t *testing.T) {
+	// Only one value in a million is not skipped, so random draws hardly ever hit it.
+	gen := New(Sequence(0, 999999, 0, SequenceSkipFunc(func(u uint64) bool { return u != 123456 })))
+	for i := 0; i < 10; i++ {
+		if p := gen.Transform(strconv.Itoa(i)); p != "123456" {
+			t.Errorf("Sequence with sparse values returned invalid value: want \"123456\", got %s", strconv.Quote(p))
+		}
+	}
+}
+
+func TestSequencePersistence(t *testing.T) {
+	var saved uint64
+	save := func(u uint64) {
+		saved = u
+	}
+
+	gen := New(Sequence(1, 100, 0, SequenceOnUpdate(save)))
+	for _, want := range []string{"1", "2", "3"} {
+		p := gen.String()
+		if p != want {
+			t.Errorf("Sequence returned invalid value: want %s, got %s", strconv.Quote(want), strconv.Quote(p))
+		}
+	}
+
+	if saved != 3 {
+		t.Errorf("Sequence did not report the last value: want 3, got %d", saved)
+	}
+
+	// Simulate a restart.
+	gen = New(Sequence(1, 100, 0, SequenceResume(saved), SequenceOnUpdate(save)))
+	for _, want := range []string{"4", "5"} {
+		p := gen.String()
+		if p != want {
+			t.Errorf("resumed Sequence returned invalid value: want %s, got %s", strconv.Quote(want), strconv.Quote(p))
+		}
+	}
+
+	if saved != 5 {
+		t.Errorf("Sequence did not report the last value: want 5, got %d", saved)
+	}
+
+	// Sequences created with the same option count independently.
+	resume := SequenceResume(10)
+	g1, g2 := New(Sequence(1, 100, 0, resume)), New(Sequence(1, 100, 0, resume))
+	for _, want := range []string{"11", "12"} {
+		if p1, p2 := g1.String(), g2.String(); p1 != want || p2 != want {
+			t.Errorf("Sequences with a shared option returned invalid values: want %s, got %s and %s", strconv.Quote(want), strconv.Quote(p1), strconv.Quote(p2))
+		}
+	}
+
+	// Resuming at max resets the Sequence.
+	gen = New(Sequence(1, 100, 0, SequenceResume(100)))
+	p := gen.String()
+	if p != "1" {
+		t.Errorf("resumed Sequence returned invalid value: want \"1\", got %s", strconv.Quote(p))
+	}
+}
+
+type testCounter struct {
+	curr uint64
+	err  error
+}
+
+func (c *testCounter) Next() (uint64, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	return atomic.AddUint64(&c.curr, 1), nil
+}
+
+func TestSequenceBackend(t *testing.T) {
+	c := &testCounter{curr: 41}
+
+	gen := New(SequenceBackend(c, 4))
+	for _, want := range []string{"0042", "0043"} {
+		p := gen.String()
+		if p != want {
+			t.Errorf("SequenceBackend returned invalid value: want %s, got %s", strconv.Quote(want), strconv.Quote(p))
+		}
+	}
+
+	// A second generator shares the counter.
+	gen2 := New(SequenceBackend(c, 0))
+	p := gen2.String()
+	if p != "44" {
+		t.Errorf("SequenceBackend returned invalid value: want \"44\", got %s", strconv.Quote(p))
+	}
+}
+
+func TestSequenceBackendPanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("SequenceBackend with nil counter did not panic")
+			}
+		}()
+
+		New(SequenceBackend(nil, 0))
+	}()
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("SequenceBackend with failing counter did not panic")
+			}
+		}()
+
+		id = New(SequenceBackend(&testCounter{err: errors.New("unavailable")}, 0)).String()
+	}()
+}
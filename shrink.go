@@ -0,0 +1,989 @@
+package pattern
+
+import (
+	"bytes"
+	"sort"
+	"unicode/utf8"
+)
+
+// derivation records the choices a Part made to generate an output.
+type derivation struct {
+	// value is the choice of the Part, e.g. the index of the selected alphabet member or the number of repetitions.
+	value uint64
+	// order is the order of the children of a Shuffle.
+	order []int
+	// sub holds the derivations of the children in output order.
+	sub []*derivation
+}
+
+// derivable is implemented by Parts that can recover the choices that led to an output.
+type derivable interface {
+	// derive calls yield with each derivation of the Part that generates a prefix of s, together with the length of that prefix.
+	// It stops and returns false as soon as yield returns false.
+	// Whether yield returns false may only depend on the length, so derive can skip states it has already explored.
+	derive(s []byte, yield func(d *derivation, n int) bool) bool
+	// first returns the derivation of the first output in the enumeration order of All.
+	first() *derivation
+	// build appends the output of d to b.
+	build(b []byte, d *derivation) []byte
+	// shrink calls yield with derivations of simpler outputs than d, the most aggressive simplifications first.
+	shrink(d *derivation, yield func(*derivation) bool) bool
+	// mutate calls yield with derivations of outputs that differ from d by a single choice.
+	mutate(d *derivation, r *run, yield func(*derivation) bool) bool
+}
+
+// canDerive reports whether the choices of all parts of p can be recovered from an output.
+func canDerive(p Part) bool {
+	ok := true
+	walk(p, func(p Part) {
+		if _, d := p.(derivable); !d {
+			ok = false
+		}
+	})
+	return ok
+}
+
+// deriveAll returns the first derivation of p that generates exactly s or nil if there is none.
+func deriveAll(p derivable, s []byte) *derivation {
+	var found *derivation
+	p.derive(s, func(d *derivation, n int) bool {
+		if n == len(s) {
+			found = d
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// deriveGroup calls yield with the derivations of each child of parts that generate a prefix of s in order.
+func deriveGroup(parts []Part, s []byte, yield func(sub []*derivation, n int) bool) bool {
+	sub := make([]*derivation, len(parts))
+	var explored states
+
+	var rec func(i int, off int) bool
+	rec = func(i int, off int) bool {
+		if i == len(parts) {
+			return yield(append([]*derivation(nil), sub...), off)
+		}
+		if explored.has(uint64(i), off) {
+			return true
+		}
+
+		ok := parts[i].(derivable).derive(s[off:], func(d *derivation, n int) bool {
+			sub[i] = d
+			return rec(i+1, off+n)
+		})
+		if ok {
+			explored.add(uint64(i), off)
+		}
+		return ok
+	}
+
+	return rec(0, 0)
+}
+
+// states records the states of a derivation that have been explored without yield returning false.
+// Exploring such a state again only yields the same lengths, so derivations of ambiguous patterns take polynomial instead of exponential time.
+type states map[[2]uint64]bool
+
+func (m states) has(i uint64, off int) bool {
+	return m[[2]uint64{i, uint64(off)}]
+}
+
+func (m *states) add(i uint64, off int) {
+	if *m == nil {
+		*m = make(states)
+	}
+	(*m)[[2]uint64{i, uint64(off)}] = true
+}
+
+func firstGroup(parts []Part) []*derivation {
+	sub := make([]*derivation, len(parts))
+	for i, p := range parts {
+		sub[i] = p.(derivable).first()
+	}
+	return sub
+}
+
+func buildGroup(parts []Part, b []byte, sub []*derivation) []byte {
+	for i, p := range parts {
+		b = p.(derivable).build(b, sub[i])
+	}
+	return b
+}
+
+// shrinkGroup calls yield with copies of sub where a single child has been shrunk.
+func shrinkGroup(parts []Part, sub []*derivation, yield func([]*derivation) bool) bool {
+	for i, p := range parts {
+		ok := p.(derivable).shrink(sub[i], func(d *derivation) bool {
+			c := append([]*derivation(nil), sub...)
+			c[i] = d
+			return yield(c)
+		})
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// shrinkValue calls yield with values in [min, v) in decreasing order of simplicity.
+func shrinkValue(v uint64, min uint64, yield func(uint64) bool) bool {
+	if v <= min {
+		return true
+	}
+	if !yield(min) {
+		return false
+	}
+	if mid := min + (v-min)/2; mid > min && mid < v-1 {
+		if !yield(mid) {
+			return false
+		}
+	}
+	if v-1 > min {
+		return yield(v - 1)
+	}
+	return true
+}
+
+// decodeUint decodes s with the digits of alphabet.
+func decodeUint(s []byte, alphabet string) (uint64, bool) {
+	if len(s) == 0 {
+		return 0, false
+	}
+
+	base := uint64(len(alphabet))
+	var u uint64
+	for _, c := range s {
+		d := bytes.IndexByte([]byte(alphabet), c)
+		if d < 0 {
+			return 0, false
+		}
+
+		var ok bool
+		if u, ok = mulCount(u, base); !ok {
+			return 0, false
+		}
+		if u, ok = addCount(u, uint64(d)); !ok {
+			return 0, false
+		}
+	}
+	return u, true
+}
+
+// deriveNumber calls yield with each number formatted by format that is a prefix of s, the shortest first.
+func deriveNumber(s []byte, alphabet string, format func([]byte, uint64) []byte, yield func(u uint64, n int) bool) bool {
+	var buf []byte
+	for n := 1; n <= len(s); n++ {
+		u, ok := decodeUint(s[:n], alphabet)
+		if !ok {
+			break
+		}
+
+		// Only accept the prefix if it is the canonical representation of u.
+		buf = format(buf[:0], u)
+		if bytes.Equal(buf, s[:n]) && !yield(u, n) {
+			return false
+		}
+	}
+	return true
+}
+
+func (g gen) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return deriveGroup(g.parts, s, func(sub []*derivation, n int) bool {
+		return yield(&derivation{sub: sub}, n)
+	})
+}
+
+func (g gen) first() *derivation {
+	return &derivation{sub: firstGroup(g.parts)}
+}
+
+func (g gen) build(b []byte, d *derivation) []byte {
+	return buildGroup(g.parts, b, d.sub)
+}
+
+func (g gen) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return shrinkGroup(g.parts, d.sub, func(sub []*derivation) bool {
+		return yield(&derivation{sub: sub})
+	})
+}
+
+func (p nullpart) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return yield(&derivation{}, 0)
+}
+
+func (p nullpart) first() *derivation {
+	return &derivation{}
+}
+
+func (p nullpart) build(b []byte, d *derivation) []byte {
+	return b
+}
+
+func (p nullpart) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return true
+}
+
+func (p group) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return gen{parts: p}.derive(s, yield)
+}
+
+func (p group) first() *derivation {
+	return gen{parts: p}.first()
+}
+
+func (p group) build(b []byte, d *derivation) []byte {
+	return buildGroup(p, b, d.sub)
+}
+
+func (p group) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return gen{parts: p}.shrink(d, yield)
+}
+
+// repeated returns the children of a Repeat with n repetitions.
+func (p repeat) repeated(n uint64) []Part {
+	parts := make([]Part, 0, len(p.parts)*int(n))
+	for i := uint64(0); i < n; i++ {
+		parts = append(parts, p.parts...)
+	}
+	return parts
+}
+
+func (p repeat) derive(s []byte, yield func(*derivation, int) bool) bool {
+	max := uint64(p.min + p.maxr - 1)
+	var explored states
+
+	var rec func(count uint64, sub []*derivation, off int) bool
+	rec = func(count uint64, sub []*derivation, off int) bool {
+		if explored.has(count, off) {
+			return true
+		}
+		if count >= uint64(p.min) && !yield(&derivation{value: count, sub: sub}, off) {
+			return false
+		}
+		if count == max {
+			explored.add(count, off)
+			return true
+		}
+
+		ok := deriveGroup(p.parts, s[off:], func(next []*derivation, n int) bool {
+			// Empty repetitions beyond the minimum only add equivalent derivations.
+			if n == 0 && count >= uint64(p.min) {
+				return true
+			}
+			return rec(count+1, append(sub[:len(sub):len(sub)], next...), off+n)
+		})
+		if ok {
+			explored.add(count, off)
+		}
+		return ok
+	}
+
+	return rec(0, nil, 0)
+}
+
+func (p repeat) first() *derivation {
+	return &derivation{
+		value: uint64(p.min),
+		sub:   firstGroup(p.repeated(uint64(p.min))),
+	}
+}
+
+func (p repeat) build(b []byte, d *derivation) []byte {
+	return buildGroup(p.repeated(d.value), b, d.sub)
+}
+
+func (p repeat) shrink(d *derivation, yield func(*derivation) bool) bool {
+	l := uint64(len(p.parts))
+	min := uint64(p.min)
+
+	if d.value > min {
+		// Keep only the minimum number of repetitions.
+		if !yield(&derivation{value: min, sub: d.sub[:min*l]}) {
+			return false
+		}
+
+		// Remove a single repetition, the last one first.
+		for i := d.value; i > 0; i-- {
+			if i == d.value && d.value-1 == min {
+				// Same as keeping the minimum.
+				continue
+			}
+
+			sub := append([]*derivation(nil), d.sub[:(i-1)*l]...)
+			sub = append(sub, d.sub[i*l:]...)
+			if !yield(&derivation{value: d.value - 1, sub: sub}) {
+				return false
+			}
+		}
+	}
+
+	return shrinkGroup(p.repeated(d.value), d.sub, func(sub []*derivation) bool {
+		return yield(&derivation{value: d.value, sub: sub})
+	})
+}
+
+// optional implements derivable for Potentially.
+type optional struct {
+	part Part
+}
+
+func (p optional) derive(s []byte, yield func(*derivation, int) bool) bool {
+	if !yield(&derivation{}, 0) {
+		return false
+	}
+
+	return p.part.(derivable).derive(s, func(d *derivation, n int) bool {
+		return yield(&derivation{value: 1, sub: []*derivation{d}}, n)
+	})
+}
+
+func (p optional) first() *derivation {
+	return &derivation{}
+}
+
+func (p optional) build(b []byte, d *derivation) []byte {
+	if d.value == 0 {
+		return b
+	}
+	return p.part.(derivable).build(b, d.sub[0])
+}
+
+func (p optional) shrink(d *derivation, yield func(*derivation) bool) bool {
+	if d.value == 0 {
+		return true
+	}
+
+	if !yield(&derivation{}) {
+		return false
+	}
+
+	return p.part.(derivable).shrink(d.sub[0], func(sub *derivation) bool {
+		return yield(&derivation{value: 1, sub: []*derivation{sub}})
+	})
+}
+
+func (p potentially50) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return optional{p.part}.derive(s, yield)
+}
+
+func (p potentially50) first() *derivation {
+	return optional{p.part}.first()
+}
+
+func (p potentially50) build(b []byte, d *derivation) []byte {
+	return optional{p.part}.build(b, d)
+}
+
+func (p potentially50) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return optional{p.part}.shrink(d, yield)
+}
+
+func (p potentiallyP) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return optional{p.part}.derive(s, yield)
+}
+
+func (p potentiallyP) first() *derivation {
+	return optional{p.part}.first()
+}
+
+func (p potentiallyP) build(b []byte, d *derivation) []byte {
+	return optional{p.part}.build(b, d)
+}
+
+func (p potentiallyP) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return optional{p.part}.shrink(d, yield)
+}
+
+func (p literal) derive(s []byte, yield func(*derivation, int) bool) bool {
+	if !bytes.HasPrefix(s, p) {
+		return true
+	}
+	return yield(&derivation{}, len(p))
+}
+
+func (p literal) first() *derivation {
+	return &derivation{}
+}
+
+func (p literal) build(b []byte, d *derivation) []byte {
+	return append(b, p...)
+}
+
+func (p literal) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return true
+}
+
+func (p anyOf) derive(s []byte, yield func(*derivation, int) bool) bool {
+	for i, part := range p.parts {
+		ok := part.(derivable).derive(s, func(d *derivation, n int) bool {
+			return yield(&derivation{value: uint64(i), sub: []*derivation{d}}, n)
+		})
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (p anyOf) first() *derivation {
+	return &derivation{sub: []*derivation{p.parts[0].(derivable).first()}}
+}
+
+func (p anyOf) build(b []byte, d *derivation) []byte {
+	return p.parts[d.value].(derivable).build(b, d.sub[0])
+}
+
+func (p anyOf) shrink(d *derivation, yield func(*derivation) bool) bool {
+	ok := shrinkValue(d.value, 0, func(i uint64) bool {
+		return yield(&derivation{value: i, sub: []*derivation{p.parts[i].(derivable).first()}})
+	})
+	if !ok {
+		return false
+	}
+
+	return p.parts[d.value].(derivable).shrink(d.sub[0], func(sub *derivation) bool {
+		return yield(&derivation{value: d.value, sub: []*derivation{sub}})
+	})
+}
+
+// shrinkIndex calls yield with derivations of earlier alphabet members than d.
+func shrinkIndex(d *derivation, yield func(*derivation) bool) bool {
+	return shrinkValue(d.value, 0, func(i uint64) bool {
+		return yield(&derivation{value: i})
+	})
+}
+
+func (p anyOfString) derive(s []byte, yield func(*derivation, int) bool) bool {
+	for i, a := range p.alphabet {
+		if bytes.HasPrefix(s, []byte(a)) && !yield(&derivation{value: uint64(i)}, len(a)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p anyOfString) first() *derivation {
+	return &derivation{}
+}
+
+func (p anyOfString) build(b []byte, d *derivation) []byte {
+	return append(b, p.alphabet[d.value]...)
+}
+
+func (p anyOfString) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return shrinkIndex(d, yield)
+}
+
+func (p anyOfByte) derive(s []byte, yield func(*derivation, int) bool) bool {
+	if len(s) == 0 {
+		return true
+	}
+
+	for i, c := range p.alphabet {
+		if c == s[0] && !yield(&derivation{value: uint64(i)}, 1) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p anyOfByte) first() *derivation {
+	return &derivation{}
+}
+
+func (p anyOfByte) build(b []byte, d *derivation) []byte {
+	return append(b, p.alphabet[d.value])
+}
+
+func (p anyOfByte) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return shrinkIndex(d, yield)
+}
+
+func (p byteRange) derive(s []byte, yield func(*derivation, int) bool) bool {
+	if len(s) == 0 || s[0] < p.lo || uint32(s[0]-p.lo) >= p.n {
+		return true
+	}
+	return yield(&derivation{value: uint64(s[0] - p.lo)}, 1)
+}
+
+func (p byteRange) first() *derivation {
+	return &derivation{}
+}
+
+func (p byteRange) build(b []byte, d *derivation) []byte {
+	return p.unrank(b, d.value)
+}
+
+func (p byteRange) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return shrinkIndex(d, yield)
+}
+
+func (p anyOfRune) derive(s []byte, yield func(*derivation, int) bool) bool {
+	r, size := utf8.DecodeRune(s)
+	if size == 0 {
+		return true
+	}
+
+	for i, c := range p.alphabet {
+		if c == r && !yield(&derivation{value: uint64(i)}, size) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p anyOfRune) first() *derivation {
+	return &derivation{}
+}
+
+func (p anyOfRune) build(b []byte, d *derivation) []byte {
+	return utf8.AppendRune(b, p.alphabet[d.value])
+}
+
+func (p anyOfRune) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return shrinkIndex(d, yield)
+}
+
+func (p runeRanges) derive(s []byte, yield func(*derivation, int) bool) bool {
+	r, size := utf8.DecodeRune(s)
+	if size == 0 {
+		return true
+	}
+
+	var i uint64
+	for j := 0; j < len(p.pairs); j += 2 {
+		if r >= p.pairs[j] && r <= p.pairs[j+1] {
+			return yield(&derivation{value: i + uint64(r-p.pairs[j])}, size)
+		}
+		i += uint64(p.pairs[j+1]-p.pairs[j]) + 1
+	}
+	return true
+}
+
+func (p runeRanges) first() *derivation {
+	return &derivation{}
+}
+
+func (p runeRanges) build(b []byte, d *derivation) []byte {
+	return p.unrank(b, d.value)
+}
+
+func (p runeRanges) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return shrinkIndex(d, yield)
+}
+
+// ordered returns the children of a Shuffle in the given order.
+func (p shuffle) ordered(order []int) []Part {
+	parts := make([]Part, len(order))
+	for i, j := range order {
+		parts[i] = p.parts[j]
+	}
+	return parts
+}
+
+func (p shuffle) derive(s []byte, yield func(*derivation, int) bool) bool {
+	order := make([]int, p.k)
+	used := make([]bool, len(p.parts))
+
+	var permute func(i int) bool
+	permute = func(i int) bool {
+		if i == len(order) {
+			o := append([]int(nil), order...)
+			return deriveGroup(p.ordered(o), s, func(sub []*derivation, n int) bool {
+				return yield(&derivation{order: o, sub: sub}, n)
+			})
+		}
+
+		for j := range p.parts {
+			if used[j] {
+				continue
+			}
+
+			used[j] = true
+			order[i] = j
+			ok := permute(i + 1)
+			used[j] = false
+
+			if !ok {
+				return false
+			}
+		}
+		return true
+	}
+
+	return permute(0)
+}
+
+func (p shuffle) first() *derivation {
+	order := make([]int, p.k)
+	for i := range order {
+		order[i] = i
+	}
+	return &derivation{order: order, sub: firstGroup(p.parts[:p.k])}
+}
+
+func (p shuffle) build(b []byte, d *derivation) []byte {
+	return buildGroup(p.ordered(d.order), b, d.sub)
+}
+
+func (p shuffle) shrink(d *derivation, yield func(*derivation) bool) bool {
+	if !sort.IntsAreSorted(d.order) {
+		// Restore the original order of the selected children.
+		pos := make([]int, len(d.order))
+		for i := range pos {
+			pos[i] = i
+		}
+		sort.Slice(pos, func(a, b int) bool {
+			return d.order[pos[a]] < d.order[pos[b]]
+		})
+
+		order := make([]int, len(d.order))
+		sub := make([]*derivation, len(d.sub))
+		for i, j := range pos {
+			order[i] = d.order[j]
+			sub[i] = d.sub[j]
+		}
+
+		if !yield(&derivation{order: order, sub: sub}) {
+			return false
+		}
+	}
+
+	return shrinkGroup(p.ordered(d.order), d.sub, func(sub []*derivation) bool {
+		return yield(&derivation{order: d.order, sub: sub})
+	})
+}
+
+// digits returns the digits of the Sequence.
+func (p sequence) digits() string {
+	if p.alphabet != "" {
+		return p.alphabet
+	}
+	return digits36[:10]
+}
+
+func (p sequence) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return deriveNumber(s, p.digits(), p.format, func(u uint64, n int) bool {
+		if u < p.start || u > p.max || (p.skip != nil && p.skip(u)) {
+			return true
+		}
+
+		// The index of u in the order of the Sequence.
+		k := u - p.start
+		if p.desc {
+			k = p.max - u
+		}
+		if k%p.step != 0 {
+			return true
+		}
+
+		return yield(&derivation{value: k / p.step}, n)
+	})
+}
+
+// number returns the number at index k of the Sequence.
+func (p sequence) number(k uint64) uint64 {
+	if p.desc {
+		return p.max - k*p.step
+	}
+	return p.start + k*p.step
+}
+
+func (p sequence) first() *derivation {
+	var k uint64
+	for p.skip != nil && p.skip(p.number(k)) {
+		k++
+	}
+	return &derivation{value: k}
+}
+
+func (p sequence) build(b []byte, d *derivation) []byte {
+	return p.format(b, p.number(d.value))
+}
+
+func (p sequence) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return shrinkValue(d.value, 0, func(k uint64) bool {
+		if p.skip != nil && p.skip(p.number(k)) {
+			return true
+		}
+		return yield(&derivation{value: k})
+	})
+}
+
+// rangeNumber implements derivable for zero-padded decimal numbers in [start, max].
+type rangeNumber struct {
+	start uint64
+	max   uint64
+	width int
+}
+
+func (p rangeNumber) format(b []byte, u uint64) []byte {
+	return appendInt(b, u, p.width)
+}
+
+func (p rangeNumber) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return deriveNumber(s, digits36[:10], p.format, func(u uint64, n int) bool {
+		if u < p.start || u > p.max {
+			return true
+		}
+		return yield(&derivation{value: u - p.start}, n)
+	})
+}
+
+func (p rangeNumber) first() *derivation {
+	return &derivation{}
+}
+
+func (p rangeNumber) build(b []byte, d *derivation) []byte {
+	return p.format(b, p.start+d.value)
+}
+
+func (p rangeNumber) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return shrinkIndex(d, yield)
+}
+
+func (p sequencePer) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return rangeNumber{p.start, p.max, p.width}.derive(s, yield)
+}
+
+func (p sequencePer) first() *derivation {
+	return rangeNumber{p.start, p.max, p.width}.first()
+}
+
+func (p sequencePer) build(b []byte, d *derivation) []byte {
+	return rangeNumber{p.start, p.max, p.width}.build(b, d)
+}
+
+func (p sequencePer) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return rangeNumber{p.start, p.max, p.width}.shrink(d, yield)
+}
+
+func (p permutation) rangeNumber() rangeNumber {
+	return rangeNumber{p.start, p.start + p.n - 1, p.width}
+}
+
+func (p permutation) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return p.rangeNumber().derive(s, yield)
+}
+
+func (p permutation) first() *derivation {
+	return p.rangeNumber().first()
+}
+
+func (p permutation) build(b []byte, d *derivation) []byte {
+	return p.rangeNumber().build(b, d)
+}
+
+func (p permutation) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return p.rangeNumber().shrink(d, yield)
+}
+
+// fixedString implements derivable for strings of size bytes of alphabet.
+type fixedString struct {
+	alphabet []byte
+	size     int
+}
+
+func (p fixedString) derive(s []byte, yield func(*derivation, int) bool) bool {
+	if len(s) < p.size {
+		return true
+	}
+
+	sub := make([]*derivation, p.size)
+	for i, c := range s[:p.size] {
+		j := bytes.IndexByte(p.alphabet, c)
+		if j < 0 {
+			return true
+		}
+		sub[i] = &derivation{value: uint64(j)}
+	}
+	return yield(&derivation{sub: sub}, p.size)
+}
+
+func (p fixedString) first() *derivation {
+	sub := make([]*derivation, p.size)
+	for i := range sub {
+		sub[i] = &derivation{}
+	}
+	return &derivation{sub: sub}
+}
+
+func (p fixedString) build(b []byte, d *derivation) []byte {
+	for _, d := range d.sub {
+		b = append(b, p.alphabet[d.value])
+	}
+	return b
+}
+
+func (p fixedString) shrink(d *derivation, yield func(*derivation) bool) bool {
+	zero := true
+	for _, d := range d.sub {
+		if d.value != 0 {
+			zero = false
+			break
+		}
+	}
+
+	if !zero && !yield(p.first()) {
+		return false
+	}
+
+	for i, c := range d.sub {
+		ok := shrinkIndex(c, func(c *derivation) bool {
+			sub := append([]*derivation(nil), d.sub...)
+			sub[i] = c
+			return yield(&derivation{sub: sub})
+		})
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (p nanoIDMask) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return fixedString{p.alphabet, p.size}.derive(s, yield)
+}
+
+func (p nanoIDMask) first() *derivation {
+	return fixedString{p.alphabet, p.size}.first()
+}
+
+func (p nanoIDMask) build(b []byte, d *derivation) []byte {
+	return fixedString{p.alphabet, p.size}.build(b, d)
+}
+
+func (p nanoIDMask) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return fixedString{p.alphabet, p.size}.shrink(d, yield)
+}
+
+func (p nanoID) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return fixedString{p.alphabet, p.size}.derive(s, yield)
+}
+
+func (p nanoID) first() *derivation {
+	return fixedString{p.alphabet, p.size}.first()
+}
+
+func (p nanoID) build(b []byte, d *derivation) []byte {
+	return fixedString{p.alphabet, p.size}.build(b, d)
+}
+
+func (p nanoID) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return fixedString{p.alphabet, p.size}.shrink(d, yield)
+}
+
+func (p randomHex) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return p.fixedString().derive(s, yield)
+}
+
+func (p randomHex) first() *derivation {
+	return p.fixedString().first()
+}
+
+func (p randomHex) build(b []byte, d *derivation) []byte {
+	return p.fixedString().build(b, d)
+}
+
+func (p randomHex) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return p.fixedString().shrink(d, yield)
+}
+
+func (p cycle) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return p.oneOf().derive(s, yield)
+}
+
+func (p cycle) first() *derivation {
+	return p.oneOf().first()
+}
+
+func (p cycle) build(b []byte, d *derivation) []byte {
+	return p.oneOf().build(b, d)
+}
+
+func (p cycle) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return p.oneOf().shrink(d, yield)
+}
+
+func (p repeatNormal) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return p.uniform.(derivable).derive(s, yield)
+}
+
+func (p repeatNormal) first() *derivation {
+	return p.uniform.(derivable).first()
+}
+
+func (p repeatNormal) build(b []byte, d *derivation) []byte {
+	return p.uniform.(derivable).build(b, d)
+}
+
+func (p repeatNormal) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return p.uniform.(derivable).shrink(d, yield)
+}
+
+func (p balanced) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return p.nested.(derivable).derive(s, yield)
+}
+
+func (p balanced) first() *derivation {
+	return p.nested.(derivable).first()
+}
+
+func (p balanced) build(b []byte, d *derivation) []byte {
+	return p.nested.(derivable).build(b, d)
+}
+
+func (p balanced) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return p.nested.(derivable).shrink(d, yield)
+}
+
+func (p ifPart) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return p.oneOf().derive(s, yield)
+}
+
+func (p ifPart) first() *derivation {
+	return p.oneOf().first()
+}
+
+func (p ifPart) build(b []byte, d *derivation) []byte {
+	return p.oneOf().build(b, d)
+}
+
+func (p ifPart) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return p.oneOf().shrink(d, yield)
+}
+
+func (p choose) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return p.oneOf().derive(s, yield)
+}
+
+func (p choose) first() *derivation {
+	return p.oneOf().first()
+}
+
+func (p choose) build(b []byte, d *derivation) []byte {
+	return p.oneOf().build(b, d)
+}
+
+func (p choose) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return p.oneOf().shrink(d, yield)
+}
+
+func (p throttle) derive(s []byte, yield func(*derivation, int) bool) bool {
+	return p.part.(derivable).derive(s, yield)
+}
+
+func (p throttle) first() *derivation {
+	return p.part.(derivable).first()
+}
+
+func (p throttle) build(b []byte, d *derivation) []byte {
+	return p.part.(derivable).build(b, d)
+}
+
+func (p throttle) shrink(d *derivation, yield func(*derivation) bool) bool {
+	return p.part.(derivable).shrink(d, yield)
+}
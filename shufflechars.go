@@ -0,0 +1,78 @@
+package pattern
+
+import (
+	"unicode/utf8"
+)
+
+// ShuffleBytes returns a Part that randomly rearranges the bytes of the output of p in each iteration,
+// e.g. to mix required character classes into a password instead of appending them at the end.
+// Multi-byte characters are split, use ShuffleRunes for non-ASCII output.
+func ShuffleBytes(p Part) Part {
+	return shuffleChars{part: p}
+}
+
+// ShuffleRunes returns a Part that randomly rearranges the runes of the output of p in each iteration.
+// Invalid UTF-8 is treated as one rune per byte and moved as is.
+func ShuffleRunes(p Part) Part {
+	return shuffleChars{part: p, runes: true}
+}
+
+type shuffleChars struct {
+	part  Part
+	runes bool
+}
+
+func (p shuffleChars) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p shuffleChars) appendRun(r *run, b []byte) []byte {
+	defer r.restoreLimit(r.suspendLimit())
+	start := len(b)
+	b = appendRun(r, p.part, b)
+	out := b[start:]
+
+	if !p.runes {
+		for i := len(out) - 1; i > 0; i-- {
+			j := r.randN(uint32(i + 1))
+			out[i], out[j] = out[j], out[i]
+		}
+		return b
+	}
+
+	// Offsets of the runes in out, followed by len(out).
+	var buf [65]int
+	offsets := buf[:0]
+	for i := 0; i < len(out); {
+		offsets = append(offsets, i)
+		_, n := utf8.DecodeRune(out[i:])
+		i += n
+	}
+	n := len(offsets)
+	offsets = append(offsets, len(out))
+	if n < 2 {
+		return b
+	}
+
+	var idxBuf [64]int
+	idx := idxBuf[:0]
+	for i := 0; i < n; i++ {
+		idx = append(idx, i)
+	}
+	for i := n - 1; i > 0; i-- {
+		j := r.randN(uint32(i + 1))
+		idx[i], idx[j] = idx[j], idx[i]
+	}
+
+	// The runes are moved through a copy, since they have different lengths.
+	src := append([]byte(nil), out...)
+	out = out[:0]
+	for _, i := range idx {
+		out = append(out, src[offsets[i]:offsets[i+1]]...)
+	}
+	return b
+}
+
+func (p shuffleChars) Children() []Part {
+	return []Part{p.part}
+}
@@ -0,0 +1,70 @@
+package pattern
+
+import (
+	"sort"
+	"strconv"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestShuffleBytes(t *testing.T) {
+	gen := New(Literal("-"), ShuffleBytes(Group(Repeat(4, 4, OneOfByte(AlphaLower)), OneOfByte(Digits), Literal("!"))))
+
+	lastDigit := 0
+	for i := 0; i < 200; i++ {
+		v := gen.String()
+		if len(v) != 7 || v[0] != '-' {
+			t.Fatalf("ShuffleBytes returned invalid value: %s", strconv.Quote(v))
+		}
+
+		digits, bangs := 0, 0
+		for j, c := range []byte(v[1:]) {
+			switch {
+			case '0' <= c && c <= '9':
+				digits++
+				if j == 5 {
+					lastDigit++
+				}
+			case c == '!':
+				bangs++
+			}
+		}
+		if digits != 1 || bangs != 1 {
+			t.Errorf("ShuffleBytes changed the characters of the output: %s", strconv.Quote(v))
+		}
+	}
+
+	// The digit is placed at its original position in about one of six values.
+	if lastDigit == 0 || lastDigit > 100 {
+		t.Errorf("ShuffleBytes did not shuffle: the digit was %d times at its original position", lastDigit)
+	}
+}
+
+func TestShuffleRunes(t *testing.T) {
+	const in = "äöü-abc-あいう"
+	gen := New(ShuffleRunes(Literal(in)))
+
+	sorted := func(s string) string {
+		r := []rune(s)
+		sort.Slice(r, func(i, j int) bool { return r[i] < r[j] })
+		return string(r)
+	}
+
+	changed := false
+	for i := 0; i < 100; i++ {
+		v := gen.String()
+		if !utf8.ValidString(v) || sorted(v) != sorted(in) {
+			t.Fatalf("ShuffleRunes returned invalid value: %s", strconv.Quote(v))
+		}
+		changed = changed || v != in
+	}
+
+	if !changed {
+		t.Errorf("ShuffleRunes did not shuffle %s", strconv.Quote(in))
+	}
+
+	// Invalid UTF-8 is kept.
+	if v := New(ShuffleRunes(Literal("\xff"))).String(); v != "\xff" {
+		t.Errorf("ShuffleRunes returned invalid value: want \"\\xff\", got %s", strconv.Quote(v))
+	}
+}
@@ -0,0 +1,392 @@
+package pattern
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// defaultCapacity is the initial capacity of the buffers of String and Bytes if the maximum length of the outputs is unknown.
+const defaultCapacity = 100
+
+// maxCapacity is the largest initial capacity of the buffers of String and Bytes derived from a SizeHint.
+const maxCapacity = 4096
+
+// Sizer is implemented by Parts that know the length of their outputs.
+// Generators use it to preallocate their buffers; custom Parts can implement it to take part.
+type Sizer interface {
+	// SizeHint returns the minimum and maximum length of the outputs in bytes.
+	// max is -1 if the length is unbounded or unknown.
+	SizeHint() (min int, max int)
+}
+
+// capacityHint returns the initial capacity of the buffers for the outputs of parts,
+// which is the maximum length if it is known and not too large.
+func capacityHint(parts []Part) int {
+	min, max := sizeSum(parts)
+
+	n := defaultCapacity
+	if max >= 0 {
+		n = max
+	}
+	if n > maxCapacity {
+		n = maxCapacity
+	}
+	if n < min {
+		n = min
+	}
+	return n
+}
+
+// minLen returns a lower bound of the length of the outputs of p in bytes.
+// It is exact for the built-in Parts with a known length and 0 for Parts whose length is unknown, like custom Parts without a SizeHint.
+func minLen(p Part) int {
+	min, _ := sizeOf(p)
+	return min
+}
+
+// maxLen returns an upper bound of the length of the outputs of p in bytes or -1 if it is unknown,
+// like for Parts whose output is unbounded or depends on the runtime and custom Parts without a SizeHint.
+func maxLen(p Part) int {
+	_, max := sizeOf(p)
+	return max
+}
+
+// sizeOf returns the SizeHint of p, or 0 and -1 if p does not implement Sizer.
+func sizeOf(p Part) (int, int) {
+	if s, ok := p.(Sizer); ok {
+		return s.SizeHint()
+	}
+	return 0, -1
+}
+
+// sizeSum returns the SizeHint of the concatenation of parts.
+func sizeSum(parts []Part) (int, int) {
+	min, max := 0, 0
+	for _, p := range parts {
+		lo, hi := sizeOf(p)
+		min += lo
+		if max >= 0 && (hi < 0 || max+hi < max) {
+			max = -1
+		} else if max >= 0 {
+			max += hi
+		}
+	}
+	return min, max
+}
+
+// sizeOneOf returns the SizeHint of a choice of one of parts, which is 0 and 0 if there are none.
+func sizeOneOf(parts []Part) (int, int) {
+	if len(parts) == 0 {
+		return 0, 0
+	}
+
+	min, max := sizeOf(parts[0])
+	for _, p := range parts[1:] {
+		lo, hi := sizeOf(p)
+		if lo < min {
+			min = lo
+		}
+		if max >= 0 && (hi < 0 || hi > max) {
+			max = hi
+		}
+	}
+	return min, max
+}
+
+// maxLenMul returns n times the length m or -1 if m is unknown or the product overflows.
+func maxLenMul(n int, m int) int {
+	if m < 0 || (m > 0 && n > math.MaxInt/m) {
+		return -1
+	}
+	return n * m
+}
+
+func (g gen) SizeHint() (int, int) {
+	return sizeSum(g.parts)
+}
+
+func (p group) SizeHint() (int, int) {
+	return sizeSum(p)
+}
+
+func (p repeat) SizeHint() (int, int) {
+	min, max := sizeSum(p.parts)
+	return int(p.min) * min, maxLenMul(int(p.min+p.maxr-1), max)
+}
+
+func (p repeatDistinct) SizeHint() (int, int) {
+	min, max := sizeOf(p.part)
+	return int(p.min) * min, maxLenMul(int(p.min+p.maxr-1), max)
+}
+
+func (p atLeastOneOf) SizeHint() (int, int) {
+	// One of each class and the rest of the shortest class.
+	min, _ := sizeSum(p.classes)
+	shortest, _ := sizeOneOf(p.classes)
+	return min + (int(p.min)-len(p.classes))*shortest, -1
+}
+
+func (p shuffle) SizeHint() (int, int) {
+	// The k shortest and the k longest Parts.
+	mins := make([]int, len(p.parts))
+	maxs := make([]int, len(p.parts))
+	known := true
+	for i, part := range p.parts {
+		mins[i], maxs[i] = sizeOf(part)
+		known = known && maxs[i] >= 0
+	}
+	sort.Ints(mins)
+	sort.Sort(sort.Reverse(sort.IntSlice(maxs)))
+
+	min, max := 0, 0
+	for i := 0; i < int(p.k); i++ {
+		min += mins[i]
+		max += maxs[i]
+	}
+	if !known {
+		max = -1
+	}
+	return min, max
+}
+
+func (p maxTotalLen) SizeHint() (int, int) {
+	min, _ := sizeSum(p.parts)
+	return min, p.n
+}
+
+func (p maxOutputLen) SizeHint() (int, int) {
+	min, _ := sizeSum(p.parts)
+	if p.truncate && min >= p.n {
+		min = p.n
+	}
+	return min, p.n
+}
+
+func (p potentially50) SizeHint() (int, int) {
+	_, max := sizeOf(p.part)
+	return 0, max
+}
+
+func (p potentiallyP) SizeHint() (int, int) {
+	_, max := sizeOf(p.part)
+	return 0, max
+}
+
+func (p anyOf) SizeHint() (int, int) {
+	return sizeOneOf(p.parts)
+}
+
+func (p cycle) SizeHint() (int, int) {
+	return sizeOf(p.oneOf())
+}
+
+func (p choose) SizeHint() (int, int) {
+	return sizeOf(p.oneOf())
+}
+
+func (p ifPart) SizeHint() (int, int) {
+	return sizeOf(p.oneOf())
+}
+
+func (p zipf) SizeHint() (int, int) {
+	return sizeOf(p.oneOf())
+}
+
+func (p define) SizeHint() (int, int) {
+	// The depth of the recursion is not known in advance.
+	min, _ := sizeOf(p.part)
+	return min, -1
+}
+
+func (p balanced) SizeHint() (int, int) {
+	return len(p.open) + len(p.close), -1
+}
+
+func (p repeatNormal) SizeHint() (int, int) {
+	return sizeOf(p.uniform)
+}
+
+func (p benfordDigit) SizeHint() (int, int) {
+	return 1, 1
+}
+
+func (p benfordAmount) SizeHint() (int, int) {
+	return len(strconv.FormatFloat(p.lo, 'f', p.decimals, 64)), -1
+}
+
+func (p literal) SizeHint() (int, int) {
+	return len(p), len(p)
+}
+
+func (p nullpart) SizeHint() (int, int) {
+	return 0, 0
+}
+
+func (p anyOfString) SizeHint() (int, int) {
+	if len(p.alphabet) == 0 {
+		return 0, 0
+	}
+
+	min, max := len(p.alphabet[0]), 0
+	for _, s := range p.alphabet {
+		if len(s) < min {
+			min = len(s)
+		}
+		if len(s) > max {
+			max = len(s)
+		}
+	}
+	return min, max
+}
+
+func (p anyOfByte) SizeHint() (int, int) {
+	return 1, 1
+}
+
+func (p anyOfRune) SizeHint() (int, int) {
+	if len(p.alphabet) == 0 {
+		return 0, 0
+	}
+
+	min, max := utf8.RuneLen(p.alphabet[0]), 0
+	for _, r := range p.alphabet {
+		n := utf8.RuneLen(r)
+		if n < min {
+			min = n
+		}
+		if n > max {
+			max = n
+		}
+	}
+	return min, max
+}
+
+func (p byteRange) SizeHint() (int, int) {
+	return 1, 1
+}
+
+func (p runeRanges) SizeHint() (int, int) {
+	return utf8.RuneLen(p.pairs[0]), utf8.RuneLen(p.pairs[len(p.pairs)-1])
+}
+
+func (p nanoID) SizeHint() (int, int) {
+	return p.size, p.size
+}
+
+func (p nanoIDMask) SizeHint() (int, int) {
+	return p.size, p.size
+}
+
+func (p randomHex) SizeHint() (int, int) {
+	return 2 * p.n, 2 * p.n
+}
+
+func (p randomEncoded) SizeHint() (int, int) {
+	n := p.enc.EncodedLen(p.n)
+	return n, n
+}
+
+func (p crockford) SizeHint() (int, int) {
+	return p.n + 1, p.n + 1
+}
+
+func (p markov) SizeHint() (int, int) {
+	// The length of the words counts runes, so the maximum is scaled by the widest rune of the corpus.
+	width := 1
+	for _, w := range p.corpus {
+		for _, c := range w {
+			if n := utf8.RuneLen(c); n > width {
+				width = n
+			}
+		}
+	}
+	return p.minLen, width * p.maxLen
+}
+
+func (p pronounceable) SizeHint() (int, int) {
+	return 2*p.syllables + p.digits, -1
+}
+
+func (p sequence) SizeHint() (int, int) {
+	base := uint64(10)
+	if p.alphabet != "" {
+		base = uint64(len(p.alphabet))
+	}
+	n := 1
+	for v := p.max; v >= base; v /= base {
+		n++
+	}
+	if n < p.width {
+		n = p.width
+	}
+	return p.width, n
+}
+
+func (p permutation) SizeHint() (int, int) {
+	return p.width, -1
+}
+
+func (p sequencePer) SizeHint() (int, int) {
+	return p.width, -1
+}
+
+func (p sequenceBackend) SizeHint() (int, int) {
+	return p.width, -1
+}
+
+func (p encoded) SizeHint() (int, int) {
+	min, max := sizeOf(p.part)
+	if max >= 0 {
+		max = p.enc.EncodedLen(max)
+	}
+	return p.enc.EncodedLen(min), max
+}
+
+func (p pad) SizeHint() (int, int) {
+	min, max := sizeOf(p.part)
+	if min < p.width {
+		min = p.width
+	}
+	if max >= 0 && max < p.width {
+		max = p.width
+	}
+	return min, max
+}
+
+func (p exactLen) SizeHint() (int, int) {
+	return p.n, p.n
+}
+
+func (p hashed) SizeHint() (int, int) {
+	return p.truncate, p.truncate
+}
+
+func (p fpe) SizeHint() (int, int) {
+	return sizeOf(p.part)
+}
+
+func (p noConsecutive) SizeHint() (int, int) {
+	return sizeOf(p.part)
+}
+
+func (p shuffleChars) SizeHint() (int, int) {
+	return sizeOf(p.part)
+}
+
+func (p throttle) SizeHint() (int, int) {
+	return sizeOf(p.part)
+}
+
+func (p uniqueBy) SizeHint() (int, int) {
+	return sizeOf(p.part)
+}
+
+func (p avoid) SizeHint() (int, int) {
+	return sizeOf(p.part)
+}
+
+func (p constrain) SizeHint() (int, int) {
+	return sizeOf(p.part)
+}
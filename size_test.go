@@ -0,0 +1,76 @@
+package pattern
+
+import (
+	"testing"
+)
+
+type sizedPart struct{}
+
+func (sizedPart) Append(b []byte) []byte {
+	return append(b, "0123456789"...)
+}
+
+func (sizedPart) SizeHint() (int, int) {
+	return 10, 10
+}
+
+func TestSizeHint(t *testing.T) {
+	tests := []struct {
+		name     string
+		part     Part
+		min, max int
+	}{
+		{"Literal", Literal("abc"), 3, 3},
+		{"Repeat", Repeat(2, 5, Literal("ab"), OneOfByte([]byte("xy"))), 6, 15},
+		{"OneOf", OneOf(Literal("a"), Literal("abc")), 1, 3},
+		{"OneOfString", OneOfString([]string{"ab", "abcd"}), 2, 4},
+		{"OneOfRune", OneOfRune([]rune("aあ")), 1, 3},
+		{"Potentially", Potentially(0.3, Literal("abc")), 0, 3},
+		{"Sequence", Sequence(1, 12345, 3), 3, 5},
+		{"Group", Group(Literal("a"), Sequence(1, 99, 4)), 5, 5},
+		{"SampleK", SampleK(2, Literal("a"), Literal("bb"), Literal("ccc")), 3, 5},
+		{"custom", Group(Literal("a"), sizedPart{}), 11, 11},
+		{"unknown", Group(Literal("a"), PartFunc(func(b []byte) []byte { return b })), 1, -1},
+		{"generator", New(Literal("id-"), Repeat(1, 3, OneOfByte([]byte("ab")))), 4, 6},
+		{"Markov", New(Markov([]string{"äöü", "aü"}, 1, 2, 4)), 2, 8},
+		{"HexEncode", HexEncode(Repeat(1, 2, Literal("ab"))), 4, 8},
+		{"Shuffle", Shuffle(Literal("a"), Literal("bb"), Sequence(1, 9, 0)), 3, 4},
+		{"NoConsecutive", NoConsecutive(OneOfString([]string{"a", "bcd"})), 1, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ok := tt.part.(Sizer)
+			if !ok {
+				t.Fatalf("%T does not implement Sizer", tt.part)
+			}
+
+			if min, max := s.SizeHint(); min != tt.min || max != tt.max {
+				t.Errorf("SizeHint returned (%d, %d), want (%d, %d)", min, max, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestCapacityHint(t *testing.T) {
+	tests := []struct {
+		name string
+		gen  *gen
+		want int
+	}{
+		{"max", New(Literal("id-"), Repeat(1, 3, OneOfByte([]byte("ab")))), 6},
+		{"large", New(Repeat(100, 200, Literal("abc"))), 600},
+		{"capped", New(Repeat(1, 10000, Literal("abc"))), maxCapacity},
+		{"min", New(Repeat(5000, 10000, Literal("ab"))), 10000},
+		{"unknown", New(PartFunc(func(b []byte) []byte { return b })), defaultCapacity},
+		{"With", New(Literal("a")).With(Repeat(200, 200, Literal("b"))), 201},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if n := tt.gen.bufferCap(); n != tt.want {
+				t.Errorf("bufferCap returned %d, want %d", n, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package pattern
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"sort"
+	"unicode/utf8"
+)
+
+// ErrNotRegular is wrapped by the errors of PatternE for Parts that can not be expressed as a regular expression.
+var ErrNotRegular = errors.New("pattern: not expressible as a regular expression")
+
+// Pattern returns a regular expression that generates the same outputs as the pattern, which can be parsed again with Compile.
+// Probabilities are not represented, e.g. Potentially(0.3, p) is written as p?.
+// Wrappers that do not change the possible outputs, like Throttle and UniqueBy, are omitted.
+// Pattern panics if the pattern contains Parts that can not be expressed as a regular expression,
+// like Shuffle, Sequence, Timestamp, FPE or custom Parts.
+func (g gen) Pattern() string {
+	re := toRegexp(g)
+	return re.String()
+}
+
+// PatternE returns a regular expression like Pattern, but returns an error wrapping ErrNotRegular instead of panicking,
+// e.g. to log the patterns of generators from user configuration.
+func (g gen) PatternE() (s string, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			e, ok := v.(error)
+			if !ok || !errors.Is(e, ErrNotRegular) {
+				panic(v)
+			}
+			err = e
+		}
+	}()
+
+	return g.Pattern(), nil
+}
+
+// toRegexp returns the regular expression that generates the outputs of p.
+func toRegexp(p Part) *syntax.Regexp {
+	switch p := p.(type) {
+	case *gen:
+		return concatRegexp(p.parts)
+	case gen:
+		return concatRegexp(p.parts)
+	case nullpart:
+		return &syntax.Regexp{Op: syntax.OpEmptyMatch}
+	case group:
+		return concatRegexp(p)
+	case constRepeat:
+		return repeatRegexp(p.group[:len(p.group)/int(p.n)], int(p.n), int(p.n))
+	case repeat:
+		return repeatRegexp(p.parts, int(p.min), int(p.min+p.maxr-1))
+	case potentially50:
+		return &syntax.Regexp{Op: syntax.OpQuest, Sub: []*syntax.Regexp{toRegexp(p.part)}}
+	case potentiallyP:
+		return &syntax.Regexp{Op: syntax.OpQuest, Sub: []*syntax.Regexp{toRegexp(p.part)}}
+	case literal:
+		if !utf8.Valid(p) {
+			break
+		}
+		return literalRegexp(string(p))
+	case anyOf:
+		subs := make([]*syntax.Regexp, len(p.parts))
+		for i, p := range p.parts {
+			subs[i] = toRegexp(p)
+		}
+		return &syntax.Regexp{Op: syntax.OpAlternate, Sub: subs}
+	case anyOfString:
+		subs := make([]*syntax.Regexp, len(p.alphabet))
+		for i, s := range p.alphabet {
+			subs[i] = literalRegexp(s)
+		}
+		return &syntax.Regexp{Op: syntax.OpAlternate, Sub: subs}
+	case anyOfByte:
+		if re := byteClassRegexp(p.alphabet); re != nil {
+			return re
+		}
+	case anyOfRune:
+		return classRegexp(p.alphabet)
+	case runeRanges:
+		return &syntax.Regexp{Op: syntax.OpCharClass, Rune: append([]rune(nil), p.pairs...)}
+	case nanoID:
+		if re := byteClassRegexp(p.alphabet); re != nil {
+			return &syntax.Regexp{Op: syntax.OpRepeat, Min: p.size, Max: p.size, Sub: []*syntax.Regexp{re}}
+		}
+	case nanoIDMask:
+		if re := byteClassRegexp(p.alphabet); re != nil {
+			return &syntax.Regexp{Op: syntax.OpRepeat, Min: p.size, Max: p.size, Sub: []*syntax.Regexp{re}}
+		}
+	case byteRange:
+		if p.lo+byte(p.n-1) < utf8.RuneSelf {
+			return &syntax.Regexp{Op: syntax.OpCharClass, Rune: []rune{rune(p.lo), rune(p.lo + byte(p.n-1))}}
+		}
+	case pronounceable:
+		syllable := &syntax.Regexp{Op: syntax.OpConcat, Sub: []*syntax.Regexp{
+			byteClassRegexp([]byte(pronounceableConsonants)),
+			byteClassRegexp([]byte(pronounceableVowels)),
+		}}
+		digits := byteClassRegexp(Digits)
+		return &syntax.Regexp{Op: syntax.OpConcat, Sub: []*syntax.Regexp{
+			{Op: syntax.OpRepeat, Min: p.syllables, Max: p.syllables, Sub: []*syntax.Regexp{syllable}},
+			{Op: syntax.OpRepeat, Min: p.digits, Max: p.digits, Sub: []*syntax.Regexp{digits}},
+		}}
+	case randomHex:
+		re := byteClassRegexp([]byte(hexDigits))
+		return &syntax.Regexp{Op: syntax.OpRepeat, Min: 2 * p.n, Max: 2 * p.n, Sub: []*syntax.Regexp{re}}
+	case cycle:
+		return toRegexp(p.oneOf())
+	case choose:
+		return toRegexp(p.oneOf())
+	case ifPart:
+		return toRegexp(p.oneOf())
+	case balanced:
+		return toRegexp(p.nested)
+	case repeatNormal:
+		return toRegexp(p.uniform)
+	case zipf:
+		return toRegexp(p.oneOf())
+	case benfordDigit:
+		return &syntax.Regexp{Op: syntax.OpCharClass, Rune: []rune{'1', '9'}}
+	case throttle:
+		return toRegexp(p.part)
+	case uniqueBy:
+		return toRegexp(p.part)
+	}
+
+	panic(fmt.Errorf("%w: %s", ErrNotRegular, explainPart(p)))
+}
+
+func concatRegexp(parts []Part) *syntax.Regexp {
+	if len(parts) == 0 {
+		return &syntax.Regexp{Op: syntax.OpEmptyMatch}
+	}
+	if len(parts) == 1 {
+		return toRegexp(parts[0])
+	}
+
+	subs := make([]*syntax.Regexp, len(parts))
+	for i, p := range parts {
+		subs[i] = toRegexp(p)
+	}
+	return &syntax.Regexp{Op: syntax.OpConcat, Sub: subs}
+}
+
+// maxRegexpRepeat is the largest count of a repetition accepted by regexp/syntax.
+const maxRegexpRepeat = 1000
+
+// repeatRegexp returns the repetition of parts between min and max times.
+// Repetitions above maxRegexpRepeat are split into consecutive repetitions, e.g. x{1,2000} into x{1,1000}x{0,1000}.
+func repeatRegexp(parts []Part, min int, max int) *syntax.Regexp {
+	sub := concatRegexp(parts)
+	if max <= maxRegexpRepeat {
+		return &syntax.Regexp{Op: syntax.OpRepeat, Min: min, Max: max, Sub: []*syntax.Regexp{sub}}
+	}
+
+	var subs []*syntax.Regexp
+	for max > 0 {
+		hi := max
+		if hi > maxRegexpRepeat {
+			hi = maxRegexpRepeat
+		}
+		lo := min
+		if lo > hi {
+			lo = hi
+		}
+		subs = append(subs, &syntax.Regexp{Op: syntax.OpRepeat, Min: lo, Max: hi, Sub: []*syntax.Regexp{sub}})
+		min -= lo
+		max -= hi
+	}
+	return &syntax.Regexp{Op: syntax.OpConcat, Sub: subs}
+}
+
+func literalRegexp(s string) *syntax.Regexp {
+	if s == "" {
+		return &syntax.Regexp{Op: syntax.OpEmptyMatch}
+	}
+	return &syntax.Regexp{Op: syntax.OpLiteral, Rune: []rune(s)}
+}
+
+// classRegexp returns a character class of the runes in alphabet.
+func classRegexp(alphabet []rune) *syntax.Regexp {
+	runes := append([]rune(nil), alphabet...)
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	// Merge consecutive runes into ranges.
+	var pairs []rune
+	for _, r := range runes {
+		if n := len(pairs); n > 0 && r <= pairs[n-1]+1 {
+			if r > pairs[n-1] {
+				pairs[n-1] = r
+			}
+			continue
+		}
+		pairs = append(pairs, r, r)
+	}
+	return &syntax.Regexp{Op: syntax.OpCharClass, Rune: pairs}
+}
+
+// byteClassRegexp returns a character class of the bytes in alphabet or nil if it contains non-ASCII bytes.
+func byteClassRegexp(alphabet []byte) *syntax.Regexp {
+	runes := make([]rune, len(alphabet))
+	for i, c := range alphabet {
+		if c >= utf8.RuneSelf {
+			return nil
+		}
+		runes[i] = rune(c)
+	}
+	return classRegexp(runes)
+}
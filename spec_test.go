@@ -0,0 +1,103 @@
+package pattern
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestPattern(t *testing.T) {
+	tests := []struct {
+		gen  *gen
+		want string
+	}{
+		{
+			New(
+				Literal("id-"),
+				Repeat(2, 4, Literal("ab"), OneOfByte([]byte("xyz0123"))),
+				Potentially(0.3, OneOf(Literal("a.b"), OneOfString([]string{"c", "dd"}))),
+				NanoID(5, []byte("abc")),
+			),
+			`id-(?:ab[0-3x-z]){2,4}(?:a\.b|c|dd)?[a-c]{5}`,
+		},
+		{MustCompile(`user-[a-z]{3,8}(-[0-9]{2})?`), `user-[a-z]{3,8}(?:-[0-9]{2})?`},
+		{New(OneOfRune([]rune("äöüa")), Throttle(100, Literal("x"))), `[aäöü]x`},
+		{New(Repeat(1, 2, Repeat(3, 3, Literal("ab")))), `(?:(?:ab){3}){1,2}`},
+		{MustCompile(`[\x{100}-\x{3ff}]+`), `[Ā-Ͽ]{1,11}`},
+		{New(), `(?:)`},
+	}
+
+	for _, test := range tests {
+		got := test.gen.Pattern()
+		if got != test.want {
+			t.Errorf("Pattern returned invalid value: want %s, got %s", strconv.Quote(test.want), strconv.Quote(got))
+			continue
+		}
+
+		// The pattern is stable when it is parsed again.
+		if again := MustCompile(got).Pattern(); again != got {
+			t.Errorf("Pattern is not canonical: want %s, got %s", strconv.Quote(got), strconv.Quote(again))
+		}
+	}
+}
+
+func TestPatternLargeRepeat(t *testing.T) {
+	tests := []struct {
+		gen      *gen
+		min, max int
+		want     string
+	}{
+		{New(Repeat(1, 2000, Literal("a"))), 1, 2000, `a{1,1000}a{0,1000}`},
+		{New(Repeat(2500, 2501, OneOfByte([]byte("ab")))), 2500, 2501, `[ab]{1000}[ab]{1000}[ab]{500,501}`},
+		{New(Repeat(1500, 2001, Literal("a"))), 1500, 2001, `a{1000}a{500,1000}a{0,1}`},
+	}
+
+	for _, test := range tests {
+		got := test.gen.Pattern()
+		if got != test.want {
+			t.Errorf("Pattern returned invalid value: want %s, got %s", strconv.Quote(test.want), strconv.Quote(got))
+			continue
+		}
+
+		gen, err := Compile(got)
+		if err != nil {
+			t.Errorf("Compile of %s returned error: %v", strconv.Quote(got), err)
+			continue
+		}
+
+		for i := 0; i < 100; i++ {
+			if s := gen.String(); len(s) < test.min || len(s) > test.max {
+				t.Errorf("compiled Pattern %s returned value of invalid length: want %d to %d, got %d", strconv.Quote(got), test.min, test.max, len(s))
+			}
+		}
+	}
+}
+
+func TestPatternPanic(t *testing.T) {
+	tests := []Part{
+		Shuffle(Literal("a"), Literal("b")),
+		Sequence(1, 10, 0),
+		Timestamp(time.RFC3339),
+		OneOfByte([]byte{0xff}),
+	}
+
+	for _, p := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Pattern did not panic on %s", explainPart(p))
+				}
+			}()
+			New(Literal("a"), p).Pattern()
+		}()
+
+		if s, err := New(Literal("a"), p).PatternE(); !errors.Is(err, ErrNotRegular) || s != "" {
+			t.Errorf("PatternE returned invalid value for %s: want ErrNotRegular, got %s, %v", explainPart(p), strconv.Quote(s), err)
+		}
+	}
+
+	if s, err := New(Literal("a")).PatternE(); err != nil || s != "a" {
+		t.Errorf("PatternE returned invalid value: want \"a\", got %s, %v", strconv.Quote(s), err)
+	}
+}
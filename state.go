@@ -0,0 +1,153 @@
+package pattern
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/sollniss/pattern/internal"
+)
+
+// stateful is implemented by Parts that keep state between iterations.
+type stateful interface {
+	saveState() partState
+	loadState(partState) error
+}
+
+// partState is the serialized state of a single stateful Part.
+type partState struct {
+	Kind   string `json:"kind"`
+	Curr   uint64 `json:"curr"`
+	Valid  bool   `json:"valid,omitempty"`
+	Bucket int64  `json:"bucket,omitempty"`
+}
+
+type genState struct {
+	Version int         `json:"version"`
+	Parts   []partState `json:"parts"`
+	// Seed is the state of the source of generators created with WithSeed.
+	Seed *uint64 `json:"seed,omitempty"`
+}
+
+const stateVersion = 1
+
+// MarshalState returns a snapshot of the state of all stateful Parts of the generator, e.g. the counters of Sequences,
+// and of the source of randomness if the generator was created with WithSeed.
+// The snapshot can be restored with UnmarshalState on a generator with the same Parts.
+func (g gen) MarshalState() ([]byte, error) {
+	s := genState{
+		Version: stateVersion,
+		Parts:   []partState{},
+	}
+
+	walk(g, func(p Part) {
+		if v, ok := p.(stateful); ok {
+			s.Parts = append(s.Parts, v.saveState())
+		}
+	})
+
+	if src, ok := g.src.(*internal.SeedSource); ok {
+		seed := src.State()
+		s.Seed = &seed
+	}
+
+	return json.Marshal(s)
+}
+
+// UnmarshalState restores a snapshot created by MarshalState.
+// The generator must consist of the same Parts as the generator the snapshot was taken from.
+func (g gen) UnmarshalState(data []byte) error {
+	var s genState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("pattern: invalid state: %w", err)
+	}
+
+	if s.Version != stateVersion {
+		return fmt.Errorf("pattern: unsupported state version %d", s.Version)
+	}
+
+	var parts []stateful
+	walk(g, func(p Part) {
+		if v, ok := p.(stateful); ok {
+			parts = append(parts, v)
+		}
+	})
+
+	if len(parts) != len(s.Parts) {
+		return fmt.Errorf("pattern: state has %d stateful parts, generator has %d", len(s.Parts), len(parts))
+	}
+
+	// Validate everything before modifying any Part.
+	for i, p := range parts {
+		if kind := p.saveState().Kind; kind != s.Parts[i].Kind {
+			return fmt.Errorf("pattern: state of part %d is of kind %q, want %q", i, s.Parts[i].Kind, kind)
+		}
+	}
+
+	src, seeded := g.src.(*internal.SeedSource)
+	if seeded != (s.Seed != nil) {
+		return errors.New("pattern: state and generator differ in the use of WithSeed")
+	}
+
+	for i, p := range parts {
+		if err := p.loadState(s.Parts[i]); err != nil {
+			return fmt.Errorf("pattern: state of part %d: %w", i, err)
+		}
+	}
+
+	if seeded {
+		src.SetState(*s.Seed)
+	}
+
+	return nil
+}
+
+var errStateRange = errors.New("counter out of range")
+
+func (p sequence) saveState() partState {
+	return partState{
+		Kind: "sequence",
+		Curr: atomic.LoadUint64(p.curr),
+	}
+}
+
+func (p sequence) loadState(s partState) error {
+	// Before the first number, the counter is one step outside of the range.
+	initial := p.start - p.step
+	if p.desc {
+		initial = p.max + p.step
+	}
+	if (s.Curr < p.start || s.Curr > p.max) && s.Curr != initial {
+		return errStateRange
+	}
+
+	atomic.StoreUint64(p.curr, s.Curr)
+	return nil
+}
+
+func (p sequencePer) saveState() partState {
+	p.state.mu.Lock()
+	defer p.state.mu.Unlock()
+
+	return partState{
+		Kind:   "sequencePer",
+		Curr:   p.state.curr,
+		Valid:  p.state.valid,
+		Bucket: p.state.bucket,
+	}
+}
+
+func (p sequencePer) loadState(s partState) error {
+	if s.Valid && (s.Curr < p.start || s.Curr > p.max) {
+		return errStateRange
+	}
+
+	p.state.mu.Lock()
+	defer p.state.mu.Unlock()
+
+	p.state.curr = s.Curr
+	p.state.valid = s.Valid
+	p.state.bucket = s.Bucket
+	return nil
+}
@@ -0,0 +1,118 @@
+package pattern
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestMarshalState(t *testing.T) {
+	setNow(t, func() time.Time {
+		return time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	})
+
+	newGen := func() *gen {
+		return New(
+			Sequence(1, 100, 3),
+			Literal("-"),
+			Repeat(1, 1, Group(Literal("x"), SequencePer(Daily, 1, 100, 0))),
+		)
+	}
+
+	gen := newGen()
+	for i := 0; i < 5; i++ {
+		id = gen.String()
+	}
+
+	data, err := gen.MarshalState()
+	if err != nil {
+		t.Fatalf("MarshalState returned error: %v", err)
+	}
+
+	want := gen.String()
+
+	// Restore into a fresh generator.
+	gen2 := newGen()
+	if err := gen2.UnmarshalState(data); err != nil {
+		t.Fatalf("UnmarshalState returned error: %v", err)
+	}
+
+	p := gen2.String()
+	if p != want {
+		t.Errorf("restored generator returned invalid value: want %s, got %s", strconv.Quote(want), strconv.Quote(p))
+	}
+
+	// Restore into the original generator.
+	if err := gen.UnmarshalState(data); err != nil {
+		t.Fatalf("UnmarshalState returned error: %v", err)
+	}
+
+	p = gen.String()
+	if p != want {
+		t.Errorf("restored generator returned invalid value: want %s, got %s", strconv.Quote(want), strconv.Quote(p))
+	}
+}
+
+func TestUnmarshalStateError(t *testing.T) {
+	data, err := New(Sequence(1, 100, 0)).MarshalState()
+	if err != nil {
+		t.Fatalf("MarshalState returned error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		gen  *gen
+		data []byte
+	}{
+		{"invalid json", New(Sequence(1, 100, 0)), []byte("{")},
+		{"invalid version", New(Sequence(1, 100, 0)), []byte(`{"version":0}`)},
+		{"part count", New(Sequence(1, 100, 0), Sequence(1, 100, 0)), data},
+		{"part kind", New(SequencePer(Daily, 1, 100, 0)), data},
+		{"range", New(SequencePer(Daily, 1, 100, 0)), []byte(`{"version":1,"parts":[{"kind":"sequencePer","curr":101,"valid":true}]}`)},
+		{"sequence range", New(Sequence(1, 100, 0)), []byte(`{"version":1,"parts":[{"kind":"sequence","curr":101}]}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.gen.UnmarshalState(tt.data); err == nil {
+				t.Errorf("UnmarshalState did not return an error")
+			}
+		})
+	}
+
+	// The state before the first number is in range.
+	if err := New(Sequence(1, 100, 0)).UnmarshalState(data); err != nil {
+		t.Errorf("UnmarshalState of an unused Sequence returned error: %v", err)
+	}
+}
+
+func TestMarshalStateSeed(t *testing.T) {
+	newGen := func(opts ...Option) *gen {
+		return NewWithOptions(opts, Sequence(1, 100, 0), Literal("-"), Repeat(5, 5, OneOfByte(AlphaLower)))
+	}
+
+	gen := newGen(WithSeed(7))
+	id = gen.String()
+
+	data, err := gen.MarshalState()
+	if err != nil {
+		t.Fatalf("MarshalState returned error: %v", err)
+	}
+
+	want := gen.String()
+
+	// The seeded source continues where it was saved.
+	gen2 := newGen(WithSeed(7))
+	if err := gen2.UnmarshalState(data); err != nil {
+		t.Fatalf("UnmarshalState returned error: %v", err)
+	}
+
+	p := gen2.String()
+	if p != want {
+		t.Errorf("restored generator returned invalid value: want %s, got %s", strconv.Quote(want), strconv.Quote(p))
+	}
+
+	if err := newGen().UnmarshalState(data); err == nil {
+		t.Errorf("UnmarshalState restored a seeded state into a generator without WithSeed")
+	}
+}
@@ -0,0 +1,79 @@
+package pattern
+
+import (
+	"context"
+	"io"
+)
+
+// Reader returns a reader that produces an endless stream of generated patterns, each followed by sep.
+// Patterns are generated lazily as the stream is read.
+// The reader is not safe for concurrent use, but multiple readers of the same generator are.
+func (g gen) Reader(sep string) io.Reader {
+	return &reader{
+		gen: g,
+		sep: sep,
+	}
+}
+
+type reader struct {
+	gen gen
+	sep string
+	buf []byte
+	// off is the offset of the unread data in buf.
+	off int
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	// Patterns can be empty, so give up after some tries.
+	for i := 0; r.off == len(r.buf); i++ {
+		if i == 100 {
+			return 0, io.ErrNoProgress
+		}
+
+		r.buf = r.gen.Append(r.buf[:0])
+		r.buf = append(r.buf, r.sep...)
+		r.off = 0
+	}
+
+	n := copy(p, r.buf[r.off:])
+	r.off += n
+	return n, nil
+}
+
+// Produce starts a goroutine that generates patterns into a channel with a buffer of size buf until ctx is cancelled.
+// The channel is closed after ctx is cancelled.
+// Parts that block, like Throttle, observe ctx while generating, and values generated after ctx is cancelled are discarded.
+func (g gen) Produce(ctx context.Context, buf int) <-chan string {
+	ch := make(chan string, buf)
+
+	go func() {
+		defer close(ch)
+		for {
+			r := g.run(0)
+			if r == nil {
+				r = &run{}
+			}
+			r.ctx = ctx
+
+			b := make([]byte, 0, g.bufferCap())
+			b = g.appendRun(r, b)
+
+			// Check ctx first, since select chooses randomly if the channel is ready too.
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case ch <- string(b):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
@@ -0,0 +1,104 @@
+package pattern
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestReader(t *testing.T) {
+	gen := New(Literal("id-"), Repeat(1, 5, OneOfByte([]byte("0123456789"))))
+
+	s := bufio.NewScanner(gen.Reader("\n"))
+	re := regexp.MustCompile(`^id-\d{1,5}$`)
+	for i := 0; i < 1000 && s.Scan(); i++ {
+		if !re.MatchString(s.Text()) {
+			t.Errorf("Reader returned invalid value %s", strconv.Quote(s.Text()))
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		t.Errorf("Reader returned error: %v", err)
+	}
+
+	// Small reads split patterns.
+	r := New(Literal("abc")).Reader("")
+	buf := make([]byte, 2)
+	var out []byte
+	for len(out) < 10 {
+		n, err := r.Read(buf)
+		if err != nil {
+			t.Fatalf("Read returned error: %v", err)
+		}
+		out = append(out, buf[:n]...)
+	}
+
+	if string(out[:10]) != "abcabcabca" {
+		t.Errorf("Reader returned invalid stream: want \"abcabcabca\", got %s", strconv.Quote(string(out)))
+	}
+}
+
+func TestReaderEmpty(t *testing.T) {
+	r := New(Group()).Reader("")
+	if _, err := r.Read(make([]byte, 1)); err != io.ErrNoProgress {
+		t.Errorf("Reader of empty pattern returned invalid error: want %v, got %v", io.ErrNoProgress, err)
+	}
+}
+
+func TestProduce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	gen := New(Sequence(1, 1000, 0))
+	ch := gen.Produce(ctx, 10)
+
+	for i := 1; i <= 100; i++ {
+		v := <-ch
+		if v != strconv.Itoa(i) {
+			t.Errorf("Produce returned invalid value: want %d, got %s", i, strconv.Quote(v))
+		}
+	}
+
+	cancel()
+
+	// The channel is closed after draining the buffer.
+	n := 0
+	for range ch {
+		n++
+	}
+
+	if n > 11 {
+		t.Errorf("Produce generated too many values after cancellation: want at most 11, got %d", n)
+	}
+}
+
+// blocking is a Part that blocks until the context of the run is cancelled.
+type blocking struct{}
+
+func (p blocking) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p blocking) appendRun(r *run, b []byte) []byte {
+	<-r.done()
+	return append(b, 'x')
+}
+
+func TestProduceCancelGenerating(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := New(blocking{}).Produce(ctx, 0)
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	select {
+	case v, ok := <-ch:
+		if ok {
+			t.Errorf("Produce sent value generated after cancellation: %s", strconv.Quote(v))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Produce did not observe cancellation while generating")
+	}
+}
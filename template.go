@@ -0,0 +1,66 @@
+package pattern
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FuncMap returns a function map for text/template and html/template with the function pattern,
+// which returns a value generated by the named Part of patterns, e.g. {{ pattern "orderID" }}.
+// The function returns an error if no Part with the name exists.
+//
+// The result can be passed directly to the Funcs method of a template.
+func FuncMap(patterns map[string]Part) map[string]any {
+	// Copy the map, so later changes do not affect the templates.
+	parts := make(map[string]Part, len(patterns))
+	for name, p := range patterns {
+		parts[name] = p
+	}
+
+	return map[string]any{
+		"pattern": func(name string) (string, error) {
+			p, ok := parts[name]
+			if !ok {
+				return "", fmt.Errorf("pattern: unknown pattern %q", name)
+			}
+			return string(p.Append(nil)), nil
+		},
+	}
+}
+
+// Template returns a Part that outputs s with each {{name}} marker replaced by the output of the Part parts[name],
+// e.g. Template("user-{{id}}-{{suffix}}", map[string]Part{"id": ..., "suffix": ...}).
+// Spaces around the name are ignored. The template is parsed once into a Group of Literals and the referenced Parts.
+//
+// Panics if a marker is not closed or references a name that is not in parts.
+func Template(s string, parts map[string]Part) Part {
+	var g []Part
+	for {
+		i := strings.Index(s, "{{")
+		if i < 0 {
+			break
+		}
+
+		j := strings.Index(s[i:], "}}")
+		if j < 0 {
+			panic("unclosed marker in template")
+		}
+
+		name := strings.TrimSpace(s[i+2 : i+j])
+		p, ok := parts[name]
+		if !ok {
+			panic("unknown part " + name + " in template")
+		}
+
+		if i > 0 {
+			g = append(g, Literal(s[:i]))
+		}
+		g = append(g, p)
+		s = s[i+j+2:]
+	}
+
+	if s != "" {
+		g = append(g, Literal(s))
+	}
+	return Group(g...)
+}
@@ -0,0 +1,96 @@
+package pattern
+
+import (
+	htmltemplate "html/template"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestFuncMap(t *testing.T) {
+	funcs := FuncMap(map[string]Part{
+		"orderID": New(Literal("ORD-"), Repeat(4, 4, OneOfByte([]byte("0123456789")))),
+		"tag":     Literal("<b>"),
+	})
+
+	tmpl := template.Must(template.New("").Funcs(funcs).Parse(`{{ pattern "orderID" }} {{ pattern "orderID" }} {{ pattern "tag" }}`))
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, nil); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	if !regexp.MustCompile(`^ORD-\d{4} ORD-\d{4} <b>$`).MatchString(out.String()) {
+		t.Errorf("template returned invalid value: got %s", strconv.Quote(out.String()))
+	}
+
+	// html/template escapes the output.
+	htmpl := htmltemplate.Must(htmltemplate.New("").Funcs(funcs).Parse(`{{ pattern "tag" }}`))
+
+	out.Reset()
+	if err := htmpl.Execute(&out, nil); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	if out.String() != "&lt;b&gt;" {
+		t.Errorf("html template returned invalid value: want \"&lt;b&gt;\", got %s", strconv.Quote(out.String()))
+	}
+
+	// Unknown names are an error.
+	tmpl = template.Must(template.New("").Funcs(funcs).Parse(`{{ pattern "unknown" }}`))
+	if err := tmpl.Execute(&out, nil); err == nil {
+		t.Errorf("template with unknown pattern did not return an error")
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	p := Template("user-{{id}}-{{ suffix }}.{{id}}", map[string]Part{
+		"id":     Sequence(1, 100, 3),
+		"suffix": OneOfString([]string{"a", "b"}),
+		"unused": Literal("x"),
+	})
+
+	re := regexp.MustCompile(`^user-(\d{3})-[ab]\.(\d{3})$`)
+	for i := 0; i < 10; i++ {
+		v := string(p.Append(nil))
+		m := re.FindStringSubmatch(v)
+		if m == nil {
+			t.Fatalf("Template returned invalid value: got %s", strconv.Quote(v))
+		}
+		// Both markers use the same Sequence.
+		if want := strconv.Itoa(2*i + 1); strings.TrimLeft(m[1], "0") != want {
+			t.Errorf("Template returned invalid value: got %s, want id %s", strconv.Quote(v), want)
+		}
+	}
+
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"", ""},
+		{"plain", "plain"},
+		{"{{x}}", "x"},
+		{"a}}{{x}}{", "a}}x{"},
+	}
+	for _, test := range tests {
+		if v := string(Template(test.s, map[string]Part{"x": Literal("x")}).Append(nil)); v != test.want {
+			t.Errorf("Template(%q) returned invalid value: want %q, got %q", test.s, test.want, v)
+		}
+	}
+}
+
+func TestTemplatePanic(t *testing.T) {
+	tests := []string{"a{{x", "{{y}}"}
+	for _, s := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Template(%q) did not panic", s)
+				}
+			}()
+			Template(s, map[string]Part{"x": Literal("x")})
+		}()
+	}
+}
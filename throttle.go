@@ -0,0 +1,70 @@
+package pattern
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Throttle returns a Part that limits the generation of p to rate times per second.
+// Append blocks until the next slot is available, so a throttled generator can drive steady-state load directly,
+// e.g. with Produce, Reader or Seq. In Produce, the wait ends as soon as the context is cancelled, and p is not generated.
+// Slots are shared by all goroutines using the Part.
+//
+// Panics if rate is <= 0.
+func Throttle(rate float64, p Part) Part {
+	if rate <= 0 {
+		panic("rate must be > 0")
+	}
+
+	return throttle{
+		part:     p,
+		interval: int64(float64(time.Second) / rate),
+		next:     new(int64),
+	}
+}
+
+type throttle struct {
+	part Part
+	// interval is the time between two slots in nanoseconds.
+	interval int64
+	// next is the Unix time of the next free slot in nanoseconds.
+	next *int64
+}
+
+func (p throttle) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p throttle) appendRun(r *run, b []byte) []byte {
+	for {
+		t := now().UnixNano()
+		next := atomic.LoadInt64(p.next)
+
+		// Slots in the past can not be used to catch up.
+		slot := next
+		if slot < t {
+			slot = t
+		}
+
+		if atomic.CompareAndSwapInt64(p.next, next, slot+p.interval) {
+			if slot == t {
+				break
+			}
+
+			timer := time.NewTimer(time.Duration(slot - t))
+			select {
+			case <-timer.C:
+			case <-r.done():
+				timer.Stop()
+				return b
+			}
+			break
+		}
+	}
+
+	return appendRun(r, p.part, b)
+}
+
+func (p throttle) Children() []Part {
+	return []Part{p.part}
+}
@@ -0,0 +1,71 @@
+package pattern
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestThrottle(t *testing.T) {
+	gen := New(Throttle(1000, Literal("o")))
+
+	start := time.Now()
+	for i := 0; i < 51; i++ {
+		id = gen.String()
+	}
+
+	// The first value is generated immediately.
+	if d := time.Since(start); d < 50*time.Millisecond {
+		t.Errorf("Throttle generated too fast: want at least 50ms for 51 values, got %v", d)
+	}
+}
+
+func TestThrottleProduce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := New(Throttle(500, Literal("o"))).Produce(ctx, 0)
+	defer func() {
+		// Wait for the producer to stop, so it does not outlive the test.
+		cancel()
+		for range ch {
+		}
+	}()
+
+	start := time.Now()
+	for i := 0; i < 11; i++ {
+		<-ch
+	}
+
+	if d := time.Since(start); d < 20*time.Millisecond {
+		t.Errorf("throttled Produce generated too fast: want at least 20ms for 11 values, got %v", d)
+	}
+}
+
+func TestThrottleProduceCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := New(Throttle(0.01, Literal("o"))).Produce(ctx, 0)
+
+	// The second value waits for 100s.
+	<-ch
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	select {
+	case v, ok := <-ch:
+		if ok {
+			t.Errorf("throttled Produce sent value after cancellation: %s", v)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("throttled Produce did not return after cancellation")
+	}
+}
+
+func TestThrottlePanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Throttle with rate == 0 did not panic")
+			}
+		}()
+
+		Throttle(0, Literal("o"))
+	}()
+}
@@ -0,0 +1,78 @@
+package pattern
+
+import (
+	"time"
+)
+
+const (
+	digits36 = "0123456789abcdefghijklmnopqrstuvwxyz"
+	// digits62 is sorted in ASCII order, so encoded numbers of equal length sort correctly.
+	digits62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// now returns the current time. It can be replaced in tests.
+var now = time.Now
+
+// Timestamp returns a Part that will output the current local time formatted with layout in each iteration.
+// See the time package for the layout syntax.
+func Timestamp(layout string) Part {
+	return timestamp{
+		layout: layout,
+	}
+}
+
+// TimestampUTC returns a Part that will output the current UTC time formatted with layout in each iteration.
+// See the time package for the layout syntax.
+func TimestampUTC(layout string) Part {
+	return timestamp{
+		layout: layout,
+		utc:    true,
+	}
+}
+
+type timestamp struct {
+	layout string
+	utc    bool
+}
+
+func (p timestamp) Append(b []byte) []byte {
+	t := now()
+	if p.utc {
+		t = t.UTC()
+	}
+	return t.AppendFormat(b, p.layout)
+}
+
+// EpochBase returns a Part that will output the current Unix time in multiples of unit encoded in base in each iteration.
+// For example, a unit of time.Millisecond outputs the Unix time in milliseconds.
+// Bases up to 36 use the lowercase digits "0-9a-z", bases up to 62 use the digits "0-9A-Za-z".
+//
+// Panics if unit is <= 0 or base is not in [2, 62].
+func EpochBase(unit time.Duration, base int) Part {
+	if unit <= 0 {
+		panic("unit must be > 0")
+	}
+
+	if base < 2 || base > 62 {
+		panic("base must be in [2, 62]")
+	}
+
+	digits := digits62[:base]
+	if base <= 36 {
+		digits = digits36[:base]
+	}
+
+	return epoch{
+		unit:   int64(unit),
+		digits: digits,
+	}
+}
+
+type epoch struct {
+	unit   int64
+	digits string
+}
+
+func (p epoch) Append(b []byte) []byte {
+	return appendUintBase(b, uint64(now().UnixNano()/p.unit), 0, p.digits)
+}
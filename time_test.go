@@ -0,0 +1,86 @@
+package pattern
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// setNow replaces the package clock for the duration of the test.
+func setNow(t *testing.T, f func() time.Time) {
+	t.Helper()
+	old := now
+	now = f
+	t.Cleanup(func() {
+		now = old
+	})
+}
+
+func TestTimestamp(t *testing.T) {
+	loc := time.FixedZone("UTC+9", 9*60*60)
+	setNow(t, func() time.Time {
+		return time.Date(2024, 1, 31, 20, 0, 0, 0, loc)
+	})
+
+	gen := New(Literal("ORD-"), Timestamp("20060102"), Literal("-"), TimestampUTC("20060102"))
+	p := gen.String()
+	if p != "ORD-20240131-20240131" {
+		t.Errorf("Timestamp returned invalid value: want \"ORD-20240131-20240131\", got %s", strconv.Quote(p))
+	}
+
+	setNow(t, func() time.Time {
+		return time.Date(2024, 2, 1, 8, 0, 0, 0, loc)
+	})
+
+	p = gen.String()
+	if p != "ORD-20240201-20240131" {
+		t.Errorf("Timestamp returned invalid value: want \"ORD-20240201-20240131\", got %s", strconv.Quote(p))
+	}
+}
+
+func TestEpochBase(t *testing.T) {
+	ts := time.Date(2024, 1, 31, 20, 0, 0, 0, time.UTC)
+	setNow(t, func() time.Time {
+		return ts
+	})
+
+	tests := []struct {
+		unit time.Duration
+		base int
+		want string
+	}{
+		{time.Second, 10, strconv.FormatInt(ts.Unix(), 10)},
+		{time.Second, 16, strconv.FormatInt(ts.Unix(), 16)},
+		{time.Millisecond, 36, strconv.FormatInt(ts.UnixMilli(), 36)},
+		{time.Millisecond, 62, "U2yMBIe"},
+	}
+
+	for _, tt := range tests {
+		p := New(EpochBase(tt.unit, tt.base)).String()
+		if p != tt.want {
+			t.Errorf("EpochBase(%v, %d) returned invalid value: want %s, got %s", tt.unit, tt.base, strconv.Quote(tt.want), strconv.Quote(p))
+		}
+	}
+}
+
+func TestEpochBasePanic(t *testing.T) {
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("EpochBase with unit == 0 did not panic")
+			}
+		}()
+
+		New(EpochBase(0, 16))
+	}()
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("EpochBase with base > 62 did not panic")
+			}
+		}()
+
+		New(EpochBase(time.Second, 63))
+	}()
+}
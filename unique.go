@@ -0,0 +1,88 @@
+package pattern
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrRetriesExhausted is returned when a value satisfying a constraint could not be generated within the allowed number of retries.
+var ErrRetriesExhausted = errors.New("pattern: retries exhausted")
+
+// uniqueRetries is the number of consecutive duplicates after which UniqueStrings gives up.
+const uniqueRetries = 100
+
+// UniqueStrings returns n distinct generated patterns.
+// If no new pattern is found within 100 consecutive tries, which usually means that the pattern can not generate n distinct values,
+// an error wrapping ErrRetriesExhausted is returned.
+// If n is < 0, an error wrapping ErrInvalidArgument is returned.
+func (g gen) UniqueStrings(n int) ([]string, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("%w: n must be >= 0", ErrInvalidArgument)
+	}
+
+	res := make([]string, 0, n)
+	seen := make(map[string]struct{}, n)
+
+	for retries := 0; len(res) < n; {
+		v := g.String()
+		if _, ok := seen[v]; ok {
+			retries++
+			if retries == uniqueRetries {
+				return nil, fmt.Errorf("%w: generated only %d of %d unique values", ErrRetriesExhausted, len(res), n)
+			}
+			continue
+		}
+
+		retries = 0
+		seen[v] = struct{}{}
+		res = append(res, v)
+	}
+
+	return res, nil
+}
+
+// UniqueBy returns a Part that regenerates p as long as exists reports that its output is already taken,
+// e.g. by looking it up in a database with a unique index.
+// exists must not retain the slice passed to it.
+//
+// The Part panics with an error wrapping ErrRetriesExhausted if the output of p still exists after maxRetries regenerations.
+func UniqueBy(p Part, exists func([]byte) bool, maxRetries int) Part {
+	if maxRetries < 0 {
+		panic("maxRetries must be >= 0")
+	}
+
+	return uniqueBy{
+		part:       p,
+		exists:     exists,
+		maxRetries: maxRetries,
+	}
+}
+
+type uniqueBy struct {
+	part       Part
+	exists     func([]byte) bool
+	maxRetries int
+}
+
+func (p uniqueBy) Append(b []byte) []byte {
+	return p.appendRun(nil, b)
+}
+
+func (p uniqueBy) appendRun(r *run, b []byte) []byte {
+	defer r.restoreLimit(r.suspendLimit())
+	start := len(b)
+	for i := 0; ; i++ {
+		b = appendRun(r, p.part, b[:start])
+		if !p.exists(b[start:]) {
+			return b
+		}
+
+		if i == p.maxRetries {
+			panic(fmt.Errorf("%w: value still exists after %d retries", ErrRetriesExhausted, p.maxRetries))
+		}
+	}
+}
+
+func (p uniqueBy) Children() []Part {
+	return []Part{p.part}
+}
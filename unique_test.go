@@ -0,0 +1,91 @@
+package pattern
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestUniqueStrings(t *testing.T) {
+	gen := New(Repeat(4, 4, OneOfByte([]byte("0123456789"))))
+
+	for _, n := range []int{0, 1, 100, 1000} {
+		values, err := gen.UniqueStrings(n)
+		if err != nil {
+			t.Fatalf("UniqueStrings(%d) returned error: %v", n, err)
+		}
+
+		if len(values) != n {
+			t.Errorf("UniqueStrings returned invalid number of values: want %d, got %d", n, len(values))
+		}
+
+		seen := make(map[string]bool, n)
+		for _, v := range values {
+			if seen[v] {
+				t.Errorf("UniqueStrings returned duplicate value %s", v)
+			}
+			seen[v] = true
+		}
+	}
+}
+
+func TestUniqueStringsExhausted(t *testing.T) {
+	gen := New(OneOfByte([]byte("ab")))
+
+	_, err := gen.UniqueStrings(3)
+	if !errors.Is(err, ErrRetriesExhausted) {
+		t.Errorf("UniqueStrings with too small output space returned invalid error: want %v, got %v", ErrRetriesExhausted, err)
+	}
+}
+
+func TestUniqueStringsNegative(t *testing.T) {
+	_, err := New(Literal("o")).UniqueStrings(-1)
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("UniqueStrings with n < 0 returned invalid error: want %v, got %v", ErrInvalidArgument, err)
+	}
+}
+
+func TestUniqueBy(t *testing.T) {
+	taken := map[string]bool{}
+	for _, v := range []string{"a", "b", "c"} {
+		taken[v] = true
+	}
+
+	exists := func(b []byte) bool {
+		return taken[string(b)]
+	}
+
+	gen := New(Literal("id-"), UniqueBy(OneOfByte([]byte("abcd")), exists, 1000))
+	for i := 0; i < 100; i++ {
+		v := gen.String()
+		if v != "id-d" {
+			t.Errorf("UniqueBy returned taken value: want \"id-d\", got %s", strconv.Quote(v))
+		}
+	}
+}
+
+func TestUniqueByExhausted(t *testing.T) {
+	calls := 0
+	exists := func(b []byte) bool {
+		calls++
+		return true
+	}
+
+	gen := New(UniqueBy(Literal("a"), exists, 5))
+
+	func() {
+		defer func() {
+			r := recover()
+			err, ok := r.(error)
+			if !ok || !errors.Is(err, ErrRetriesExhausted) {
+				t.Errorf("UniqueBy with exhausted retries panicked with invalid value: want %v, got %v", ErrRetriesExhausted, r)
+			}
+		}()
+
+		id = gen.String()
+	}()
+
+	if calls != 6 {
+		t.Errorf("UniqueBy called exists invalid number of times: want 6, got %d", calls)
+	}
+}
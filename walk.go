@@ -0,0 +1,67 @@
+package pattern
+
+// Parent is implemented by Parts that contain other Parts.
+// All built-in container Parts implement Parent;
+// custom Parts can implement it to be visited by Walk.
+type Parent interface {
+	// Children returns the Parts contained in the Part.
+	// The returned slice must not be modified.
+	Children() []Part
+}
+
+// Walk calls f for p and all Parts contained in p in depth-first order.
+// If f returns false, the Parts contained in the visited Part are skipped.
+// Parts shown by Explain as folded have already been replaced by the constructors
+// and are not visited.
+func Walk(p Part, f func(Part) bool) {
+	if !f(p) {
+		return
+	}
+	if v, ok := p.(Parent); ok {
+		for _, c := range v.Children() {
+			Walk(c, f)
+		}
+	}
+}
+
+// Describe returns a one-line description of p without its children,
+// as shown by Explain, e.g. "Sequence(1, 100, 1)" or "OneOfByte(\"abc\")".
+func Describe(p Part) string {
+	return explainPart(p)
+}
+
+// walk calls f for p and all Parts contained in p in depth-first order.
+func walk(p Part, f func(Part)) {
+	Walk(p, func(p Part) bool {
+		f(p)
+		return true
+	})
+}
+
+func (g gen) Children() []Part {
+	return g.parts
+}
+
+func (p group) Children() []Part {
+	return p
+}
+
+func (p repeat) Children() []Part {
+	return p.parts
+}
+
+func (p potentially50) Children() []Part {
+	return []Part{p.part}
+}
+
+func (p potentiallyP) Children() []Part {
+	return []Part{p.part}
+}
+
+func (p anyOf) Children() []Part {
+	return p.parts
+}
+
+func (p shuffle) Children() []Part {
+	return p.parts
+}
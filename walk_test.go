@@ -0,0 +1,82 @@
+package pattern
+
+import (
+	"reflect"
+	"testing"
+)
+
+// custom is a Part that is opaque to the package but exposes its children.
+type custom []Part
+
+func (p custom) Append(b []byte) []byte {
+	for _, c := range p {
+		b = c.Append(b)
+	}
+	return b
+}
+
+func (p custom) Children() []Part {
+	return p
+}
+
+func TestWalk(t *testing.T) {
+	gen := New(
+		Literal("a"),
+		OneOf(Literal("b"), Shuffle(Literal("c"), Literal("d"))),
+		custom{Literal("e"), Repeat(1, 2, Literal("f"))},
+	)
+
+	var got []string
+	Walk(gen, func(p Part) bool {
+		got = append(got, Describe(p))
+		return true
+	})
+	want := []string{
+		"New",
+		`Literal("a")`,
+		"OneOf",
+		`Literal("b")`,
+		"Shuffle",
+		`Literal("c")`,
+		`Literal("d")`,
+		"pattern.custom",
+		`Literal("e")`,
+		"Repeat(1, 2)",
+		`Literal("f")`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestWalkSkip(t *testing.T) {
+	gen := New(
+		Literal("a"),
+		OneOf(Literal("b"), Literal("c")),
+		Potentially(0.5, Literal("d")),
+	)
+
+	var got []string
+	Walk(gen, func(p Part) bool {
+		got = append(got, Describe(p))
+		_, ok := p.(anyOf)
+		return !ok
+	})
+	want := []string{"New", `Literal("a")`, "OneOf", "Potentially(0.5)", `Literal("d")`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestChildren(t *testing.T) {
+	b := Literal("b")
+	p := OneOf(Literal("a"), b)
+
+	c := p.(Parent).Children()
+	if len(c) != 2 || !reflect.DeepEqual(c[1], b) {
+		t.Errorf("got %v, want [a b]", c)
+	}
+	if _, ok := Literal("a").(Parent); ok {
+		t.Error("Literal implements Parent")
+	}
+}
@@ -0,0 +1,70 @@
+package pattern
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"strings"
+)
+
+var (
+	//go:embed wordlists/eff_large.txt
+	effLarge string
+	//go:embed wordlists/nouns.txt
+	nouns string
+	//go:embed wordlists/adjectives.txt
+	adjectives string
+)
+
+// WordList is a list of words for Word.
+type WordList []string
+
+// Embedded word lists for Word.
+// The slices are shared and must not be modified; use ReadWordList or a WordList literal for custom lists.
+var (
+	// EFFLarge holds the 7776 words of the EFF large wordlist for Diceware passphrases, about 12.9 bits of entropy per word.
+	// https://www.eff.org/deeplinks/2016/07/new-wordlists-random-passphrases
+	EFFLarge = mustReadWordList(effLarge)
+	// Nouns holds short, common English nouns, mostly animals, plants and places.
+	Nouns = mustReadWordList(nouns)
+	// Adjectives holds short, common and friendly English adjectives.
+	Adjectives = mustReadWordList(adjectives)
+)
+
+// Word returns a Part that will output one word of list randomly in each iteration, e.g.
+// Join("-", Word(EFFLarge), Word(EFFLarge), Word(EFFLarge), Word(EFFLarge)) for passphrases like "correct-horse-battery-staple".
+//
+// Panics if list is empty.
+func Word(list WordList) Part {
+	if len(list) == 0 {
+		panic("list must not be empty")
+	}
+
+	return OneOfString(list)
+}
+
+// ReadWordList reads a word list with one word per line from r.
+// Empty lines and lines starting with # are skipped. Only the last field of each line is used,
+// so Diceware lists with a roll before each word can be read as is.
+func ReadWordList(r io.Reader) (WordList, error) {
+	var list WordList
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		list = append(list, fields[len(fields)-1])
+	}
+	return list, s.Err()
+}
+
+func mustReadWordList(s string) WordList {
+	list, err := ReadWordList(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+	return list
+}
@@ -0,0 +1,84 @@
+package pattern
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWordLists(t *testing.T) {
+	tests := []struct {
+		name string
+		list WordList
+		len  int
+	}{
+		{"EFFLarge", EFFLarge, 7776},
+		{"Nouns", Nouns, len(Nouns)},
+		{"Adjectives", Adjectives, len(Adjectives)},
+	}
+
+	re := regexp.MustCompile(`^[a-z]+(-[a-z]+)?$`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.list) != tt.len || len(tt.list) < 100 {
+				t.Errorf("invalid number of words: want %d, got %d", tt.len, len(tt.list))
+			}
+
+			seen := make(map[string]bool, len(tt.list))
+			for _, w := range tt.list {
+				if !re.MatchString(w) {
+					t.Errorf("invalid word: %s", strconv.Quote(w))
+				}
+				if seen[w] {
+					t.Errorf("duplicate word: %s", strconv.Quote(w))
+				}
+				seen[w] = true
+			}
+		})
+	}
+}
+
+func TestWord(t *testing.T) {
+	// Some words contain hyphens.
+	gen := New(Join(" ", Word(EFFLarge), Word(EFFLarge), Word(EFFLarge), Word(EFFLarge)))
+
+	words := make(map[string]bool, len(EFFLarge))
+	for _, w := range EFFLarge {
+		words[w] = true
+	}
+
+	for i := 0; i < 100; i++ {
+		v := gen.String()
+		parts := strings.Split(v, " ")
+		if len(parts) != 4 {
+			t.Fatalf("Word returned invalid passphrase: %s", strconv.Quote(v))
+		}
+		for _, w := range parts {
+			if !words[w] {
+				t.Errorf("Word returned word not in the list: %s", strconv.Quote(w))
+			}
+		}
+	}
+}
+
+func TestReadWordList(t *testing.T) {
+	list, err := ReadWordList(strings.NewReader("# comment\n11111\tabacus\n\n  zoom  \r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (WordList{"abacus", "zoom"}); !reflect.DeepEqual(list, want) {
+		t.Errorf("ReadWordList returned invalid list: want %q, got %q", want, list)
+	}
+}
+
+func TestWordPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Word did not panic on an empty list")
+		}
+	}()
+	Word(nil)
+}
@@ -0,0 +1,44 @@
+package pattern
+
+import (
+	"math"
+)
+
+// OneOfZipf returns a Part that selects one of parts randomly in each iteration with a Zipf distribution,
+// where the k-th Part (starting at 0) is selected with a probability proportional to (v + k)^-s,
+// e.g. to generate skewed workloads with hot keys or popular products.
+// A larger s makes the first Parts more likely, a larger v flattens the head of the distribution.
+// The parameters are those of math/rand.Zipf, except that s only has to be > 0, since parts is finite.
+//
+// Panics if parts is empty, s is <= 0 or v is < 1.
+func OneOfZipf(s float64, v float64, parts ...Part) Part {
+	if len(parts) == 0 {
+		panic("parts must not be empty")
+	}
+
+	if !(s > 0) || math.IsInf(s, 0) {
+		panic("s must be > 0")
+	}
+
+	if !(v >= 1) || math.IsInf(v, 0) {
+		panic("v must be >= 1")
+	}
+
+	choices := make([]WeightedChoice, len(parts))
+	for k, p := range parts {
+		choices[k] = WeightedChoice{Weight: math.Pow(v+float64(k), -s), Part: p}
+	}
+
+	return zipf{
+		choose: Choose(choices...).(choose),
+		s:      s,
+		v:      v,
+	}
+}
+
+// zipf is a Choose with Zipf distributed weights.
+type zipf struct {
+	choose
+	s float64
+	v float64
+}
@@ -0,0 +1,54 @@
+package pattern
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOneOfZipf(t *testing.T) {
+	parts := []Part{Literal("0"), Literal("1"), Literal("2"), Literal("3"), Literal("4")}
+	gen := New(OneOfZipf(2, 1, parts...))
+
+	const n = 100000
+	counts := make([]int, len(parts))
+	for i := 0; i < n; i++ {
+		counts[gen.String()[0]-'0']++
+	}
+
+	// 1/(1+k)^2 normalized by 1 + 1/4 + 1/9 + 1/16 + 1/25.
+	total := 1 + 1.0/4 + 1.0/9 + 1.0/16 + 1.0/25
+	for k, c := range counts {
+		want := math.Pow(1+float64(k), -2) / total
+		if got := float64(c) / n; math.Abs(got-want) > 0.01 {
+			t.Errorf("OneOfZipf selected Part %d with invalid frequency: want %v, got %v", k, want, got)
+		}
+	}
+
+	if got := explainPart(OneOfZipf(1.5, 2, parts...)); got != "OneOfZipf(1.5, 2)" {
+		t.Errorf("Explain returned invalid description: %s", got)
+	}
+}
+
+func TestOneOfZipfPanic(t *testing.T) {
+	tests := []struct {
+		name  string
+		s, v  float64
+		parts []Part
+	}{
+		{"empty", 1, 1, nil},
+		{"s", 0, 1, []Part{Literal("a")}},
+		{"s NaN", math.NaN(), 1, []Part{Literal("a")}},
+		{"v", 1, 0.5, []Part{Literal("a")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("OneOfZipf did not panic")
+				}
+			}()
+			OneOfZipf(tt.s, tt.v, tt.parts...)
+		})
+	}
+}